@@ -73,6 +73,10 @@ func runAllCommand(cmd *cobra.Command, args []string) error {
 	fmt.Println("  • k8s-cli cost")
 	fmt.Println("  • k8s-cli workload")
 	fmt.Println("  • k8s-cli logs")
+	fmt.Println("  • k8s-cli network")
+	fmt.Println("  • k8s-cli images")
+	fmt.Println("  • k8s-cli nodes")
+	fmt.Println("  • k8s-cli capacity")
 	fmt.Println("  • k8s-cli export --format json")
 
 	return nil
@@ -282,6 +286,18 @@ func showWorkloadHealth(client *kubernetes.Client) error {
 		fmt.Sprintf("%d", analysis.WorkloadSummary.HealthyDaemonSets),
 		fmt.Sprintf("%d", analysis.WorkloadSummary.TotalDaemonSets-analysis.WorkloadSummary.HealthyDaemonSets),
 	})
+	workloadTable.AddRow([]string{
+		"Jobs",
+		fmt.Sprintf("%d", analysis.WorkloadSummary.TotalJobs),
+		fmt.Sprintf("%d", analysis.WorkloadSummary.HealthyJobs),
+		fmt.Sprintf("%d", analysis.WorkloadSummary.TotalJobs-analysis.WorkloadSummary.HealthyJobs),
+	})
+	workloadTable.AddRow([]string{
+		"CronJobs",
+		fmt.Sprintf("%d", analysis.WorkloadSummary.TotalCronJobs),
+		fmt.Sprintf("%d", analysis.WorkloadSummary.HealthyCronJobs),
+		fmt.Sprintf("%d", analysis.WorkloadSummary.TotalCronJobs-analysis.WorkloadSummary.HealthyCronJobs),
+	})
 
 	healthStatus := fmt.Sprintf("%d/100", analysis.WorkloadSummary.OverallHealthScore)
 	if analysis.WorkloadSummary.CriticalIssues > 0 {
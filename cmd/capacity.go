@@ -0,0 +1,226 @@
+package cmd
+
+import (
+	"fmt"
+	"strings"
+
+	"k8s-cli/pkg/kubernetes"
+	"k8s-cli/pkg/table"
+
+	"github.com/spf13/cobra"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+)
+
+var capacityCmd = &cobra.Command{
+	Use:   "capacity",
+	Short: "Capacity planning and bin-packing simulation",
+	Long: `Simulate pod placement onto nodes using allocatable resources, taints, node and pod affinity and topology spread constraints.
+
+  k8s-cli capacity --replicas-of deploy/api -n prod   how many more replicas fit?
+  k8s-cli capacity --cpu 500m --memory 1Gi            how many pods of this size fit?
+  k8s-cli capacity --drain node-1                     can node-1 be drained?
+
+Without a question, the command sizes the cluster for its current requests at the target utilization and recommends the cheapest node count and instance mix from the pricing catalog.`,
+	RunE: runCapacityCommand,
+}
+
+var (
+	capacityNamespace  string
+	capacityReplicasOf string
+	capacityCPU        string
+	capacityMemory     string
+	capacityDrain      string
+	capacityTarget     float64
+)
+
+func init() {
+	rootCmd.AddCommand(capacityCmd)
+	capacityCmd.Flags().StringVarP(&capacityNamespace, "namespace", "n", "default", "Namespace of the workload or hypothetical pod")
+	capacityCmd.Flags().StringVar(&capacityReplicasOf, "replicas-of", "", "Workload whose pod spec is simulated (deploy/name, sts/name or pod/name)")
+	capacityCmd.Flags().StringVar(&capacityCPU, "cpu", "", "CPU request of a hypothetical pod (e.g. 500m)")
+	capacityCmd.Flags().StringVar(&capacityMemory, "memory", "", "Memory request of a hypothetical pod (e.g. 1Gi)")
+	capacityCmd.Flags().StringVar(&capacityDrain, "drain", "", "Simulate draining this node")
+	capacityCmd.Flags().Float64Var(&capacityTarget, "target-utilization", 0.7, "Target request utilization for node sizing (0-1)")
+}
+
+func runCapacityCommand(cmd *cobra.Command, args []string) error {
+	kubeconfig, _ := cmd.Flags().GetString("kubeconfig")
+
+	client, err := kubernetes.NewClient(kubeconfig)
+	if err != nil {
+		return fmt.Errorf("failed to create Kubernetes client: %w", err)
+	}
+
+	fmt.Println("📐 Capacity Planning")
+	fmt.Println(strings.Repeat("=", 80))
+	fmt.Println()
+
+	asked := false
+
+	if capacityReplicasOf != "" || capacityCPU != "" || capacityMemory != "" {
+		asked = true
+		template, err := capacityPodTemplate(client)
+		if err != nil {
+			return err
+		}
+
+		headroom, err := client.GetReplicaHeadroom(template)
+		if err != nil {
+			return fmt.Errorf("failed to simulate replicas: %w", err)
+		}
+		showReplicaHeadroom(headroom)
+	}
+
+	if capacityDrain != "" {
+		asked = true
+		simulation, err := client.SimulateDrain(capacityDrain)
+		if err != nil {
+			return fmt.Errorf("failed to simulate drain: %w", err)
+		}
+		showDrainSimulation(simulation)
+	}
+
+	if !asked {
+		plan, err := client.GetCapacityPlan(capacityTarget)
+		if err != nil {
+			return fmt.Errorf("failed to plan capacity: %w", err)
+		}
+		showCapacityPlan(plan)
+	}
+
+	return nil
+}
+
+func capacityPodTemplate(client *kubernetes.Client) (*corev1.Pod, error) {
+	if capacityReplicasOf != "" {
+		if capacityCPU != "" || capacityMemory != "" {
+			return nil, fmt.Errorf("--replicas-of cannot be combined with --cpu or --memory")
+		}
+		return client.GetPodTemplate(capacityReplicasOf, capacityNamespace)
+	}
+
+	var cpu, memory resource.Quantity
+	var err error
+	if capacityCPU != "" {
+		if cpu, err = resource.ParseQuantity(capacityCPU); err != nil {
+			return nil, fmt.Errorf("invalid --cpu %q: %w", capacityCPU, err)
+		}
+	}
+	if capacityMemory != "" {
+		if memory, err = resource.ParseQuantity(capacityMemory); err != nil {
+			return nil, fmt.Errorf("invalid --memory %q: %w", capacityMemory, err)
+		}
+	}
+
+	return kubernetes.NewHypotheticalPod(capacityNamespace, cpu, memory), nil
+}
+
+func showReplicaHeadroom(headroom *kubernetes.ReplicaHeadroom) {
+	fmt.Printf("🧮 REPLICA HEADROOM: %s/%s\n", headroom.Namespace, headroom.Workload)
+	fmt.Println(strings.Repeat("-", 40))
+	fmt.Printf("Pod requests: cpu %s, memory %s\n", formatMilliCores(headroom.CPURequest), formatMemoryBytes(headroom.MemoryRequest))
+
+	if headroom.Capped {
+		fmt.Printf("🟢 At least %d more replicas fit (simulation limit reached)\n", headroom.Replicas)
+	} else if headroom.Replicas == 0 {
+		fmt.Println("🔴 No additional replicas fit on any node")
+	} else {
+		fmt.Printf("🟡 %d more replicas fit\n", headroom.Replicas)
+	}
+	fmt.Println()
+
+	if len(headroom.PerNode) > 0 {
+		nodeTable := table.NewTable([]string{"Node", "Additional Replicas"})
+		for _, node := range headroom.PerNode {
+			nodeTable.AddRow([]string{node.Node, fmt.Sprintf("%d", node.Replicas)})
+		}
+		nodeTable.Render()
+		fmt.Println()
+	}
+
+	if len(headroom.BlockingReasons) > 0 {
+		fmt.Println("💡 The next replica does not fit because:")
+		for _, reason := range headroom.BlockingReasons {
+			fmt.Printf("  • %s\n", reason)
+		}
+		fmt.Println()
+	}
+}
+
+func showDrainSimulation(simulation *kubernetes.DrainSimulation) {
+	fmt.Printf("🚧 DRAIN SIMULATION: %s\n", simulation.Node)
+	fmt.Println(strings.Repeat("-", 40))
+
+	if len(simulation.Placements) == 0 {
+		fmt.Printf("✅ No evictable pods on %s (%d DaemonSet/static pods skipped)\n\n", simulation.Node, simulation.SkippedPods)
+		return
+	}
+
+	if simulation.Feasible {
+		fmt.Printf("🟢 All %d pods can be rescheduled; %s can be drained\n", len(simulation.Placements), simulation.Node)
+	} else {
+		fmt.Printf("🔴 %s cannot be drained without adding capacity\n", simulation.Node)
+	}
+	fmt.Printf("Skipped %d DaemonSet/static pods\n\n", simulation.SkippedPods)
+
+	placementTable := table.NewTable([]string{"Pod", "Namespace", "Workload", "Target Node"})
+	for _, placement := range simulation.Placements {
+		target := "🟢 " + placement.TargetNode
+		if placement.TargetNode == "" {
+			target = "🔴 " + placement.Reason
+		}
+		placementTable.AddRow([]string{placement.Pod, placement.Namespace, placement.Workload, target})
+	}
+	placementTable.Render()
+	fmt.Println()
+}
+
+func showCapacityPlan(plan *kubernetes.CapacityPlan) {
+	fmt.Printf("📊 NODE SIZING (target utilization %.0f%%)\n", plan.TargetUtilization*100)
+	fmt.Println(strings.Repeat("-", 40))
+
+	summaryTable := table.NewTable([]string{"Metric", "Value"})
+	summaryTable.AddRow([]string{"Pod CPU Requests", formatMilliCores(plan.CPURequests)})
+	summaryTable.AddRow([]string{"Pod Memory Requests", formatMemoryBytes(plan.MemoryRequests)})
+	summaryTable.AddRow([]string{"DaemonSet Overhead per Node", fmt.Sprintf("%s / %s", formatMilliCores(plan.DaemonSetCPU), formatMemoryBytes(plan.DaemonSetMemory))})
+	summaryTable.AddRow([]string{"Largest Pod", fmt.Sprintf("%s / %s", formatMilliCores(plan.LargestPodCPU), formatMemoryBytes(plan.LargestPodMemory))})
+	summaryTable.AddRow([]string{"Current Nodes", fmt.Sprintf("%d", plan.CurrentNodes)})
+	summaryTable.AddRow([]string{"Current Monthly Cost", fmt.Sprintf("$%.2f", plan.CurrentMonthlyCost)})
+	if plan.PendingPods > 0 {
+		summaryTable.AddRow([]string{"Pending Pods Placeable Now", fmt.Sprintf("%d/%d", plan.PendingPodsPlaceable, plan.PendingPods)})
+	}
+	summaryTable.Render()
+	fmt.Println()
+
+	if len(plan.Options) == 0 {
+		fmt.Println("🔴 No instance type in the pricing catalog fits the largest pod at this target utilization.")
+		fmt.Println()
+		return
+	}
+
+	fmt.Println("💰 RECOMMENDED NODE POOLS")
+	fmt.Println(strings.Repeat("-", 40))
+
+	optionTable := table.NewTable([]string{"Rank", "Instances", "Nodes", "CPU Util", "Memory Util", "Monthly Cost", "vs Current"})
+	for i, option := range plan.Options {
+		var instances []string
+		for _, instance := range option.Instances {
+			instances = append(instances, fmt.Sprintf("%d x %s", instance.Count, instance.Type))
+		}
+
+		optionTable.AddRow([]string{
+			fmt.Sprintf("%d", i+1),
+			strings.Join(instances, " + "),
+			fmt.Sprintf("%d", option.TotalNodes),
+			fmt.Sprintf("%.0f%%", option.CPUUtilization),
+			fmt.Sprintf("%.0f%%", option.MemoryUtilization),
+			fmt.Sprintf("$%.2f", option.MonthlyCost),
+			fmt.Sprintf("%+.2f", option.MonthlyCost-plan.CurrentMonthlyCost),
+		})
+	}
+	optionTable.Render()
+	fmt.Println()
+	fmt.Println("💡 Costs use the simplified pricing catalog of 'k8s-cli cost'; requests are packed in aggregate, not per pod.")
+	fmt.Println()
+}
@@ -0,0 +1,115 @@
+package cmd
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"k8s-cli/pkg/kubernetes"
+	"k8s-cli/pkg/table"
+
+	"github.com/spf13/cobra"
+)
+
+var cleanupReportCmd = &cobra.Command{
+	Use:   "cleanup-report",
+	Short: "Find orphaned and unused objects",
+	Long:  `List waste objects: ConfigMaps and Secrets no pod references, PVCs no pod mounts, Services without endpoints, ReplicaSets scaled to 0 beyond revisionHistoryLimit, finished Jobs, Evicted pods, empty namespaces and ServiceAccounts no pod uses. Each entry shows its age and owner; volumes and load balancers are priced with the cost catalog.`,
+	RunE:  runCleanupReportCommand,
+}
+
+var (
+	cleanupNamespace string
+	cleanupCategory  string
+)
+
+func init() {
+	rootCmd.AddCommand(cleanupReportCmd)
+	cleanupReportCmd.Flags().StringVarP(&cleanupNamespace, "namespace", "n", "", "Namespace to inspect (empty for all)")
+	cleanupReportCmd.Flags().StringVar(&cleanupCategory, "category", "", "Only show one category (e.g. \"Unused Secret\")")
+}
+
+func runCleanupReportCommand(cmd *cobra.Command, args []string) error {
+	kubeconfig, _ := cmd.Flags().GetString("kubeconfig")
+
+	client, err := kubernetes.NewClient(kubeconfig)
+	if err != nil {
+		return fmt.Errorf("failed to create Kubernetes client: %w", err)
+	}
+
+	report, err := client.GetCleanupReport(cleanupNamespace)
+	if err != nil {
+		return fmt.Errorf("failed to build cleanup report: %w", err)
+	}
+
+	fmt.Println("🧹 Cleanup Report")
+	fmt.Println(strings.Repeat("=", 80))
+	fmt.Println()
+
+	if len(report.Findings) == 0 {
+		fmt.Println("✅ No orphaned or unused resources found!")
+		return nil
+	}
+
+	showCleanupSummary(report)
+
+	byCategory := make(map[string][]kubernetes.CleanupFinding)
+	for _, finding := range report.Findings {
+		if cleanupCategory != "" && !strings.EqualFold(finding.Category, cleanupCategory) {
+			continue
+		}
+		byCategory[finding.Category] = append(byCategory[finding.Category], finding)
+	}
+
+	categories := make([]string, 0, len(byCategory))
+	for category := range byCategory {
+		categories = append(categories, category)
+	}
+	sort.Strings(categories)
+
+	fixRules := make(map[string]bool)
+	for _, category := range categories {
+		findings := byCategory[category]
+
+		fmt.Printf("🗑️  %s (%d)\n", strings.ToUpper(category), len(findings))
+		fmt.Println(strings.Repeat("-", 40))
+
+		findingTable := table.NewTable([]string{"Name", "Namespace", "Age", "Owner", "Reason", "Monthly Cost"})
+		for _, finding := range findings {
+			cost := "-"
+			if finding.MonthlyCost > 0 {
+				cost = fmt.Sprintf("$%.2f", finding.MonthlyCost)
+			}
+			findingTable.AddRow([]string{finding.Name, finding.Namespace, finding.Age, finding.Owner, finding.Reason, cost})
+			if finding.FixRule != "" {
+				fixRules[finding.FixRule] = true
+			}
+		}
+		findingTable.Render()
+		fmt.Println()
+	}
+
+	for _, rule := range []string{"completed-jobs", "failed-pods"} {
+		if fixRules[rule] {
+			fmt.Printf("💡 Remove these safely with 'k8s-cli fix %s'\n", rule)
+		}
+	}
+
+	return nil
+}
+
+func showCleanupSummary(report *kubernetes.CleanupReport) {
+	categories := make([]string, 0, len(report.Counts))
+	for category := range report.Counts {
+		categories = append(categories, category)
+	}
+	sort.Strings(categories)
+
+	summaryTable := table.NewTable([]string{"Category", "Objects"})
+	for _, category := range categories {
+		summaryTable.AddRow([]string{category, fmt.Sprintf("%d", report.Counts[category])})
+	}
+	summaryTable.AddRow([]string{"Priceable Waste", fmt.Sprintf("$%.2f/month", report.MonthlyCost)})
+	summaryTable.Render()
+	fmt.Println()
+}
@@ -1,7 +1,52 @@
 package cmd
 
-import "testing"
+import (
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"testing"
+)
 
 func TestCmdDummy(t *testing.T) {
 	t.Log("cmd test running")
 }
+
+func TestLoadLintManifests(t *testing.T) {
+	dir := t.TempDir()
+	write := func(name, content string) {
+		path := filepath.Join(dir, name)
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	write("app.yaml", "apiVersion: v1\nkind: Service\nmetadata: {name: web}\n---\napiVersion: apps/v1\nkind: Deployment\nmetadata: {name: web}\n")
+	write("overlays/prod/job.yml", "apiVersion: batch/v1\nkind: Job\nmetadata: {name: migrate}\n")
+	write("README.md", "kind: NotAManifest\n")
+	write("values.json", `{"kind": "Ignored"}`)
+
+	manifests, files, err := loadLintManifests([]string{dir})
+	if err != nil {
+		t.Fatalf("failed to load manifests: %v", err)
+	}
+	if files != 2 {
+		t.Errorf("expected only the .yaml and .yml files to be read, got %d", files)
+	}
+
+	var names []string
+	for _, manifest := range manifests {
+		names = append(names, manifest.Kind+"/"+manifest.Name)
+	}
+	sort.Strings(names)
+	if strings.Join(names, ",") != "Deployment/web,Job/migrate,Service/web" {
+		t.Errorf("unexpected manifests %v", names)
+	}
+
+	if _, _, err := loadLintManifests([]string{filepath.Join(dir, "missing")}); err == nil {
+		t.Errorf("expected a missing path to fail")
+	}
+}
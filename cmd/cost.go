@@ -2,6 +2,7 @@ package cmd
 
 import (
 	"fmt"
+	"sort"
 	"strings"
 
 	"k8s-cli/pkg/kubernetes"
@@ -22,6 +23,7 @@ var (
 	showCostNamespaces    bool
 	showCostUnderutilized bool
 	showCostOptimizations bool
+	showCostConsolidation bool
 )
 
 func init() {
@@ -30,6 +32,7 @@ func init() {
 	costCmd.Flags().BoolVar(&showCostNamespaces, "namespaces", true, "Show namespace cost analysis")
 	costCmd.Flags().BoolVar(&showCostUnderutilized, "underutilized", true, "Show underutilized resources")
 	costCmd.Flags().BoolVar(&showCostOptimizations, "optimizations", true, "Show cost optimization recommendations")
+	costCmd.Flags().BoolVar(&showCostConsolidation, "consolidation", true, "Show the node consolidation plan")
 }
 
 func runCostCommand(cmd *cobra.Command, args []string) error {
@@ -61,6 +64,11 @@ func runCostCommand(cmd *cobra.Command, args []string) error {
 
 	if showCostUnderutilized {
 		showUnderutilizedResources(analysis.UnderutilizedResources)
+		showUnusedResources(analysis.UnusedResources)
+	}
+
+	if showCostConsolidation && analysis.Consolidation != nil {
+		showConsolidationPlan(analysis.Consolidation)
 	}
 
 	if showCostOptimizations {
@@ -190,6 +198,33 @@ func showUnderutilizedResources(resources []kubernetes.UnderutilizedResource) {
 	fmt.Println()
 }
 
+func showUnusedResources(findings []kubernetes.CleanupFinding) {
+	if len(findings) == 0 {
+		return
+	}
+
+	fmt.Println("🗑️  UNUSED RESOURCES")
+	fmt.Println(strings.Repeat("-", 40))
+
+	unusedTable := table.NewTable([]string{"Kind", "Name", "Namespace", "Age", "Reason", "Monthly Cost"})
+	total := 0.0
+	for _, finding := range findings {
+		total += finding.MonthlyCost
+		unusedTable.AddRow([]string{
+			finding.Kind,
+			finding.Name,
+			finding.Namespace,
+			finding.Age,
+			finding.Reason,
+			fmt.Sprintf("$%.2f", finding.MonthlyCost),
+		})
+	}
+	unusedTable.Render()
+
+	fmt.Printf("\n💡 Unused resources cost $%.2f/month. See 'k8s-cli cleanup-report' for all orphaned objects.\n", total)
+	fmt.Println()
+}
+
 func showOptimizationRecommendations(optimizations []kubernetes.CostOptimization) {
 	if len(optimizations) == 0 {
 		return
@@ -233,3 +268,53 @@ func showOptimizationRecommendations(optimizations []kubernetes.CostOptimization
 	}
 	fmt.Println()
 }
+
+func showConsolidationPlan(plan *kubernetes.ConsolidationPlan) {
+	fmt.Println("🧩 NODE CONSOLIDATION PLAN")
+	fmt.Println(strings.Repeat("-", 40))
+
+	if len(plan.RemovableNodes) == 0 {
+		fmt.Println("✅ No node can be removed without blocking or unschedulable pods.")
+	} else {
+		nodeTable := table.NewTable([]string{"Order", "Node", "Type", "Monthly Cost", "Pods Moved", "Targets"})
+		for i, node := range plan.RemovableNodes {
+			targets := make(map[string]bool)
+			for _, move := range node.Moves {
+				targets[move.TargetNode] = true
+			}
+			var targetNames []string
+			for target := range targets {
+				targetNames = append(targetNames, target)
+			}
+			sort.Strings(targetNames)
+
+			nodeTable.AddRow([]string{
+				fmt.Sprintf("%d", i+1),
+				node.Node,
+				node.Type,
+				fmt.Sprintf("$%.2f", node.MonthlyCost),
+				fmt.Sprintf("%d", len(node.Moves)),
+				strings.Join(targetNames, ", "),
+			})
+		}
+		nodeTable.Render()
+		fmt.Printf("\n💰 Removing these nodes saves $%.2f/month\n", plan.MonthlySavings)
+	}
+	fmt.Println()
+
+	if len(plan.BlockedNodes) > 0 {
+		fmt.Println("Nodes that cannot be removed:")
+		for i, node := range plan.BlockedNodes {
+			if i >= 10 {
+				fmt.Printf("... and %d more nodes\n", len(plan.BlockedNodes)-10)
+				break
+			}
+			fmt.Printf("🔴 %s: %s", node.Node, node.Blockers[0])
+			if len(node.Blockers) > 1 {
+				fmt.Printf(" (+%d more)", len(node.Blockers)-1)
+			}
+			fmt.Println()
+		}
+		fmt.Println()
+	}
+}
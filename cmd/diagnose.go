@@ -0,0 +1,219 @@
+package cmd
+
+import (
+	"fmt"
+	"strings"
+
+	"k8s-cli/pkg/kubernetes"
+	"k8s-cli/pkg/table"
+
+	"github.com/spf13/cobra"
+)
+
+var diagnoseCmd = &cobra.Command{
+	Use:   "diagnose <pod/name|deploy/name>",
+	Short: "Diagnose why a pod or deployment is crash-looping or unhealthy",
+	Long:  `Combine container termination state, previous logs, recent events, probe configuration and failures, resource limits versus usage and node conditions into a ranked list of likely causes with suggested fixes.`,
+	Args:  cobra.ExactArgs(1),
+	RunE:  runDiagnoseCommand,
+}
+
+var (
+	diagnoseNamespace string
+	diagnoseTailLines int64
+	diagnoseMaxPods   int
+	diagnoseShowLogs  int
+)
+
+func init() {
+	rootCmd.AddCommand(diagnoseCmd)
+	diagnoseCmd.Flags().StringVarP(&diagnoseNamespace, "namespace", "n", "default", "Namespace of the pod or deployment")
+	diagnoseCmd.Flags().Int64Var(&diagnoseTailLines, "tail", 100, "Number of log lines to read per container")
+	diagnoseCmd.Flags().IntVar(&diagnoseMaxPods, "max-pods", 3, "Maximum number of deployment pods to diagnose")
+	diagnoseCmd.Flags().IntVar(&diagnoseShowLogs, "show-logs", 10, "Number of log lines to display per container (0 to hide)")
+}
+
+func runDiagnoseCommand(cmd *cobra.Command, args []string) error {
+	kubeconfig, _ := cmd.Flags().GetString("kubeconfig")
+
+	client, err := kubernetes.NewClient(kubeconfig)
+	if err != nil {
+		return fmt.Errorf("failed to create Kubernetes client: %w", err)
+	}
+
+	diagnoses, err := client.DiagnoseTarget(args[0], diagnoseNamespace, diagnoseTailLines, diagnoseMaxPods)
+	if err != nil {
+		return fmt.Errorf("failed to diagnose %s: %w", args[0], err)
+	}
+
+	for _, diagnosis := range diagnoses {
+		showPodDiagnosis(&diagnosis)
+	}
+
+	return nil
+}
+
+func showPodDiagnosis(diagnosis *kubernetes.PodDiagnosis) {
+	fmt.Printf("🩺 Diagnosis: pod/%s (namespace %s)\n", diagnosis.Pod, diagnosis.Namespace)
+	fmt.Println(strings.Repeat("=", 80))
+	fmt.Println()
+
+	overviewTable := table.NewTable([]string{"Metric", "Value"})
+	overviewTable.AddRow([]string{"Phase", diagnosis.Phase})
+	overviewTable.AddRow([]string{"Node", valueOrUnknown(diagnosis.Node)})
+	overviewTable.AddRow([]string{"Restarts", fmt.Sprintf("%d", diagnosis.Restarts)})
+	overviewTable.Render()
+	fmt.Println()
+
+	fmt.Println("📦 CONTAINERS")
+	fmt.Println(strings.Repeat("-", 40))
+
+	containerTable := table.NewTable([]string{"Container", "State", "Ready", "Restarts", "Last Exit", "CPU Usage/Limit", "Memory Usage/Limit"})
+	for _, container := range diagnosis.Containers {
+		lastExit := "-"
+		if container.RestartCount > 0 || container.ExitCode != 0 {
+			lastExit = fmt.Sprintf("%d", container.ExitCode)
+			if container.Reason != "" {
+				lastExit += " " + container.Reason
+			}
+			if container.Signal != "" {
+				lastExit += " (" + container.Signal + ")"
+			}
+		}
+
+		ready := "🔴 No"
+		if container.Ready {
+			ready = "🟢 Yes"
+		}
+
+		containerTable.AddRow([]string{
+			container.Name,
+			container.State,
+			ready,
+			fmt.Sprintf("%d", container.RestartCount),
+			lastExit,
+			formatUsageLimit(container.CPUUsage, container.CPULimit, formatMilliCores),
+			formatUsageLimit(container.MemoryUsage, container.MemoryLimit, formatMemoryBytes),
+		})
+	}
+	containerTable.Render()
+	fmt.Println()
+
+	fmt.Println("🔎 PROBES")
+	fmt.Println(strings.Repeat("-", 40))
+	for _, container := range diagnosis.Containers {
+		fmt.Printf("  %s\n", container.Name)
+		fmt.Printf("    liveness:  %s\n", container.LivenessProbe)
+		fmt.Printf("    readiness: %s\n", container.ReadinessProbe)
+		fmt.Printf("    startup:   %s\n", container.StartupProbe)
+	}
+	fmt.Println()
+
+	if len(diagnosis.NodeConditions) > 0 {
+		fmt.Println("🖥️  NODE CONDITIONS")
+		fmt.Println(strings.Repeat("-", 40))
+		for _, condition := range diagnosis.NodeConditions {
+			fmt.Printf("  • %s\n", condition)
+		}
+		fmt.Println()
+	}
+
+	if len(diagnosis.Events) > 0 {
+		fmt.Println("📋 RECENT EVENTS")
+		fmt.Println(strings.Repeat("-", 40))
+
+		eventTable := table.NewTable([]string{"Last Seen", "Type", "Reason", "Count", "Message"})
+		for i, event := range diagnosis.Events {
+			if i >= 10 {
+				break
+			}
+			message := event.Message
+			if len(message) > 60 {
+				message = message[:57] + "..."
+			}
+			eventTable.AddRow([]string{
+				event.LastTime.Format("01-02 15:04"),
+				event.Type,
+				event.Reason,
+				fmt.Sprintf("%d", event.Count),
+				message,
+			})
+		}
+		eventTable.Render()
+		fmt.Println()
+	}
+
+	if diagnoseShowLogs > 0 {
+		for _, container := range diagnosis.Containers {
+			if len(container.LogTail) == 0 {
+				continue
+			}
+
+			source := "current"
+			if container.PreviousLogs {
+				source = "previous"
+			}
+			fmt.Printf("📜 LOG TAIL: %s (%s instance)\n", container.Name, source)
+			fmt.Println(strings.Repeat("-", 40))
+
+			lines := container.LogTail
+			if len(lines) > diagnoseShowLogs {
+				lines = lines[len(lines)-diagnoseShowLogs:]
+			}
+			for _, line := range lines {
+				fmt.Printf("  %s\n", line)
+			}
+			fmt.Println()
+		}
+	}
+
+	fmt.Println("🧭 LIKELY CAUSES")
+	fmt.Println(strings.Repeat("-", 40))
+
+	if len(diagnosis.Causes) == 0 {
+		fmt.Println("✅ No problems detected for this pod!")
+		fmt.Println()
+		return
+	}
+
+	for i, cause := range diagnosis.Causes {
+		likelihood := "🟢 Low"
+		if cause.Confidence >= 75 {
+			likelihood = "🔴 High"
+		} else if cause.Confidence >= 50 {
+			likelihood = "🟡 Medium"
+		}
+
+		title := cause.Title
+		if cause.Container != "" {
+			title += fmt.Sprintf(" [%s]", cause.Container)
+		}
+
+		fmt.Printf("%d. %s (%s, %d%%)\n", i+1, title, likelihood, cause.Confidence)
+		for _, evidence := range cause.Evidence {
+			fmt.Printf("   • %s\n", evidence)
+		}
+		fmt.Printf("   💡 %s\n", cause.Fix)
+		fmt.Println()
+	}
+}
+
+func formatUsageLimit(usage, limit int64, format func(int64) string) string {
+	usageStr := "-"
+	if usage > 0 {
+		usageStr = format(usage)
+	}
+	limitStr := "none"
+	if limit > 0 {
+		limitStr = format(limit)
+	}
+	return usageStr + " / " + limitStr
+}
+
+func formatMilliCores(milliCores int64) string {
+	return fmt.Sprintf("%dm", milliCores)
+}
+
+func formatMemoryBytes(bytes int64) string {
+	return fmt.Sprintf("%dMi", bytes/(1024*1024))
+}
@@ -0,0 +1,172 @@
+package cmd
+
+import (
+	"fmt"
+	"strings"
+
+	"k8s-cli/pkg/export"
+	"k8s-cli/pkg/recommendations"
+	"k8s-cli/pkg/table"
+
+	"github.com/spf13/cobra"
+)
+
+var diffCmd = &cobra.Command{
+	Use:   "diff <before.json> <after.json>",
+	Short: "Compare two saved snapshots",
+	Long:  `Show what changed between two snapshots taken with 'k8s-cli snapshot save': added and removed nodes, control plane, kubelet and component version changes, cost deltas by namespace, health score changes, and new or resolved recommendations. Works offline; the cluster is not contacted.`,
+	Args:  cobra.ExactArgs(2),
+	RunE:  runDiffCommand,
+}
+
+func init() {
+	rootCmd.AddCommand(diffCmd)
+}
+
+func runDiffCommand(cmd *cobra.Command, args []string) error {
+	before, err := export.LoadSnapshot(args[0])
+	if err != nil {
+		return err
+	}
+	after, err := export.LoadSnapshot(args[1])
+	if err != nil {
+		return err
+	}
+
+	diff := export.DiffSnapshots(before, after)
+
+	fmt.Println("🔀 Snapshot Diff")
+	fmt.Println(strings.Repeat("=", 80))
+	fmt.Printf("Before: %s (%s)\n", args[0], diff.Before.Format("2006-01-02 15:04:05"))
+	fmt.Printf("After:  %s (%s)\n", args[1], diff.After.Format("2006-01-02 15:04:05"))
+	if before.Server != "" && after.Server != "" && before.Server != after.Server {
+		fmt.Printf("⚠️  Snapshots come from different API servers (%s vs %s)\n", before.Server, after.Server)
+	}
+	fmt.Println()
+
+	if !diff.HasChanges() {
+		fmt.Println("✅ No changes between the snapshots!")
+		return nil
+	}
+
+	showNodeChanges(diff)
+	showVersionChanges(diff.VersionChanges)
+	showCostChanges(diff)
+	showHealthChanges(diff)
+	showRecommendationChanges(diff.NewRecommendations, diff.ResolvedRecommendations)
+
+	return nil
+}
+
+func showNodeChanges(diff *export.SnapshotDiff) {
+	if len(diff.AddedNodes) == 0 && len(diff.RemovedNodes) == 0 && len(diff.AddedComponents) == 0 && len(diff.RemovedComponents) == 0 {
+		return
+	}
+
+	fmt.Println("🖥️  NODES AND COMPONENTS")
+	fmt.Println(strings.Repeat("-", 40))
+
+	changeTable := table.NewTable([]string{"Change", "Kind", "Name"})
+	for _, node := range diff.AddedNodes {
+		changeTable.AddRow([]string{"🟢 Added", "Node", node})
+	}
+	for _, node := range diff.RemovedNodes {
+		changeTable.AddRow([]string{"🔴 Removed", "Node", node})
+	}
+	for _, component := range diff.AddedComponents {
+		changeTable.AddRow([]string{"🟢 Added", "Component", component})
+	}
+	for _, component := range diff.RemovedComponents {
+		changeTable.AddRow([]string{"🔴 Removed", "Component", component})
+	}
+	changeTable.Render()
+	fmt.Println()
+}
+
+func showVersionChanges(changes []export.VersionChange) {
+	if len(changes) == 0 {
+		return
+	}
+
+	fmt.Println("⬆️  VERSION CHANGES")
+	fmt.Println(strings.Repeat("-", 40))
+
+	versionTable := table.NewTable([]string{"Kind", "Name", "Before", "After"})
+	for _, change := range changes {
+		versionTable.AddRow([]string{change.Kind, change.Name, valueOrUnknown(change.Before), valueOrUnknown(change.After)})
+	}
+	versionTable.Render()
+	fmt.Println()
+}
+
+func showCostChanges(diff *export.SnapshotDiff) {
+	if len(diff.CostChanges) == 0 && diff.TotalCostBefore == diff.TotalCostAfter {
+		return
+	}
+
+	fmt.Println("💰 COST CHANGES (monthly)")
+	fmt.Println(strings.Repeat("-", 40))
+
+	costTable := table.NewTable([]string{"Namespace", "Before", "After", "Delta"})
+	for _, change := range diff.CostChanges {
+		costTable.AddRow([]string{change.Namespace, fmt.Sprintf("$%.2f", change.Before), fmt.Sprintf("$%.2f", change.After), formatCostDelta(change.Delta)})
+	}
+	costTable.AddRow([]string{"Total", fmt.Sprintf("$%.2f", diff.TotalCostBefore), fmt.Sprintf("$%.2f", diff.TotalCostAfter), formatCostDelta(diff.TotalCostAfter - diff.TotalCostBefore)})
+	costTable.Render()
+	fmt.Println()
+}
+
+func formatCostDelta(delta float64) string {
+	if delta >= 0 {
+		return fmt.Sprintf("🔺 +$%.2f", delta)
+	}
+	return fmt.Sprintf("🔻 -$%.2f", -delta)
+}
+
+func showHealthChanges(diff *export.SnapshotDiff) {
+	if len(diff.HealthChanges) == 0 && diff.OverallHealthBefore == diff.OverallHealthAfter {
+		return
+	}
+
+	fmt.Println("🏥 HEALTH CHANGES")
+	fmt.Println(strings.Repeat("-", 40))
+
+	fmt.Printf("Overall health score: %d → %d\n", diff.OverallHealthBefore, diff.OverallHealthAfter)
+
+	if len(diff.HealthChanges) > 0 {
+		healthTable := table.NewTable([]string{"Workload", "Before", "After", "Change"})
+		for _, change := range diff.HealthChanges {
+			icon := "🟢"
+			if change.AfterScore < change.BeforeScore {
+				icon = "🔴"
+			}
+			healthTable.AddRow([]string{
+				change.Workload,
+				fmt.Sprintf("%d (%s)", change.BeforeScore, change.BeforeStatus),
+				fmt.Sprintf("%d (%s)", change.AfterScore, change.AfterStatus),
+				fmt.Sprintf("%s %+d", icon, change.AfterScore-change.BeforeScore),
+			})
+		}
+		healthTable.Render()
+	}
+	fmt.Println()
+}
+
+func showRecommendationChanges(added, resolved []recommendations.Recommendation) {
+	if len(added) == 0 && len(resolved) == 0 {
+		return
+	}
+
+	fmt.Println("💡 RECOMMENDATIONS")
+	fmt.Println(strings.Repeat("-", 40))
+
+	recTable := table.NewTable([]string{"Change", "Severity", "Type", "Title"})
+	for _, rec := range added {
+		recTable.AddRow([]string{"🆕 New", rec.Severity, rec.Type, rec.Title})
+	}
+	for _, rec := range resolved {
+		recTable.AddRow([]string{"✅ Resolved", rec.Severity, rec.Type, rec.Title})
+	}
+	recTable.Render()
+	fmt.Println()
+}
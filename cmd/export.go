@@ -56,21 +56,47 @@ func runExportCommand(cmd *cobra.Command, args []string) error {
 	fmt.Printf("📤 Exporting cluster data to %s format...\n", strings.ToUpper(exportFormat))
 	fmt.Println(strings.Repeat("=", 50))
 
+	data := collectExportData(client, exportNamespace, exportHours, includeMetrics, includeCosts, includeLogs, includeEvents)
+
+	switch exportFormat {
+	case "json":
+		if err := exportToJSON(exporter, data); err != nil {
+			return err
+		}
+	case "csv":
+		if err := exportToCSV(exporter, data); err != nil {
+			return err
+		}
+	case "prometheus":
+		if err := exportToPrometheus(exporter, data); err != nil {
+			return err
+		}
+	default:
+		return fmt.Errorf("unsupported export format: %s", exportFormat)
+	}
+
+	fmt.Println("✅ Export completed successfully!")
+	return nil
+}
+
+// collectExportData gathers the requested sections; sections that fail to
+// load are left empty rather than aborting the export.
+func collectExportData(client *kubernetes.Client, namespace string, hours int, metrics, costs, logs, events bool) *export.ExportData {
 	data := &export.ExportData{
 		Timestamp: time.Now(),
 	}
 
-	if includeMetrics {
+	if metrics {
 		fmt.Println("📊 Collecting cluster metrics...")
-		if metrics, err := client.GetClusterMetrics(); err == nil {
-			data.ClusterMetrics = metrics
+		if clusterMetrics, err := client.GetClusterMetrics(); err == nil {
+			data.ClusterMetrics = clusterMetrics
 		}
 
 		if nodeMetrics, err := client.GetRealTimeNodeMetrics(); err == nil {
 			data.NodeMetrics = nodeMetrics
 		}
 
-		if podMetrics, err := client.GetRealTimePodMetrics(exportNamespace); err == nil {
+		if podMetrics, err := client.GetRealTimePodMetrics(namespace); err == nil {
 			data.PodMetrics = podMetrics
 		}
 
@@ -79,46 +105,28 @@ func runExportCommand(cmd *cobra.Command, args []string) error {
 		}
 	}
 
-	if includeCosts {
+	if costs {
 		fmt.Println("💰 Collecting cost analysis...")
 		if costAnalysis, err := client.GetCostAnalysis(); err == nil {
 			data.CostAnalysis = costAnalysis
 		}
 	}
 
-	if includeLogs {
+	if logs {
 		fmt.Println("📋 Collecting log analysis...")
-		if logAnalysis, err := client.GetLogAnalysis(exportNamespace, exportHours); err == nil {
+		if logAnalysis, err := client.GetLogAnalysis(namespace, hours); err == nil {
 			data.LogAnalysis = logAnalysis
 		}
 	}
 
-	if includeEvents {
+	if events {
 		fmt.Println("📅 Collecting cluster events...")
-		if events, err := client.GetClusterEvents(exportNamespace, exportHours); err == nil {
-			data.Events = events
-		}
-	}
-
-	switch exportFormat {
-	case "json":
-		if err := exportToJSON(exporter, data); err != nil {
-			return err
-		}
-	case "csv":
-		if err := exportToCSV(exporter, data); err != nil {
-			return err
-		}
-	case "prometheus":
-		if err := exportToPrometheus(exporter, data); err != nil {
-			return err
+		if clusterEvents, err := client.GetClusterEvents(namespace, hours); err == nil {
+			data.Events = clusterEvents
 		}
-	default:
-		return fmt.Errorf("unsupported export format: %s", exportFormat)
 	}
 
-	fmt.Println("✅ Export completed successfully!")
-	return nil
+	return data
 }
 
 func exportToJSON(exporter *export.Exporter, data *export.ExportData) error {
@@ -0,0 +1,231 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"k8s-cli/pkg/kubernetes"
+	"k8s-cli/pkg/table"
+
+	"github.com/spf13/cobra"
+)
+
+var fixCmd = &cobra.Command{
+	Use:   "fix [rule-id]",
+	Short: "Apply safe remediations (server-side dry-run unless --yes)",
+	Long: `Remediate a subset of safe findings. Every change is first sent to the API server with server-side dry-run and shown as a diff; nothing is persisted unless --yes is given. Applied changes are appended to an audit log.
+
+Run without a rule id to list the available rules.`,
+	Args: cobra.MaximumNArgs(1),
+	RunE: runFixCommand,
+}
+
+var (
+	fixNamespace  string
+	fixYes        bool
+	fixOlderThan  time.Duration
+	fixPercentile float64
+	fixHeadroom   float64
+	fixSamples    int
+	fixInterval   time.Duration
+	fixAuditLog   string
+)
+
+type fixAuditEntry struct {
+	Timestamp time.Time `json:"timestamp"`
+	Server    string    `json:"server"`
+	Rule      string    `json:"rule"`
+	Operation string    `json:"operation"`
+	Kind      string    `json:"kind"`
+	Namespace string    `json:"namespace"`
+	Name      string    `json:"name"`
+	Reason    string    `json:"reason"`
+	Diff      []string  `json:"diff"`
+	Error     string    `json:"error,omitempty"`
+}
+
+func init() {
+	rootCmd.AddCommand(fixCmd)
+	fixCmd.Flags().StringVarP(&fixNamespace, "namespace", "n", "", "Namespace to remediate (empty for all)")
+	fixCmd.Flags().BoolVar(&fixYes, "yes", false, "Apply the changes instead of only dry-running them")
+	fixCmd.Flags().DurationVar(&fixOlderThan, "older-than", 0, "Minimum age for stuck-terminating and completed-jobs (default depends on the rule)")
+	fixCmd.Flags().Float64Var(&fixPercentile, "percentile", 95, "Usage percentile for missing-requests")
+	fixCmd.Flags().Float64Var(&fixHeadroom, "headroom", 0.2, "Headroom on top of usage for missing-requests")
+	fixCmd.Flags().IntVar(&fixSamples, "samples", 10, "Number of metrics samples missing-requests sizes from")
+	fixCmd.Flags().DurationVar(&fixInterval, "interval", 30*time.Second, "Interval between metrics samples for missing-requests")
+	fixCmd.Flags().StringVar(&fixAuditLog, "audit-log", defaultFixAuditLog(), "File applied changes are appended to")
+}
+
+func runFixCommand(cmd *cobra.Command, args []string) error {
+	if len(args) == 0 {
+		showFixRules()
+		return nil
+	}
+
+	ruleID := args[0]
+	if ruleID == "missing-requests" {
+		if fixPercentile <= 0 || fixPercentile > 100 {
+			return fmt.Errorf("--percentile must be between 0 and 100")
+		}
+		if fixHeadroom < 0 {
+			return fmt.Errorf("--headroom cannot be negative")
+		}
+	}
+
+	kubeconfig, _ := cmd.Flags().GetString("kubeconfig")
+
+	client, err := kubernetes.NewClient(kubeconfig)
+	if err != nil {
+		return fmt.Errorf("failed to create Kubernetes client: %w", err)
+	}
+
+	if ruleID == "missing-requests" && fixSamples > 1 {
+		fmt.Printf("Taking %d metrics samples %s apart...\n", fixSamples, fixInterval)
+	}
+	actions, err := client.PlanFix(ruleID, kubernetes.FixOptions{
+		Namespace:  fixNamespace,
+		OlderThan:  fixOlderThan,
+		Percentile: fixPercentile,
+		Headroom:   fixHeadroom,
+		Samples:    fixSamples,
+		Interval:   fixInterval,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to plan %s: %w", ruleID, err)
+	}
+
+	fmt.Printf("🔧 Fix: %s\n", ruleID)
+	fmt.Println(strings.Repeat("=", 80))
+	fmt.Println()
+
+	if len(actions) == 0 {
+		fmt.Println("✅ Nothing to fix!")
+		return nil
+	}
+
+	// Always validate against the API server first, even with --yes
+	dryRun := client.ApplyFix(actions, true)
+	showFixDiff(dryRun)
+
+	var valid []kubernetes.FixAction
+	for _, result := range dryRun {
+		if result.Error == "" {
+			valid = append(valid, result.Action)
+		}
+	}
+
+	if !fixYes {
+		fmt.Printf("💡 Server-side dry-run: %d/%d changes would succeed. Re-run with --yes to apply them.\n", len(valid), len(actions))
+		return nil
+	}
+
+	if len(valid) == 0 {
+		return fmt.Errorf("no change passed the server-side dry-run")
+	}
+
+	// Open the audit log before touching the cluster, and record each change
+	// as soon as it is applied so nothing is changed without a record
+	auditLog, err := openFixAuditLog(fixAuditLog)
+	if err != nil {
+		return fmt.Errorf("failed to open audit log %s: %w", fixAuditLog, err)
+	}
+	defer auditLog.Close()
+
+	encoder := json.NewEncoder(auditLog)
+	var results []kubernetes.FixResult
+	for _, action := range valid {
+		result := client.ApplyFix([]kubernetes.FixAction{action}, false)[0]
+		results = append(results, result)
+		if err := encoder.Encode(newFixAuditEntry(client.Config.Host, result)); err != nil {
+			return fmt.Errorf("failed to write audit log %s after %s %s/%s, stopping: %w", fixAuditLog, action.Kind, action.Namespace, action.Name, err)
+		}
+	}
+
+	failed := 0
+	for _, result := range results {
+		if result.Error != "" {
+			failed++
+			fmt.Printf("🔴 %s %s/%s: %s\n", result.Action.Kind, result.Action.Namespace, result.Action.Name, result.Error)
+		}
+	}
+	fmt.Printf("✅ Applied %d/%d changes (audit log: %s)\n", len(results)-failed, len(results), fixAuditLog)
+
+	if failed > 0 {
+		return fmt.Errorf("%d changes failed", failed)
+	}
+	return nil
+}
+
+func showFixRules() {
+	fmt.Println("🔧 Available Fix Rules")
+	fmt.Println(strings.Repeat("=", 80))
+
+	rulesTable := table.NewTable([]string{"Rule", "Description", "Default --older-than"})
+	for _, rule := range kubernetes.FixRules() {
+		olderThan := "-"
+		if rule.DefaultOlderThan > 0 {
+			olderThan = rule.DefaultOlderThan.String()
+		}
+		rulesTable.AddRow([]string{rule.ID, rule.Description, olderThan})
+	}
+	rulesTable.Render()
+	fmt.Println()
+	fmt.Println("💡 Usage: k8s-cli fix <rule-id> [--yes]")
+}
+
+func showFixDiff(results []kubernetes.FixResult) {
+	fmt.Println("📝 CHANGES")
+	fmt.Println(strings.Repeat("-", 40))
+
+	for _, result := range results {
+		action := result.Action
+		icon := "🟢"
+		if result.Error != "" {
+			icon = "🔴"
+		}
+
+		fmt.Printf("%s %s %s %s/%s: %s\n", icon, action.Operation, action.Kind, action.Namespace, action.Name, action.Reason)
+		for _, line := range action.Diff {
+			fmt.Printf("    %s\n", line)
+		}
+		if result.Error != "" {
+			fmt.Printf("    dry-run failed: %s\n", result.Error)
+		}
+	}
+	fmt.Println()
+}
+
+// openFixAuditLog opens the audit log for appending, creating it if needed.
+func openFixAuditLog(path string) (*os.File, error) {
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return nil, err
+	}
+	return os.OpenFile(path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0644)
+}
+
+func newFixAuditEntry(server string, result kubernetes.FixResult) fixAuditEntry {
+	return fixAuditEntry{
+		Timestamp: time.Now().UTC(),
+		Server:    server,
+		Rule:      result.Action.Rule,
+		Operation: result.Action.Operation,
+		Kind:      result.Action.Kind,
+		Namespace: result.Action.Namespace,
+		Name:      result.Action.Name,
+		Reason:    result.Action.Reason,
+		Diff:      result.Action.Diff,
+		Error:     result.Error,
+	}
+}
+
+func defaultFixAuditLog() string {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "k8s-cli-audit.log"
+	}
+	return filepath.Join(home, ".k8s-cli", "audit.log")
+}
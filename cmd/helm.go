@@ -0,0 +1,160 @@
+package cmd
+
+import (
+	"fmt"
+	"strings"
+
+	"k8s-cli/pkg/kubernetes"
+	"k8s-cli/pkg/table"
+
+	"github.com/spf13/cobra"
+)
+
+var helmCmd = &cobra.Command{
+	Use:   "helm",
+	Short: "Show Helm releases with chart and app versions",
+	Long:  `Decode Helm release secrets to show chart name, chart version, appVersion, last deployment time and status. Failed and pending releases are flagged, and the revision history of a single release can be shown with --history.`,
+	RunE:  runHelmCommand,
+}
+
+var (
+	helmNamespace   string
+	helmHistory     string
+	helmShowNotes   bool
+	helmFlaggedOnly bool
+)
+
+func init() {
+	rootCmd.AddCommand(helmCmd)
+	helmCmd.Flags().StringVarP(&helmNamespace, "namespace", "n", "", "Namespace to inspect (empty for all)")
+	helmCmd.Flags().StringVar(&helmHistory, "history", "", "Show revision history for the named release")
+	helmCmd.Flags().BoolVar(&helmShowNotes, "notes", false, "Show release notes (with --history)")
+	helmCmd.Flags().BoolVar(&helmFlaggedOnly, "flagged-only", false, "Show only failed or pending releases")
+}
+
+func runHelmCommand(cmd *cobra.Command, args []string) error {
+	kubeconfig, _ := cmd.Flags().GetString("kubeconfig")
+
+	client, err := kubernetes.NewClient(kubeconfig)
+	if err != nil {
+		return fmt.Errorf("failed to create Kubernetes client: %w", err)
+	}
+
+	if helmHistory != "" {
+		return showHelmHistory(client, helmNamespace, helmHistory)
+	}
+
+	fmt.Println("⎈ Helm Releases")
+	fmt.Println(strings.Repeat("=", 80))
+	fmt.Println()
+
+	releases, err := client.GetHelmReleases(helmNamespace)
+	if err != nil {
+		return fmt.Errorf("failed to get helm releases: %w", err)
+	}
+
+	if len(releases) == 0 {
+		fmt.Println("No Helm releases found.")
+		return nil
+	}
+
+	releaseTable := table.NewTable([]string{"Release", "Namespace", "Revision", "Chart", "Chart Version", "App Version", "Status", "Last Deployed"})
+	flagged := 0
+	for _, release := range releases {
+		if release.Flagged {
+			flagged++
+		}
+		if helmFlaggedOnly && !release.Flagged {
+			continue
+		}
+
+		releaseTable.AddRow([]string{
+			release.Name,
+			release.Namespace,
+			fmt.Sprintf("%d", release.Revision),
+			valueOrUnknown(release.Chart),
+			valueOrUnknown(release.ChartVersion),
+			valueOrUnknown(release.AppVersion),
+			formatReleaseStatus(release),
+			formatDeployTime(release),
+		})
+	}
+	releaseTable.Render()
+	fmt.Println()
+
+	if flagged > 0 {
+		fmt.Printf("⚠️  %d releases are failed or pending. Use --history <release> for details.\n\n", flagged)
+	}
+
+	return nil
+}
+
+func showHelmHistory(client *kubernetes.Client, namespace, name string) error {
+	fmt.Printf("⎈ Helm History: %s\n", name)
+	fmt.Println(strings.Repeat("=", 80))
+	fmt.Println()
+
+	history, err := client.GetHelmReleaseHistory(namespace, name)
+	if err != nil {
+		return fmt.Errorf("failed to get helm history: %w", err)
+	}
+
+	historyTable := table.NewTable([]string{"Revision", "Namespace", "Updated", "Status", "Chart", "App Version", "Description"})
+	for _, release := range history {
+		chart := valueOrUnknown(release.Chart)
+		if release.ChartVersion != "" {
+			chart += "-" + release.ChartVersion
+		}
+
+		historyTable.AddRow([]string{
+			fmt.Sprintf("%d", release.Revision),
+			release.Namespace,
+			formatDeployTime(release),
+			formatReleaseStatus(release),
+			chart,
+			valueOrUnknown(release.AppVersion),
+			release.Description,
+		})
+	}
+	historyTable.Render()
+	fmt.Println()
+
+	current := history[0]
+	if len(current.Images) > 0 {
+		fmt.Println("🐳 Images from values:")
+		for _, image := range current.Images {
+			fmt.Printf("  • %s\n", image)
+		}
+		fmt.Println()
+	}
+
+	if helmShowNotes && current.Notes != "" {
+		fmt.Println("📝 NOTES")
+		fmt.Println(strings.Repeat("-", 40))
+		fmt.Println(current.Notes)
+		fmt.Println()
+	}
+
+	return nil
+}
+
+func formatReleaseStatus(release kubernetes.HelmRelease) string {
+	if release.Flagged {
+		return "🔴 " + release.Status
+	}
+	return "🟢 " + release.Status
+}
+
+func formatDeployTime(release kubernetes.HelmRelease) string {
+	if release.LastDeployed.IsZero() {
+		return "Unknown"
+	}
+	return release.LastDeployed.Format("2006-01-02 15:04")
+}
+
+func valueOrUnknown(value string) string {
+	if value == "" {
+		return "Unknown"
+	}
+	return value
+}
@@ -0,0 +1,199 @@
+package cmd
+
+import (
+	"fmt"
+	"strings"
+
+	"k8s-cli/pkg/kubernetes"
+	"k8s-cli/pkg/table"
+
+	"github.com/spf13/cobra"
+)
+
+var imagesCmd = &cobra.Command{
+	Use:   "images",
+	Short: "Inventory container images and check registry hygiene",
+	Long:  `List every distinct image used across workloads with its registry, tag or digest, pull policy, the workloads using it and the number of nodes it runs on. Flags latest or untagged images, images from registries outside the allowlist, different tags of the same repository across namespaces, and image pull failures.`,
+	RunE:  runImagesCommand,
+}
+
+var (
+	imagesNamespace         string
+	imagesAllowedRegistries []string
+	imagesIssuesOnly        bool
+)
+
+func init() {
+	rootCmd.AddCommand(imagesCmd)
+	imagesCmd.Flags().StringVarP(&imagesNamespace, "namespace", "n", "", "Namespace to analyze (empty for all)")
+	imagesCmd.Flags().StringSliceVar(&imagesAllowedRegistries, "allowed-registries", nil, "Allowed registries or repository prefixes, e.g. ghcr.io/my-org,registry.k8s.io")
+	imagesCmd.Flags().BoolVar(&imagesIssuesOnly, "issues-only", false, "Show only images with issues")
+}
+
+func runImagesCommand(cmd *cobra.Command, args []string) error {
+	kubeconfig, _ := cmd.Flags().GetString("kubeconfig")
+
+	client, err := kubernetes.NewClient(kubeconfig)
+	if err != nil {
+		return fmt.Errorf("failed to create Kubernetes client: %w", err)
+	}
+
+	fmt.Println("🐳 Image Inventory")
+	fmt.Println(strings.Repeat("=", 80))
+	fmt.Println()
+
+	inventory, err := client.GetImageInventory(imagesNamespace, imagesAllowedRegistries)
+	if err != nil {
+		return fmt.Errorf("failed to get image inventory: %w", err)
+	}
+
+	showImageSummary(&inventory.Summary)
+	showImageList(inventory.Images)
+	showImageTagConflicts(inventory.TagConflicts)
+	showImagePullFailures(inventory.PullFailures)
+
+	return nil
+}
+
+func showImageSummary(summary *kubernetes.ImageSummary) {
+	fmt.Println("📊 IMAGE SUMMARY")
+	fmt.Println(strings.Repeat("-", 40))
+
+	summaryTable := table.NewTable([]string{"Metric", "Value"})
+	summaryTable.AddRow([]string{"Distinct Images", fmt.Sprintf("%d", summary.TotalImages)})
+	summaryTable.AddRow([]string{"Registries", fmt.Sprintf("%d", summary.TotalRegistries)})
+	summaryTable.AddRow([]string{"Pinned By Digest", fmt.Sprintf("%d", summary.DigestPinned)})
+	summaryTable.AddRow([]string{"Latest Or Untagged", fmt.Sprintf("%d", summary.LatestOrUntagged)})
+	if len(imagesAllowedRegistries) > 0 {
+		summaryTable.AddRow([]string{"From Disallowed Registries", fmt.Sprintf("%d", summary.DisallowedRegistry)})
+	}
+	summaryTable.AddRow([]string{"Repositories With Mixed Tags", fmt.Sprintf("%d", summary.TagConflicts)})
+	summaryTable.AddRow([]string{"Image Pull Failures", fmt.Sprintf("%d", summary.PullFailures)})
+	summaryTable.Render()
+	fmt.Println()
+}
+
+func showImageList(images []kubernetes.ImageInfo) {
+	fmt.Println("📦 IMAGES")
+	fmt.Println(strings.Repeat("-", 40))
+
+	imageTable := table.NewTable([]string{"Registry", "Repository", "Tag/Digest", "Pull Policy", "Workloads", "Nodes", "Status"})
+	shown := 0
+	for _, image := range images {
+		if imagesIssuesOnly && len(image.Issues) == 0 {
+			continue
+		}
+
+		ref := kubernetes.ImageReference{Registry: image.Registry, Repository: image.Repository, Tag: image.Tag, Digest: image.Digest}
+
+		imageTable.AddRow([]string{
+			image.Registry,
+			image.Repository,
+			ref.Reference(),
+			strings.Join(image.PullPolicies, ","),
+			formatImageWorkloads(image.Workloads),
+			fmt.Sprintf("%d", image.Nodes),
+			formatImageStatus(image),
+		})
+		shown++
+	}
+
+	if shown == 0 {
+		fmt.Println("✅ No images with issues found!")
+		fmt.Println()
+		return
+	}
+
+	imageTable.Render()
+	fmt.Println()
+
+	for _, image := range images {
+		if len(image.Issues) == 0 {
+			continue
+		}
+		fmt.Printf("  • %s: %s\n", image.Image, strings.Join(image.Issues, "; "))
+	}
+	fmt.Println()
+}
+
+func showImageTagConflicts(conflicts []kubernetes.ImageTagConflict) {
+	if len(conflicts) == 0 {
+		return
+	}
+
+	fmt.Println("🏷️  MIXED TAGS ACROSS NAMESPACES")
+	fmt.Println(strings.Repeat("-", 40))
+
+	conflictTable := table.NewTable([]string{"Repository", "Tags", "Namespaces"})
+	for _, conflict := range conflicts {
+		conflictTable.AddRow([]string{
+			conflict.Repository,
+			strings.Join(conflict.Tags, ", "),
+			strings.Join(conflict.Namespaces, ", "),
+		})
+	}
+	conflictTable.Render()
+	fmt.Println()
+}
+
+func showImagePullFailures(failures []kubernetes.ImagePullFailure) {
+	if len(failures) == 0 {
+		fmt.Println("✅ No image pull failures found!")
+		fmt.Println()
+		return
+	}
+
+	fmt.Println("🚫 IMAGE PULL FAILURES")
+	fmt.Println(strings.Repeat("-", 40))
+
+	failureTable := table.NewTable([]string{"Image", "Namespace", "Pod", "Reason", "Count", "Last Seen"})
+	for i, failure := range failures {
+		if i >= 20 {
+			break
+		}
+
+		lastSeen := "Unknown"
+		if !failure.LastSeen.IsZero() {
+			lastSeen = failure.LastSeen.Format("2006-01-02 15:04")
+		}
+
+		failureTable.AddRow([]string{
+			failure.Image,
+			failure.Namespace,
+			failure.Object,
+			failure.Reason,
+			fmt.Sprintf("%d", failure.Count),
+			lastSeen,
+		})
+	}
+	failureTable.Render()
+
+	if len(failures) > 20 {
+		fmt.Printf("... and %d more pull failures\n", len(failures)-20)
+	}
+	fmt.Println()
+}
+
+func formatImageWorkloads(workloads []string) string {
+	switch len(workloads) {
+	case 0:
+		return "-"
+	case 1:
+		return workloads[0]
+	default:
+		return fmt.Sprintf("%s (+%d)", workloads[0], len(workloads)-1)
+	}
+}
+
+func formatImageStatus(image kubernetes.ImageInfo) string {
+	switch image.Severity {
+	case "High":
+		return "🔴 Issue"
+	case "Medium":
+		return "🟡 Warning"
+	case "Low":
+		return "🟠 Notice"
+	default:
+		return "🟢 OK"
+	}
+}
@@ -0,0 +1,208 @@
+package cmd
+
+import (
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"k8s-cli/pkg/kubernetes"
+	"k8s-cli/pkg/recommendations"
+	"k8s-cli/pkg/table"
+
+	"github.com/spf13/cobra"
+)
+
+var lintCmd = &cobra.Command{
+	Use:   "lint",
+	Short: "Check manifests against workload best practices without a cluster",
+	Long: `Run the workload checks used against live clusters (resource requests/limits, probes, replicas, security context) and deprecated API detection against YAML manifests.
+
+Pass files or directories with -f, or '-' to read kustomize build or helm template output from stdin:
+
+  kustomize build overlays/prod | k8s-cli lint -f -
+  helm template my-release ./chart | k8s-cli lint -f - --fail-on high`,
+	RunE: runLintCommand,
+}
+
+var (
+	lintFiles         []string
+	lintTargetVersion string
+	lintFailOn        string
+)
+
+func init() {
+	rootCmd.AddCommand(lintCmd)
+	lintCmd.Flags().StringSliceVarP(&lintFiles, "filename", "f", nil, "Manifest files or directories to lint ('-' for stdin)")
+	lintCmd.Flags().StringVar(&lintTargetVersion, "target", kubernetes.DefaultLintTargetVersion, "Kubernetes version to check deprecated APIs against")
+	lintCmd.Flags().StringVar(&lintFailOn, "fail-on", "", "Exit non-zero when findings at or above this severity exist (high, medium, low)")
+}
+
+func runLintCommand(cmd *cobra.Command, args []string) error {
+	if len(lintFiles) == 0 {
+		return fmt.Errorf("no manifests given; use -f <file|dir|->")
+	}
+
+	failOn := ""
+	if lintFailOn != "" {
+		severity, err := recommendations.ParseSeverity(lintFailOn)
+		if err != nil {
+			return err
+		}
+		failOn = severity
+	}
+
+	manifests, files, err := loadLintManifests(lintFiles)
+	if err != nil {
+		return err
+	}
+
+	findings, err := kubernetes.LintManifests(manifests, lintTargetVersion)
+	if err != nil {
+		return fmt.Errorf("failed to lint manifests: %w", err)
+	}
+
+	counts := make(map[string]int)
+	for _, finding := range findings {
+		counts[finding.Severity]++
+	}
+
+	fmt.Printf("🧹 Manifest Lint (target Kubernetes %s)\n", lintTargetVersion)
+	fmt.Println(strings.Repeat("=", 80))
+	fmt.Println()
+
+	summaryTable := table.NewTable([]string{"Metric", "Value"})
+	summaryTable.AddRow([]string{"Files", fmt.Sprintf("%d", files)})
+	summaryTable.AddRow([]string{"Objects", fmt.Sprintf("%d", len(manifests))})
+	summaryTable.AddRow([]string{"High", fmt.Sprintf("%d", counts["High"])})
+	summaryTable.AddRow([]string{"Medium", fmt.Sprintf("%d", counts["Medium"])})
+	summaryTable.AddRow([]string{"Low", fmt.Sprintf("%d", counts["Low"])})
+	summaryTable.Render()
+	fmt.Println()
+
+	showLintFindings(findings)
+
+	if failOn != "" {
+		failing := 0
+		for _, finding := range findings {
+			if recommendations.AtOrAbove(finding.Severity, failOn) {
+				failing++
+			}
+		}
+		if failing > 0 {
+			cmd.SilenceUsage = true
+			return fmt.Errorf("%d findings at or above %s severity", failing, failOn)
+		}
+	}
+
+	return nil
+}
+
+// loadLintManifests reads files, directories (recursively, *.yaml and *.yml)
+// and stdin, returning the objects found and the number of files read.
+func loadLintManifests(paths []string) ([]kubernetes.Manifest, int, error) {
+	var manifests []kubernetes.Manifest
+	files := 0
+
+	read := func(path string) error {
+		var (
+			parsed []kubernetes.Manifest
+			err    error
+		)
+		if path == "-" {
+			parsed, err = kubernetes.ParseManifests(os.Stdin, "stdin")
+		} else {
+			file, openErr := os.Open(path)
+			if openErr != nil {
+				return fmt.Errorf("failed to open %s: %w", path, openErr)
+			}
+			defer file.Close()
+			parsed, err = kubernetes.ParseManifests(file, path)
+		}
+		if err != nil {
+			return err
+		}
+		manifests = append(manifests, parsed...)
+		files++
+		return nil
+	}
+
+	for _, path := range paths {
+		if path == "-" {
+			if err := read(path); err != nil {
+				return nil, files, err
+			}
+			continue
+		}
+
+		info, err := os.Stat(path)
+		if err != nil {
+			return nil, files, fmt.Errorf("failed to read %s: %w", path, err)
+		}
+		if !info.IsDir() {
+			if err := read(path); err != nil {
+				return nil, files, err
+			}
+			continue
+		}
+
+		err = filepath.WalkDir(path, func(file string, entry fs.DirEntry, err error) error {
+			if err != nil {
+				return err
+			}
+			extension := filepath.Ext(file)
+			if entry.IsDir() || (extension != ".yaml" && extension != ".yml") {
+				return nil
+			}
+			return read(file)
+		})
+		if err != nil {
+			return nil, files, err
+		}
+	}
+
+	return manifests, files, nil
+}
+
+func showLintFindings(findings []kubernetes.LintFinding) {
+	if len(findings) == 0 {
+		fmt.Println("✅ No issues found in the manifests!")
+		fmt.Println()
+		return
+	}
+
+	fmt.Println("🔍 FINDINGS")
+	fmt.Println(strings.Repeat("-", 40))
+
+	source := ""
+	var findingsTable *table.Table
+	for _, finding := range findings {
+		if finding.Source != source || findingsTable == nil {
+			if findingsTable != nil {
+				findingsTable.Render()
+				fmt.Println()
+			}
+			source = finding.Source
+			fmt.Printf("📄 %s\n", source)
+			findingsTable = table.NewTable([]string{"Severity", "Object", "Issue", "Recommendation"})
+		}
+
+		severity := "🟢 " + finding.Severity
+		switch finding.Severity {
+		case "High":
+			severity = "🔴 " + finding.Severity
+		case "Medium":
+			severity = "🟡 " + finding.Severity
+		}
+
+		object := finding.Kind + "/" + finding.Name
+		if finding.Namespace != "" {
+			object = finding.Namespace + "/" + object
+		}
+
+		findingsTable.AddRow([]string{severity, object, finding.Issue, finding.Recommendation})
+	}
+	findingsTable.Render()
+	fmt.Println()
+}
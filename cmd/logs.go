@@ -1,11 +1,16 @@
 package cmd
 
 import (
+	"encoding/json"
 	"fmt"
+	"os"
+	"os/signal"
 	"strings"
+	"syscall"
 	"time"
 
 	"k8s-cli/pkg/kubernetes"
+	"k8s-cli/pkg/notify"
 	"k8s-cli/pkg/table"
 
 	"github.com/spf13/cobra"
@@ -27,6 +32,18 @@ var (
 	showLogsSecurityEvents bool
 	showLogsPodAnalysis    bool
 	logsNamespace          string
+	logsFollow             bool
+	logsOutput             string
+	logsMinSeverity        string
+	logsDedupWindow        time.Duration
+	logsRateLimit          int
+	logsNotifyConfig       string
+	logsContainerLogs      bool
+	logsPrevious           bool
+	logsTailLines          int64
+	logsSince              time.Duration
+	logsConcurrency        int
+	logsRulesFile          string
 )
 
 func init() {
@@ -39,6 +56,18 @@ func init() {
 	logsCmd.Flags().BoolVar(&showLogsSecurityEvents, "security-events", true, "Show security-related events")
 	logsCmd.Flags().BoolVar(&showLogsPodAnalysis, "pod-analysis", false, "Show detailed pod log analysis")
 	logsCmd.Flags().StringVarP(&logsNamespace, "namespace", "n", "", "Namespace to analyze (empty for all)")
+	logsCmd.Flags().BoolVarP(&logsFollow, "follow", "f", false, "Watch events and pod changes and stream new alerts as they arrive")
+	logsCmd.Flags().StringVarP(&logsOutput, "output", "o", "text", "Output format for --follow (text, ndjson)")
+	logsCmd.Flags().StringVar(&logsMinSeverity, "min-severity", "Warning", "Minimum severity to stream with --follow (Info, Warning, Critical)")
+	logsCmd.Flags().DurationVar(&logsDedupWindow, "dedup-window", 5*time.Minute, "Suppress repeats of the same event within this window")
+	logsCmd.Flags().IntVar(&logsRateLimit, "rate-limit", 30, "Maximum events streamed per minute (0 for unlimited)")
+	logsCmd.Flags().BoolVar(&logsContainerLogs, "container-logs", false, "Read container logs and cluster classified lines into patterns")
+	logsCmd.Flags().BoolVar(&logsPrevious, "previous", false, "Read logs of the previous instance of restarted containers (with --container-logs)")
+	logsCmd.Flags().Int64Var(&logsTailLines, "tail", 500, "Number of log lines to read per container (with --container-logs)")
+	logsCmd.Flags().DurationVar(&logsSince, "since", time.Hour, "Only read log lines newer than this duration (with --container-logs, 0 for all)")
+	logsCmd.Flags().IntVar(&logsConcurrency, "concurrency", 5, "Number of containers to read logs from in parallel")
+	logsCmd.Flags().StringVar(&logsRulesFile, "log-rules", "", "YAML/JSON file with additional log classification rules (name, severity, pattern)")
+	logsCmd.Flags().StringVar(&logsNotifyConfig, "notify-config", "", "Notifier configuration file for forwarding critical events (see 'k8s-cli notify --help')")
 }
 
 func runLogsCommand(cmd *cobra.Command, args []string) error {
@@ -49,6 +78,19 @@ func runLogsCommand(cmd *cobra.Command, args []string) error {
 		return fmt.Errorf("failed to create Kubernetes client: %w", err)
 	}
 
+	notifier, err := loadNotifier(logsNotifyConfig)
+	if err != nil {
+		return err
+	}
+
+	if logsFollow {
+		return followClusterEvents(client, notifier)
+	}
+
+	if logsContainerLogs {
+		return showContainerLogAnalysis(client)
+	}
+
 	fmt.Printf("📋 Cluster Events & Logs Analysis (Last %d hours)\n", timeWindow)
 	fmt.Println(strings.Repeat("=", 80))
 	fmt.Println()
@@ -86,6 +128,192 @@ func runLogsCommand(cmd *cobra.Command, args []string) error {
 		}
 	}
 
+	var alerts []notify.Alert
+	for _, event := range analysis.CriticalEvents {
+		alerts = append(alerts, alertFromClusterEvent(event))
+	}
+	sendAlerts(cmd.OutOrStdout(), notifier, alerts)
+
+	return nil
+}
+
+// alertQueueSize bounds the alerts waiting for delivery during --follow.
+const alertQueueSize = 100
+
+func followClusterEvents(client *kubernetes.Client, notifier *notify.Notifier) error {
+	if logsOutput != "text" && logsOutput != "ndjson" {
+		return fmt.Errorf("unsupported output format: %s (use text or ndjson)", logsOutput)
+	}
+
+	ctx, stop := signal.NotifyContext(client.Context, os.Interrupt, syscall.SIGTERM)
+	defer stop()
+	client.Context = ctx
+
+	options := kubernetes.EventWatchOptions{
+		Namespace:   logsNamespace,
+		MinSeverity: logsMinSeverity,
+		DedupWindow: logsDedupWindow,
+		RateLimit:   logsRateLimit,
+	}
+
+	// Alerts are delivered off the watch loop so a slow sink cannot stall it
+	var queue *notify.Queue
+	if notifier != nil {
+		queue = notify.NewQueue(ctx, notifier, alertQueueSize, func(alert notify.Alert, err error) {
+			fmt.Fprintf(os.Stderr, "Warning: Could not deliver alert: %v\n", err)
+		})
+		defer func() {
+			queue.Close()
+			if dropped := queue.Dropped(); dropped > 0 {
+				fmt.Fprintf(os.Stderr, "Warning: Dropped %d alerts because the notifier could not keep up\n", dropped)
+			}
+		}()
+	}
+
+	forward := func(event kubernetes.StreamEvent) {
+		if queue == nil {
+			return
+		}
+		if !queue.Enqueue(alertFromStreamEvent(event)) && queue.Dropped() == 1 {
+			fmt.Fprintln(os.Stderr, "Warning: Alert queue is full, dropping alerts until the notifier catches up")
+		}
+	}
+
+	if logsOutput == "ndjson" {
+		encoder := json.NewEncoder(os.Stdout)
+		return client.WatchClusterEvents(options, func(event kubernetes.StreamEvent) error {
+			forward(event)
+			return encoder.Encode(event)
+		})
+	}
+
+	fmt.Println("📡 Watching cluster events (Ctrl+C to stop)")
+	fmt.Println(strings.Repeat("=", 80))
+
+	return client.WatchClusterEvents(options, func(event kubernetes.StreamEvent) error {
+		severity := "🟢"
+		switch event.Severity {
+		case "Critical":
+			severity = "🔴"
+		case "Warning":
+			severity = "🟡"
+		}
+
+		object := event.Object
+		if event.Namespace != "" {
+			object = event.Namespace + "/" + object
+		}
+
+		line := fmt.Sprintf("%s %s %-8s %s %s: %s", event.Time.Format("15:04:05"), severity, event.Severity, object, event.Reason, event.Message)
+		if event.Suppressed > 0 {
+			line += fmt.Sprintf(" (%d similar suppressed)", event.Suppressed)
+		}
+		fmt.Println(line)
+		forward(event)
+		return nil
+	})
+}
+
+func showContainerLogAnalysis(client *kubernetes.Client) error {
+	options := kubernetes.ContainerLogOptions{
+		Namespace:   logsNamespace,
+		TailLines:   logsTailLines,
+		Since:       logsSince,
+		Previous:    logsPrevious,
+		Concurrency: logsConcurrency,
+	}
+
+	if logsRulesFile != "" {
+		rules, err := kubernetes.LoadLogRules(logsRulesFile)
+		if err != nil {
+			return err
+		}
+		options.Rules = rules
+	}
+
+	title := "📜 Container Log Analysis"
+	if logsPrevious {
+		title += " (previous instances)"
+	}
+	fmt.Println(title)
+	fmt.Println(strings.Repeat("=", 80))
+	fmt.Println()
+
+	analysis, err := client.GetContainerLogAnalysis(options)
+	if err != nil {
+		return fmt.Errorf("failed to analyze container logs: %w", err)
+	}
+
+	summaryTable := table.NewTable([]string{"Metric", "Value"})
+	summaryTable.AddRow([]string{"Containers Scanned", fmt.Sprintf("%d", analysis.ContainersScanned)})
+	summaryTable.AddRow([]string{"Lines Scanned", fmt.Sprintf("%d", analysis.LinesScanned)})
+	summaryTable.AddRow([]string{"Containers With Findings", fmt.Sprintf("%d", len(analysis.Containers))})
+	summaryTable.AddRow([]string{"Patterns", fmt.Sprintf("%d", len(analysis.Patterns))})
+	summaryTable.AddRow([]string{"Read Errors", fmt.Sprintf("%d", len(analysis.Errors))})
+	summaryTable.Render()
+	fmt.Println()
+
+	if len(analysis.Patterns) == 0 {
+		fmt.Println("✅ No error patterns found in container logs!")
+		fmt.Println()
+	} else {
+		fmt.Println("🔍 LOG PATTERNS")
+		fmt.Println(strings.Repeat("-", 40))
+
+		for i, pattern := range analysis.Patterns {
+			if i >= 10 {
+				fmt.Printf("... and %d more patterns\n", len(analysis.Patterns)-10)
+				break
+			}
+
+			severity := "🟡 " + pattern.Severity
+			if pattern.Severity == "Critical" {
+				severity = "🔴 " + pattern.Severity
+			}
+
+			fmt.Printf("%s [%s] %dx in %d pods\n", severity, pattern.Rule, pattern.Count, len(pattern.Pods))
+			fmt.Printf("  %s\n", pattern.Signature)
+			for _, sample := range pattern.Samples {
+				line := sample.Line
+				if runes := []rune(line); len(runes) > 120 {
+					line = string(runes[:117]) + "..."
+				}
+				fmt.Printf("    • %s/%s [%s]: %s\n", sample.Namespace, sample.Pod, sample.Container, line)
+			}
+			fmt.Println()
+		}
+	}
+
+	if len(analysis.Containers) > 0 {
+		fmt.Println("📦 CONTAINERS WITH FINDINGS")
+		fmt.Println(strings.Repeat("-", 40))
+
+		containerTable := table.NewTable([]string{"Pod", "Namespace", "Container", "Lines", "Critical", "Warnings"})
+		for i, container := range analysis.Containers {
+			if i >= 15 {
+				break
+			}
+			containerTable.AddRow([]string{
+				container.Pod,
+				container.Namespace,
+				container.Container,
+				fmt.Sprintf("%d", container.Lines),
+				fmt.Sprintf("%d", container.CriticalCount),
+				fmt.Sprintf("%d", container.WarningCount),
+			})
+		}
+		containerTable.Render()
+
+		if len(analysis.Containers) > 15 {
+			fmt.Printf("... and %d more containers\n", len(analysis.Containers)-15)
+		}
+		fmt.Println()
+	}
+
+	for _, readErr := range analysis.Errors {
+		fmt.Printf("Warning: Could not read logs for %s\n", readErr)
+	}
+
 	return nil
 }
 
@@ -128,9 +128,9 @@ func showNodeMetrics(client *kubernetes.Client) error {
 		nodeTable.AddRow([]string{
 			node.Name,
 			status,
-			fmt.Sprintf("%s / %s", node.CPUUsage, node.CPUCapacity),
+			fmt.Sprintf("%s / %s", node.CPUUsage, node.CPUAllocatable),
 			fmt.Sprintf("%.1f%%", node.CPUUsagePercent),
-			fmt.Sprintf("%s / %s", node.MemoryUsage, node.MemoryCapacity),
+			fmt.Sprintf("%s / %s", node.MemoryUsage, node.MemoryAllocatable),
 			fmt.Sprintf("%.1f%%", node.MemoryUsagePercent),
 		})
 	}
@@ -203,11 +203,12 @@ func showUtilizationAnalysis(client *kubernetes.Client) error {
 
 	utilizationTable := table.NewTable([]string{"Pod", "Namespace", "CPU %", "Memory %", "Recommendation"})
 	for _, util := range utilizations {
-		if strings.Contains(util.Recommendation, "underutilized") {
+		switch util.Status {
+		case kubernetes.UtilizationUnderutilized:
 			underutilized++
-		} else if strings.Contains(util.Recommendation, "overutilized") {
+		case kubernetes.UtilizationOverutilized:
 			overutilized++
-		} else {
+		default:
 			optimal++
 		}
 
@@ -0,0 +1,256 @@
+package cmd
+
+import (
+	"fmt"
+	"strings"
+
+	"k8s-cli/pkg/kubernetes"
+	"k8s-cli/pkg/table"
+
+	"github.com/spf13/cobra"
+)
+
+var networkCmd = &cobra.Command{
+	Use:   "network",
+	Short: "Analyze network exposure and NetworkPolicy coverage",
+	Long:  `Analyze NetworkPolicy coverage per namespace, pods not selected by any policy, externally exposed services, ingresses without TLS, and services without endpoints.`,
+	RunE:  runNetworkCommand,
+}
+
+var (
+	showNetworkCoverage      bool
+	showNetworkUncoveredPods bool
+	showNetworkExposed       bool
+	showNetworkIngresses     bool
+	showNetworkEndpoints     bool
+	networkNamespace         string
+)
+
+func init() {
+	rootCmd.AddCommand(networkCmd)
+	networkCmd.Flags().BoolVar(&showNetworkCoverage, "coverage", true, "Show NetworkPolicy coverage per namespace")
+	networkCmd.Flags().BoolVar(&showNetworkUncoveredPods, "uncovered-pods", false, "Show pods not selected by any NetworkPolicy")
+	networkCmd.Flags().BoolVar(&showNetworkExposed, "exposed", true, "Show services exposed via LoadBalancer, NodePort or externalIPs")
+	networkCmd.Flags().BoolVar(&showNetworkIngresses, "ingresses", true, "Show ingresses without TLS")
+	networkCmd.Flags().BoolVar(&showNetworkEndpoints, "endpoints", true, "Show services without endpoints")
+	networkCmd.Flags().StringVarP(&networkNamespace, "namespace", "n", "", "Namespace to analyze (empty for all)")
+}
+
+func runNetworkCommand(cmd *cobra.Command, args []string) error {
+	kubeconfig, _ := cmd.Flags().GetString("kubeconfig")
+
+	client, err := kubernetes.NewClient(kubeconfig)
+	if err != nil {
+		return fmt.Errorf("failed to create Kubernetes client: %w", err)
+	}
+
+	fmt.Println("🌐 Network Exposure Analysis")
+	fmt.Println(strings.Repeat("=", 80))
+	fmt.Println()
+
+	analysis, err := client.GetNetworkAnalysis(networkNamespace)
+	if err != nil {
+		return fmt.Errorf("failed to get network analysis: %w", err)
+	}
+
+	showNetworkOverview(&analysis.NetworkSummary)
+
+	if showNetworkCoverage {
+		showNetworkPolicyCoverage(analysis.NamespaceCoverage)
+	}
+
+	if showNetworkUncoveredPods {
+		showUncoveredPods(analysis.UncoveredPods)
+	}
+
+	if showNetworkExposed {
+		showExposedServices(analysis.ExposedServices)
+	}
+
+	if showNetworkIngresses {
+		showInsecureIngresses(analysis.InsecureIngresses)
+	}
+
+	if showNetworkEndpoints {
+		showServiceEndpointIssues(analysis.ServiceIssues)
+	}
+
+	showNetworkRecommendations(analysis.Recommendations)
+
+	return nil
+}
+
+func showNetworkOverview(summary *kubernetes.NetworkSummary) {
+	fmt.Println("📊 NETWORK OVERVIEW")
+	fmt.Println(strings.Repeat("-", 40))
+
+	overviewTable := table.NewTable([]string{"Metric", "Value"})
+	overviewTable.AddRow([]string{"Namespaces", fmt.Sprintf("%d", summary.TotalNamespaces)})
+	overviewTable.AddRow([]string{"Namespaces Without Policies", fmt.Sprintf("%d", summary.UncoveredNamespaces)})
+	overviewTable.AddRow([]string{"Pod Policy Coverage", fmt.Sprintf("%.1f%% (%d uncovered)", summary.CoveragePercent, summary.UncoveredPods)})
+	overviewTable.AddRow([]string{"Exposed Services", fmt.Sprintf("%d", summary.ExposedServices)})
+	overviewTable.AddRow([]string{"Ingresses Without TLS", fmt.Sprintf("%d", summary.InsecureIngresses)})
+	overviewTable.AddRow([]string{"Services Without Endpoints", fmt.Sprintf("%d", summary.ServicesWithoutEndpoints)})
+	overviewTable.Render()
+	fmt.Println()
+}
+
+func showNetworkPolicyCoverage(coverage []kubernetes.NamespaceNetworkCoverage) {
+	if len(coverage) == 0 {
+		return
+	}
+
+	fmt.Println("🛡️  NETWORKPOLICY COVERAGE")
+	fmt.Println(strings.Repeat("-", 40))
+
+	coverageTable := table.NewTable([]string{"Namespace", "Policies", "Pods", "Covered", "Coverage", "Default Deny", "Status"})
+	for _, ns := range coverage {
+		if ns.Status == "Empty" {
+			continue
+		}
+
+		status := ns.Status
+		switch ns.Status {
+		case "Uncovered":
+			status = "🔴 " + status
+		case "Partial":
+			status = "🟡 " + status
+		default:
+			status = "🟢 " + status
+		}
+
+		defaultDeny := "No"
+		if ns.DefaultDeny {
+			defaultDeny = "Yes"
+		}
+
+		coverageTable.AddRow([]string{
+			ns.Namespace,
+			fmt.Sprintf("%d", ns.NetworkPolicies),
+			fmt.Sprintf("%d", ns.TotalPods),
+			fmt.Sprintf("%d", ns.CoveredPods),
+			fmt.Sprintf("%.1f%%", ns.CoveragePercent),
+			defaultDeny,
+			status,
+		})
+	}
+	coverageTable.Render()
+	fmt.Println()
+}
+
+func showUncoveredPods(pods []kubernetes.UncoveredPod) {
+	if len(pods) == 0 {
+		fmt.Println("✅ All pods are selected by at least one NetworkPolicy!")
+		fmt.Println()
+		return
+	}
+
+	fmt.Println("🔓 PODS WITHOUT NETWORKPOLICY")
+	fmt.Println(strings.Repeat("-", 40))
+
+	podTable := table.NewTable([]string{"Pod", "Namespace", "Owner", "Node"})
+	for i, pod := range pods {
+		if i >= 20 {
+			break
+		}
+		podTable.AddRow([]string{pod.Name, pod.Namespace, pod.Owner, pod.Node})
+	}
+	podTable.Render()
+
+	if len(pods) > 20 {
+		fmt.Printf("... and %d more uncovered pods. Use --namespace to filter.\n", len(pods)-20)
+	}
+	fmt.Println()
+}
+
+func showExposedServices(services []kubernetes.ExposedService) {
+	if len(services) == 0 {
+		fmt.Println("✅ No externally exposed services found!")
+		fmt.Println()
+		return
+	}
+
+	fmt.Println("🌍 EXPOSED SERVICES")
+	fmt.Println(strings.Repeat("-", 40))
+
+	serviceTable := table.NewTable([]string{"Service", "Namespace", "Type", "External Address", "Ports", "Risk"})
+	for _, svc := range services {
+		risk := svc.RiskLevel
+		if svc.RiskLevel == "High" {
+			risk = "🔴 " + risk
+		} else {
+			risk = "🟡 " + risk
+		}
+
+		external := "<pending>"
+		if len(svc.ExternalIPs) > 0 {
+			external = strings.Join(svc.ExternalIPs, ",")
+		} else if strings.HasPrefix(svc.Type, "NodePort") {
+			external = "<nodes>"
+		}
+
+		serviceTable.AddRow([]string{
+			svc.Name,
+			svc.Namespace,
+			svc.Type,
+			external,
+			strings.Join(svc.Ports, ","),
+			risk,
+		})
+	}
+	serviceTable.Render()
+	fmt.Println()
+}
+
+func showInsecureIngresses(ingresses []kubernetes.IngressTLSIssue) {
+	if len(ingresses) == 0 {
+		fmt.Println("✅ All ingresses have TLS configured!")
+		fmt.Println()
+		return
+	}
+
+	fmt.Println("🔒 INGRESSES WITHOUT TLS")
+	fmt.Println(strings.Repeat("-", 40))
+
+	ingressTable := table.NewTable([]string{"Ingress", "Namespace", "Hosts", "Issue"})
+	for _, ing := range ingresses {
+		hosts := "*"
+		if len(ing.Hosts) > 0 {
+			hosts = strings.Join(ing.Hosts, ",")
+		}
+		ingressTable.AddRow([]string{ing.Name, ing.Namespace, hosts, ing.Issue})
+	}
+	ingressTable.Render()
+	fmt.Println()
+}
+
+func showServiceEndpointIssues(issues []kubernetes.ServiceEndpointIssue) {
+	if len(issues) == 0 {
+		fmt.Println("✅ All services have backing endpoints!")
+		fmt.Println()
+		return
+	}
+
+	fmt.Println("🔌 SERVICES WITHOUT ENDPOINTS")
+	fmt.Println(strings.Repeat("-", 40))
+
+	issueTable := table.NewTable([]string{"Service", "Namespace", "Selector", "Issue"})
+	for _, issue := range issues {
+		issueTable.AddRow([]string{issue.Name, issue.Namespace, issue.Selector, issue.Issue})
+	}
+	issueTable.Render()
+	fmt.Println()
+}
+
+func showNetworkRecommendations(recommendations []string) {
+	if len(recommendations) == 0 {
+		return
+	}
+
+	fmt.Println("💡 RECOMMENDATIONS")
+	fmt.Println(strings.Repeat("-", 40))
+	for _, rec := range recommendations {
+		fmt.Printf("  • %s\n", rec)
+	}
+	fmt.Println()
+}
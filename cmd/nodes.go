@@ -0,0 +1,224 @@
+package cmd
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"k8s-cli/pkg/kubernetes"
+	"k8s-cli/pkg/table"
+
+	"github.com/spf13/cobra"
+)
+
+var nodesCmd = &cobra.Command{
+	Use:   "nodes",
+	Short: "Analyze node allocation, pressure conditions and version inventory",
+	Long:  `Show allocatable, requested, limited and used CPU and memory per node (like kubectl describe node), the limit overcommit ratio, pressure conditions, cordoned nodes and taints, kubelet version skew against the control plane and the kernel, OS image and container runtime inventory.`,
+	RunE:  runNodesCommand,
+}
+
+var (
+	nodesIssuesOnly    bool
+	nodesShowTaints    bool
+	nodesShowInventory bool
+)
+
+func init() {
+	rootCmd.AddCommand(nodesCmd)
+	nodesCmd.Flags().BoolVar(&nodesIssuesOnly, "issues-only", false, "Show only nodes with issues")
+	nodesCmd.Flags().BoolVar(&nodesShowTaints, "taints", true, "Show node taints")
+	nodesCmd.Flags().BoolVar(&nodesShowInventory, "inventory", true, "Show kubelet, kernel, OS and runtime inventory")
+}
+
+func runNodesCommand(cmd *cobra.Command, args []string) error {
+	kubeconfig, _ := cmd.Flags().GetString("kubeconfig")
+
+	client, err := kubernetes.NewClient(kubeconfig)
+	if err != nil {
+		return fmt.Errorf("failed to create Kubernetes client: %w", err)
+	}
+
+	analysis, err := client.GetNodeAnalysis()
+	if err != nil {
+		return fmt.Errorf("failed to analyze nodes: %w", err)
+	}
+
+	fmt.Println("🖥️  Node Analysis")
+	fmt.Println(strings.Repeat("=", 80))
+	fmt.Printf("Control plane: %s\n", valueOrUnknown(analysis.ControlPlaneVersion))
+	if !analysis.MetricsAvailable {
+		fmt.Println("Usage: unavailable (metrics-server not installed)")
+	}
+	fmt.Println()
+
+	var nodes []kubernetes.NodeDetail
+	for _, node := range analysis.Nodes {
+		if nodesIssuesOnly && len(node.Issues) == 0 {
+			continue
+		}
+		nodes = append(nodes, node)
+	}
+
+	if len(nodes) == 0 {
+		fmt.Println("✅ No node issues found!")
+		return nil
+	}
+
+	showNodeAllocation(nodes, analysis.MetricsAvailable)
+	showNodeIssues(nodes)
+	showDrainReadiness(nodes)
+
+	if nodesShowTaints {
+		showNodeTaints(nodes)
+	}
+	if nodesShowInventory && !nodesIssuesOnly {
+		showNodeInventory(analysis.Inventory)
+	}
+
+	return nil
+}
+
+func showNodeAllocation(nodes []kubernetes.NodeDetail, metricsAvailable bool) {
+	fmt.Println("📊 ALLOCATION (percent of allocatable)")
+	fmt.Println(strings.Repeat("-", 40))
+
+	allocationTable := table.NewTable([]string{"Node", "Status", "Pods", "CPU Alloc", "CPU Req", "CPU Lim", "CPU Used", "Mem Alloc", "Mem Req", "Mem Lim", "Mem Used"})
+	for _, node := range nodes {
+		cpuUsed, memUsed := "-", "-"
+		if metricsAvailable {
+			cpuUsed = formatAllocation(formatMilliCores(node.CPUUsage), node.CPUUsage, node.CPUAllocatable)
+			memUsed = formatAllocation(formatMemoryBytes(node.MemoryUsage), node.MemoryUsage, node.MemoryAllocatable)
+		}
+
+		allocationTable.AddRow([]string{
+			node.Name,
+			node.Status,
+			fmt.Sprintf("%d/%d", node.Pods, node.PodCapacity),
+			formatMilliCores(node.CPUAllocatable),
+			formatAllocation(formatMilliCores(node.CPURequests), node.CPURequests, node.CPUAllocatable),
+			formatAllocation(formatMilliCores(node.CPULimits), node.CPULimits, node.CPUAllocatable),
+			cpuUsed,
+			formatMemoryBytes(node.MemoryAllocatable),
+			formatAllocation(formatMemoryBytes(node.MemoryRequests), node.MemoryRequests, node.MemoryAllocatable),
+			formatAllocation(formatMemoryBytes(node.MemoryLimits), node.MemoryLimits, node.MemoryAllocatable),
+			memUsed,
+		})
+	}
+	allocationTable.Render()
+	fmt.Println()
+
+	fmt.Println("Overcommit (limits / allocatable):")
+	for _, node := range nodes {
+		icon := "🟢"
+		if node.CPUOvercommit > 1.5 || node.MemoryOvercommit > 1.5 {
+			icon = "🔴"
+		} else if node.CPUOvercommit > 1 || node.MemoryOvercommit > 1 {
+			icon = "🟡"
+		}
+		fmt.Printf("  %s %s: cpu %.2fx, memory %.2fx\n", icon, node.Name, node.CPUOvercommit, node.MemoryOvercommit)
+	}
+	fmt.Println()
+}
+
+func showNodeIssues(nodes []kubernetes.NodeDetail) {
+	fmt.Println("⚠️  CONDITIONS AND ISSUES")
+	fmt.Println(strings.Repeat("-", 40))
+
+	found := false
+	for _, node := range nodes {
+		if len(node.Issues) == 0 {
+			continue
+		}
+		found = true
+
+		fmt.Printf("🔴 %s (%s)\n", node.Name, node.Status)
+		for _, issue := range node.Issues {
+			fmt.Printf("   • %s\n", issue)
+		}
+		for _, condition := range node.Conditions {
+			fmt.Printf("     %s\n", condition)
+		}
+	}
+
+	if !found {
+		fmt.Println("✅ No pressure conditions or node issues found!")
+	}
+	fmt.Println()
+}
+
+func showDrainReadiness(nodes []kubernetes.NodeDetail) {
+	fmt.Println("🚧 DRAIN READINESS")
+	fmt.Println(strings.Repeat("-", 40))
+
+	drainTable := table.NewTable([]string{"Node", "Drain", "Blockers"})
+	for _, node := range nodes {
+		if len(node.DrainBlockers) == 0 {
+			drainTable.AddRow([]string{node.Name, "🟢 Ready", "-"})
+			continue
+		}
+
+		blockers := node.DrainBlockers
+		more := ""
+		if len(blockers) > 3 {
+			more = fmt.Sprintf(" ... and %d more", len(blockers)-3)
+			blockers = blockers[:3]
+		}
+		drainTable.AddRow([]string{node.Name, "🔴 Blocked", strings.Join(blockers, "; ") + more})
+	}
+	drainTable.Render()
+	fmt.Println()
+}
+
+func showNodeTaints(nodes []kubernetes.NodeDetail) {
+	var rows [][]string
+	for _, node := range nodes {
+		if len(node.Taints) > 0 {
+			rows = append(rows, []string{node.Name, node.Roles, strings.Join(node.Taints, ", ")})
+		}
+	}
+	if len(rows) == 0 {
+		return
+	}
+
+	fmt.Println("🚫 TAINTS")
+	fmt.Println(strings.Repeat("-", 40))
+	taintTable := table.NewTable([]string{"Node", "Roles", "Taints"})
+	for _, row := range rows {
+		taintTable.AddRow(row)
+	}
+	taintTable.Render()
+	fmt.Println()
+}
+
+func showNodeInventory(inventory kubernetes.NodeInventory) {
+	fmt.Println("📦 INVENTORY")
+	fmt.Println(strings.Repeat("-", 40))
+
+	inventoryTable := table.NewTable([]string{"Category", "Value", "Nodes"})
+	addInventoryRows(inventoryTable, "Kubelet", inventory.KubeletVersions)
+	addInventoryRows(inventoryTable, "Kernel", inventory.KernelVersions)
+	addInventoryRows(inventoryTable, "OS Image", inventory.OSImages)
+	addInventoryRows(inventoryTable, "Runtime", inventory.ContainerRuntimes)
+	inventoryTable.Render()
+	fmt.Println()
+}
+
+func addInventoryRows(inventoryTable *table.Table, category string, counts map[string]int) {
+	values := make([]string, 0, len(counts))
+	for value := range counts {
+		values = append(values, value)
+	}
+	sort.Strings(values)
+
+	for _, value := range values {
+		inventoryTable.AddRow([]string{category, valueOrUnknown(value), fmt.Sprintf("%d", counts[value])})
+	}
+}
+
+func formatAllocation(value string, amount, allocatable int64) string {
+	if allocatable <= 0 {
+		return value
+	}
+	return fmt.Sprintf("%s (%d%%)", value, amount*100/allocatable)
+}
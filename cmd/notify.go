@@ -0,0 +1,198 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"k8s-cli/pkg/kubernetes"
+	"k8s-cli/pkg/notify"
+	"k8s-cli/pkg/recommendations"
+
+	"github.com/spf13/cobra"
+)
+
+var notifyCmd = &cobra.Command{
+	Use:   "notify",
+	Short: "Send a test alert to the configured notification sinks",
+	Long: `Load a notifier configuration and send a test alert to every sink whose severity filter matches. The same configuration can be passed to logs, workload and recommend with --notify-config to forward Critical events, Critical workloads and High-severity recommendations.
+
+Example configuration (YAML or JSON):
+
+  retries: 3
+  backoff: 2s
+  sinks:
+    - name: oncall
+      type: slack            # webhook, slack, teams or smtp
+      url: https://hooks.slack.com/services/...
+      minSeverity: Critical
+      template: "{{.Severity}} {{.Title}}: {{.Message}}"
+    - name: mail
+      type: smtp
+      smtp:
+        host: smtp.example.com
+        port: 587
+        from: k8s-cli@example.com
+        to: [sre@example.com]
+        username: k8s-cli
+        passwordEnv: SMTP_PASSWORD`,
+	RunE: runNotifyCommand,
+}
+
+var (
+	notifyConfigPath string
+	notifySeverity   string
+)
+
+func init() {
+	rootCmd.AddCommand(notifyCmd)
+	notifyCmd.Flags().StringVar(&notifyConfigPath, "notify-config", "", "Notifier configuration file")
+	notifyCmd.Flags().StringVar(&notifySeverity, "severity", "Critical", "Severity of the test alert")
+	_ = notifyCmd.MarkFlagRequired("notify-config")
+}
+
+func runNotifyCommand(cmd *cobra.Command, args []string) error {
+	notifier, err := loadNotifier(notifyConfigPath)
+	if err != nil {
+		return err
+	}
+
+	alert := notify.Alert{
+		Title:    "k8s-cli test alert",
+		Message:  "This is a test alert sent by k8s-cli notify.",
+		Severity: notifySeverity,
+		Source:   "notify",
+	}
+
+	if err := notifier.Notify(context.Background(), alert); err != nil {
+		return fmt.Errorf("failed to deliver test alert: %w", err)
+	}
+
+	fmt.Println("✅ Test alert delivered to all matching sinks")
+	return nil
+}
+
+// loadNotifier returns nil when no configuration path is given.
+func loadNotifier(path string) (*notify.Notifier, error) {
+	if path == "" {
+		return nil, nil
+	}
+
+	config, err := notify.LoadConfig(path)
+	if err != nil {
+		return nil, err
+	}
+
+	notifier, err := notify.NewNotifier(config)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create notifier: %w", err)
+	}
+
+	return notifier, nil
+}
+
+func sendAlerts(w io.Writer, notifier *notify.Notifier, alerts []notify.Alert) {
+	if notifier == nil || len(alerts) == 0 {
+		return
+	}
+
+	failed := 0
+	for _, alert := range alerts {
+		if err := notifier.Notify(context.Background(), alert); err != nil {
+			fmt.Fprintf(w, "Warning: Could not deliver alert %q: %v\n", alert.Title, err)
+			failed++
+		}
+	}
+
+	fmt.Fprintf(w, "📣 Sent %d alerts (%d failed)\n\n", len(alerts)-failed, failed)
+}
+
+func alertFromClusterEvent(event kubernetes.ClusterEvent) notify.Alert {
+	return notify.Alert{
+		Title:     event.Reason,
+		Message:   event.Message,
+		Severity:  event.Severity,
+		Source:    "logs",
+		Object:    event.Object,
+		Namespace: event.Namespace,
+		Time:      event.LastTime,
+		Fields:    map[string]string{"count": fmt.Sprintf("%d", event.Count), "component": event.Component},
+	}
+}
+
+func alertFromStreamEvent(event kubernetes.StreamEvent) notify.Alert {
+	return notify.Alert{
+		Title:     event.Reason,
+		Message:   event.Message,
+		Severity:  event.Severity,
+		Source:    "logs --follow",
+		Object:    event.Object,
+		Namespace: event.Namespace,
+		Time:      event.Time,
+		Fields:    map[string]string{"suppressed": fmt.Sprintf("%d", event.Suppressed)},
+	}
+}
+
+func alertsFromWorkloadAnalysis(analysis *kubernetes.WorkloadAnalysis) []notify.Alert {
+	var alerts []notify.Alert
+
+	add := func(kind, name, namespace string, issues []string) {
+		alerts = append(alerts, notify.Alert{
+			Title:     fmt.Sprintf("%s %s is Critical", kind, name),
+			Message:   strings.Join(issues, "; "),
+			Severity:  "Critical",
+			Source:    "workload",
+			Object:    kind + "/" + name,
+			Namespace: namespace,
+			Time:      time.Now(),
+		})
+	}
+
+	for _, deploy := range analysis.DeploymentAnalysis {
+		if deploy.Status == "Critical" {
+			add("Deployment", deploy.Name, deploy.Namespace, deploy.Issues)
+		}
+	}
+	for _, ss := range analysis.StatefulSetAnalysis {
+		if ss.Status == "Critical" {
+			add("StatefulSet", ss.Name, ss.Namespace, ss.Issues)
+		}
+	}
+	for _, ds := range analysis.DaemonSetAnalysis {
+		if ds.Status == "Critical" {
+			add("DaemonSet", ds.Name, ds.Namespace, ds.Issues)
+		}
+	}
+	for _, job := range analysis.JobAnalysis {
+		if job.Status == "Critical" {
+			add("Job", job.Name, job.Namespace, job.Issues)
+		}
+	}
+	for _, cronJob := range analysis.CronJobAnalysis {
+		if cronJob.Status == "Critical" {
+			add("CronJob", cronJob.Name, cronJob.Namespace, cronJob.Issues)
+		}
+	}
+
+	return alerts
+}
+
+func alertsFromRecommendations(recs []recommendations.Recommendation) []notify.Alert {
+	var alerts []notify.Alert
+	for _, rec := range recs {
+		if rec.Severity != "High" {
+			continue
+		}
+		alerts = append(alerts, notify.Alert{
+			Title:    rec.Title,
+			Message:  fmt.Sprintf("%s %s", rec.Description, rec.Action),
+			Severity: rec.Severity,
+			Source:   "recommend",
+			Time:     time.Now(),
+			Fields:   map[string]string{"type": rec.Type},
+		})
+	}
+	return alerts
+}
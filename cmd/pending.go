@@ -0,0 +1,105 @@
+package cmd
+
+import (
+	"fmt"
+	"strings"
+
+	"k8s-cli/pkg/kubernetes"
+	"k8s-cli/pkg/table"
+
+	"github.com/spf13/cobra"
+)
+
+var pendingCmd = &cobra.Command{
+	Use:   "pending",
+	Short: "Explain why Pending pods cannot be scheduled",
+	Long:  `For each Pending pod, evaluate every node against the pod's node selector, node affinity, taints and tolerations, topology spread constraints, inter-pod affinity, PVC zone binding and free allocatable CPU/memory after existing requests, and show a per-node fits/doesn't fit matrix.`,
+	RunE:  runPendingCommand,
+}
+
+var (
+	pendingNamespace string
+	pendingPod       string
+	pendingMaxNodes  int
+)
+
+func init() {
+	rootCmd.AddCommand(pendingCmd)
+	pendingCmd.Flags().StringVarP(&pendingNamespace, "namespace", "n", "", "Namespace to analyze (default: all namespaces)")
+	pendingCmd.Flags().StringVar(&pendingPod, "pod", "", "Only explain the pod with this name")
+	pendingCmd.Flags().IntVar(&pendingMaxNodes, "max-nodes", 20, "Maximum number of nodes to show per pod (0 for all)")
+}
+
+func runPendingCommand(cmd *cobra.Command, args []string) error {
+	kubeconfig, _ := cmd.Flags().GetString("kubeconfig")
+
+	client, err := kubernetes.NewClient(kubeconfig)
+	if err != nil {
+		return fmt.Errorf("failed to create Kubernetes client: %w", err)
+	}
+
+	explanations, err := client.GetPendingPodExplanations(pendingNamespace, pendingPod)
+	if err != nil {
+		return fmt.Errorf("failed to analyze pending pods: %w", err)
+	}
+
+	fmt.Println("⏳ Pending Pod Scheduling Analysis")
+	fmt.Println(strings.Repeat("=", 80))
+	fmt.Println()
+
+	if len(explanations) == 0 {
+		fmt.Println("✅ No Pending pods found!")
+		return nil
+	}
+
+	for _, explanation := range explanations {
+		showPendingPodExplanation(&explanation)
+	}
+
+	return nil
+}
+
+func showPendingPodExplanation(explanation *kubernetes.PendingPodExplanation) {
+	status := "🔴"
+	if explanation.FittingNodes > 0 {
+		status = "🟡"
+	}
+	fmt.Printf("%s %s/%s (pending for %s)\n", status, explanation.Namespace, explanation.Name, explanation.Age)
+	fmt.Println(strings.Repeat("-", 40))
+	fmt.Printf("Requests: cpu %s, memory %s\n", formatMilliCores(explanation.CPURequest), formatMemoryBytes(explanation.MemoryRequest))
+	if explanation.SchedulerMessage != "" {
+		fmt.Printf("Scheduler: %s\n", explanation.SchedulerMessage)
+	}
+	for _, issue := range explanation.PodIssues {
+		fmt.Printf("⚠️  %s\n", issue)
+	}
+	fmt.Println()
+
+	nodeTable := table.NewTable([]string{"Node", "Fits", "Reasons"})
+	for i, node := range explanation.Nodes {
+		if pendingMaxNodes > 0 && i >= pendingMaxNodes {
+			fmt.Printf("... and %d more nodes\n", len(explanation.Nodes)-pendingMaxNodes)
+			break
+		}
+
+		fits := "🔴 No"
+		reasons := strings.Join(node.Reasons, "; ")
+		if node.Fits {
+			fits = "🟢 Yes"
+			reasons = "-"
+		}
+		nodeTable.AddRow([]string{node.Node, fits, reasons})
+	}
+	nodeTable.Render()
+	fmt.Println()
+
+	if explanation.FittingNodes > 0 {
+		fmt.Printf("💡 %d node(s) currently fit; the pod may be waiting on preemption, a scheduler backoff or a volume binding.\n", explanation.FittingNodes)
+	} else if len(explanation.ReasonSummary) > 0 {
+		fmt.Println("💡 Why no node fits:")
+		for _, reason := range explanation.ReasonSummary {
+			fmt.Printf("  • %s\n", reason)
+		}
+	}
+	fmt.Println()
+}
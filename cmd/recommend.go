@@ -2,6 +2,8 @@ package cmd
 
 import (
 	"fmt"
+	"io"
+	"os"
 	"strings"
 
 	"k8s-cli/pkg/kubernetes"
@@ -14,22 +16,49 @@ import (
 var recommendCmd = &cobra.Command{
 	Use:   "recommend",
 	Short: "Get recommendations for cluster optimization and best practices",
-	Long:  `Analyze your Kubernetes cluster and provide recommendations for optimization, security, resource management, and best practices.`,
-	RunE:  runRecommendCommand,
+	Long: `Analyze your Kubernetes cluster and provide recommendations for optimization, security, resource management, and best practices.
+
+For CI pipelines, --format sarif or junit emits findings that code-scanning and test-report UIs can display, and --fail-on exits non-zero when findings at or above a severity exist.`,
+	RunE: runRecommendCommand,
 }
 
 var (
-	severityFilter string
-	typeFilter     string
+	severityFilter   string
+	typeFilter       string
+	recommendCatalog string
+	recommendNotify  string
+	recommendFormat  string
+	recommendOutput  string
+	recommendFailOn  string
 )
 
 func init() {
 	rootCmd.AddCommand(recommendCmd)
 	recommendCmd.Flags().StringVar(&severityFilter, "severity", "", "Filter by severity (High, Medium, Low)")
 	recommendCmd.Flags().StringVar(&typeFilter, "type", "", "Filter by type (Resource, Node, Workload, etc.)")
+	recommendCmd.Flags().StringVar(&recommendCatalog, "catalog", "", "Component version catalog file (default: embedded catalog, overridden by ~/.k8s-cli/component-catalog.json)")
+	recommendCmd.Flags().StringVar(&recommendNotify, "notify-config", "", "Notifier configuration file for forwarding High-severity recommendations (see 'k8s-cli notify --help')")
+	recommendCmd.Flags().StringVarP(&recommendFormat, "format", "f", "table", "Output format: table, sarif, junit")
+	recommendCmd.Flags().StringVarP(&recommendOutput, "output", "o", "", "Write sarif or junit output to a file instead of stdout")
+	recommendCmd.Flags().StringVar(&recommendFailOn, "fail-on", "", "Exit non-zero when recommendations at or above this severity exist (high, medium, low)")
 }
 
 func runRecommendCommand(cmd *cobra.Command, args []string) error {
+	switch recommendFormat {
+	case "table", "sarif", "junit":
+	default:
+		return fmt.Errorf("unsupported format: %s (use table, sarif or junit)", recommendFormat)
+	}
+
+	failOn := ""
+	if recommendFailOn != "" {
+		severity, err := recommendations.ParseSeverity(recommendFailOn)
+		if err != nil {
+			return err
+		}
+		failOn = severity
+	}
+
 	kubeconfig, _ := cmd.Flags().GetString("kubeconfig")
 
 	client, err := kubernetes.NewClient(kubeconfig)
@@ -37,10 +66,19 @@ func runRecommendCommand(cmd *cobra.Command, args []string) error {
 		return fmt.Errorf("failed to create Kubernetes client: %w", err)
 	}
 
-	fmt.Println("🔍 Analyzing cluster for recommendations...")
-	fmt.Println()
+	notifier, err := loadNotifier(recommendNotify)
+	if err != nil {
+		return err
+	}
+
+	machineReadable := recommendFormat != "table"
+	if !machineReadable {
+		fmt.Println("🔍 Analyzing cluster for recommendations...")
+		fmt.Println()
+	}
 
 	analyzer := recommendations.NewRecommendationAnalyzer(client)
+	analyzer.SetComponentCatalogPath(recommendCatalog)
 	recs, err := analyzer.AnalyzeCluster()
 	if err != nil {
 		return fmt.Errorf("failed to analyze cluster: %w", err)
@@ -48,15 +86,56 @@ func runRecommendCommand(cmd *cobra.Command, args []string) error {
 
 	filteredRecs := filterRecommendations(recs, severityFilter, typeFilter)
 
-	if len(filteredRecs) == 0 {
+	// Keep stdout a valid SARIF/JUnit document; status lines go to stderr
+	status := cmd.OutOrStdout()
+	if machineReadable {
+		status = cmd.ErrOrStderr()
+		if err := writeRecommendationReport(status, filteredRecs, client.Config.Host, failOn); err != nil {
+			return err
+		}
+	} else if len(filteredRecs) == 0 {
 		fmt.Println("✅ Great! No recommendations found. Your cluster looks well configured!")
-		return nil
+	} else {
+		fmt.Printf("💡 Found %d recommendations:\n\n", len(filteredRecs))
+		showRecommendationsByCategory(filteredRecs)
 	}
 
-	fmt.Printf("💡 Found %d recommendations:\n\n", len(filteredRecs))
+	sendAlerts(status, notifier, alertsFromRecommendations(filteredRecs))
 
-	showRecommendationsByCategory(filteredRecs)
+	if failOn != "" {
+		if count := recommendations.CountAtOrAbove(filteredRecs, failOn); count > 0 {
+			cmd.SilenceUsage = true
+			return fmt.Errorf("%d recommendations at or above %s severity", count, failOn)
+		}
+	}
+
+	return nil
+}
+
+func writeRecommendationReport(status io.Writer, recs []recommendations.Recommendation, server, failOn string) error {
+	var w io.Writer = os.Stdout
+	if recommendOutput != "" {
+		file, err := os.Create(recommendOutput)
+		if err != nil {
+			return fmt.Errorf("failed to create file %s: %w", recommendOutput, err)
+		}
+		defer file.Close()
+		w = file
+	}
+
+	var err error
+	if recommendFormat == "sarif" {
+		err = recommendations.WriteSARIF(w, recs, cliVersion, server)
+	} else {
+		err = recommendations.WriteJUnit(w, recs, failOn)
+	}
+	if err != nil {
+		return err
+	}
 
+	if recommendOutput != "" {
+		fmt.Fprintf(status, "✅ %s report with %d findings saved to: %s\n", strings.ToUpper(recommendFormat), len(recs), recommendOutput)
+	}
 	return nil
 }
 
@@ -0,0 +1,187 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"k8s-cli/pkg/kubernetes"
+	"k8s-cli/pkg/recommendations"
+	"k8s-cli/pkg/report"
+
+	"github.com/spf13/cobra"
+)
+
+var reportCmd = &cobra.Command{
+	Use:   "report",
+	Short: "Generate a self-contained HTML or Markdown cluster report",
+	Long: `Render everything 'k8s-cli all' shows (version, components, resources, metrics, cost, workloads, events and recommendations) into a single readable file. HTML reports have sortable tables and inline SVG charts and need no external assets.
+
+Reports are built from Go templates. Print the built-in template with --dump-template, edit it and pass it back with --template.`,
+	RunE: runReportCommand,
+}
+
+var (
+	reportFormat       string
+	reportOutput       string
+	reportTemplate     string
+	reportTitle        string
+	reportCatalog      string
+	reportDumpTemplate bool
+)
+
+func init() {
+	rootCmd.AddCommand(reportCmd)
+	reportCmd.Flags().StringVarP(&reportFormat, "format", "f", "html", "Report format: html, markdown")
+	reportCmd.Flags().StringVarP(&reportOutput, "output", "o", "", "Report file (default k8s-report-<timestamp>.html or .md, '-' for stdout)")
+	reportCmd.Flags().StringVar(&reportTemplate, "template", "", "Go template file overriding the built-in template")
+	reportCmd.Flags().StringVar(&reportTitle, "title", "Kubernetes Cluster Report", "Report title")
+	reportCmd.Flags().StringVar(&reportCatalog, "catalog", "", "Component version catalog file for recommendations")
+	reportCmd.Flags().BoolVar(&reportDumpTemplate, "dump-template", false, "Print the built-in template for --format and exit")
+}
+
+func runReportCommand(cmd *cobra.Command, args []string) error {
+	if reportDumpTemplate {
+		source, err := report.DefaultTemplate(reportFormat)
+		if err != nil {
+			return err
+		}
+		fmt.Print(source)
+		return nil
+	}
+
+	extension := ".html"
+	switch reportFormat {
+	case "html":
+	case "markdown", "md":
+		extension = ".md"
+	default:
+		return fmt.Errorf("unsupported report format: %s", reportFormat)
+	}
+
+	kubeconfig, _ := cmd.Flags().GetString("kubeconfig")
+
+	client, err := kubernetes.NewClient(kubeconfig)
+	if err != nil {
+		return fmt.Errorf("failed to create Kubernetes client: %w", err)
+	}
+
+	toStdout := reportOutput == "-"
+	if !toStdout {
+		fmt.Printf("📝 Generating %s report...\n", strings.ToUpper(reportFormat))
+		fmt.Println(strings.Repeat("=", 50))
+	}
+
+	data := collectReportData(client, !toStdout)
+
+	if toStdout {
+		return report.Render(os.Stdout, reportFormat, data, reportTemplate)
+	}
+
+	path := reportOutput
+	if path == "" {
+		path = fmt.Sprintf("k8s-report-%s%s", time.Now().Format("2006-01-02-15-04-05"), extension)
+	}
+
+	file, err := os.Create(path)
+	if err != nil {
+		return fmt.Errorf("failed to create file %s: %w", path, err)
+	}
+	defer file.Close()
+
+	if err := report.Render(file, reportFormat, data, reportTemplate); err != nil {
+		return fmt.Errorf("failed to render report: %w", err)
+	}
+
+	if len(data.Warnings) > 0 {
+		fmt.Printf("⚠️  %d sections could not be collected (listed in the report)\n", len(data.Warnings))
+	}
+	fmt.Printf("✅ Report saved to: %s\n", path)
+	return nil
+}
+
+// collectReportData gathers the same sections as the all command. Failures
+// are recorded as warnings so a partial report is still produced.
+func collectReportData(client *kubernetes.Client, progress bool) *report.Data {
+	data := &report.Data{
+		Title:       reportTitle,
+		GeneratedAt: time.Now(),
+		Server:      client.Config.Host,
+	}
+
+	step := func(message string) {
+		if progress {
+			fmt.Println(message)
+		}
+	}
+	warn := func(section string, err error) {
+		data.Warnings = append(data.Warnings, fmt.Sprintf("%s: %v", section, err))
+	}
+
+	step("📊 Collecting version and components...")
+	if version, err := client.GetClusterVersion(); err == nil {
+		data.ClusterVersion = version
+	} else {
+		warn("Version", err)
+	}
+	if components, err := client.GetInstalledComponents(); err == nil {
+		data.Components = components
+	} else {
+		warn("Components", err)
+	}
+
+	step("📈 Collecting resources and metrics...")
+	if summary, err := client.GetSimpleClusterSummary(); err == nil {
+		data.Summary = summary
+	} else {
+		warn("Resources", err)
+	}
+	if nodes, err := client.GetSimpleNodesInfo(); err == nil {
+		data.Nodes = nodes
+	}
+	if clusterMetrics, err := client.GetClusterMetrics(); err == nil {
+		data.ClusterMetrics = clusterMetrics
+	} else {
+		warn("Metrics", err)
+	}
+	if nodeMetrics, err := client.GetRealTimeNodeMetrics(); err == nil {
+		data.NodeMetrics = nodeMetrics
+	}
+
+	step("💰 Collecting cost analysis...")
+	if costAnalysis, err := client.GetCostAnalysis(); err == nil {
+		data.CostAnalysis = costAnalysis
+	} else {
+		warn("Cost", err)
+	}
+
+	step("🔍 Collecting workload health...")
+	if workload, err := client.GetWorkloadAnalysis(""); err == nil {
+		data.Workload = workload
+	} else {
+		warn("Workloads", err)
+	}
+
+	step("🚨 Collecting critical events...")
+	if events, err := client.GetClusterEvents("", 1); err == nil {
+		for _, event := range events {
+			if event.Severity == "Critical" {
+				data.CriticalEvents = append(data.CriticalEvents, event)
+			}
+		}
+	} else {
+		warn("Events", err)
+	}
+
+	step("💡 Collecting recommendations...")
+	analyzer := recommendations.NewRecommendationAnalyzer(client)
+	analyzer.SetComponentCatalogPath(reportCatalog)
+	if recs, err := analyzer.AnalyzeCluster(); err == nil {
+		data.Recommendations = recs
+	} else {
+		warn("Recommendations", err)
+	}
+
+	return data
+}
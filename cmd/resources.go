@@ -93,7 +93,7 @@ func showNodesResources(client *kubernetes.Client) error {
 	}
 
 	fmt.Println("🖥️  Node Resources:")
-	nodeTable := table.NewTable([]string{"Node", "Status", "Role", "Age", "Version", "CPU Capacity", "Memory Capacity"})
+	nodeTable := table.NewTable([]string{"Node", "Status", "Role", "Age", "Version", "CPU Capacity", "Memory Capacity", "CPU Allocatable", "Memory Allocatable"})
 
 	for _, node := range nodes {
 		nodeTable.AddRow([]string{
@@ -104,6 +104,8 @@ func showNodesResources(client *kubernetes.Client) error {
 			node.Version,
 			node.CPUCapacity,
 			node.MemoryCapacity,
+			node.CPUAllocatable,
+			node.MemoryAllocatable,
 		})
 	}
 
@@ -0,0 +1,172 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"k8s-cli/pkg/kubernetes"
+	"k8s-cli/pkg/table"
+
+	"github.com/spf13/cobra"
+)
+
+var rightsizeCmd = &cobra.Command{
+	Use:   "rightsize",
+	Short: "Recommend container requests and limits from observed usage",
+	Long: `Sample pod metrics and recommend per-container CPU and memory requests and limits at a usage percentile plus headroom. VPA targets are used instead of observed usage when a VPA covers the workload.
+
+With --emit-patch the recommendations are written as strategic-merge patches (one file per Deployment, StatefulSet or DaemonSet) for a GitOps repository; --kustomize-base adds a kustomization.yaml overlaying that base. Nothing is ever applied to the cluster.`,
+	RunE: runRightsizeCommand,
+}
+
+var (
+	rightsizeNamespace     string
+	rightsizePercentile    float64
+	rightsizeHeadroom      float64
+	rightsizeSamples       int
+	rightsizeInterval      time.Duration
+	rightsizeShowAll       bool
+	rightsizeEmitPatch     string
+	rightsizeKustomizeBase string
+)
+
+func init() {
+	rootCmd.AddCommand(rightsizeCmd)
+	rightsizeCmd.Flags().StringVarP(&rightsizeNamespace, "namespace", "n", "", "Namespace to analyze (empty for all)")
+	rightsizeCmd.Flags().Float64Var(&rightsizePercentile, "percentile", 95, "Usage percentile requests are sized at")
+	rightsizeCmd.Flags().Float64Var(&rightsizeHeadroom, "headroom", 0.2, "Headroom added on top of the usage percentile (0.2 = 20%)")
+	rightsizeCmd.Flags().IntVar(&rightsizeSamples, "samples", 10, "Number of metrics samples to take")
+	rightsizeCmd.Flags().DurationVar(&rightsizeInterval, "interval", 30*time.Second, "Interval between metrics samples")
+	rightsizeCmd.Flags().BoolVar(&rightsizeShowAll, "all", false, "Show containers whose resources already match usage")
+	rightsizeCmd.Flags().StringVar(&rightsizeEmitPatch, "emit-patch", "", "Directory to write strategic-merge patches to")
+	rightsizeCmd.Flags().StringVar(&rightsizeKustomizeBase, "kustomize-base", "", "Also write a kustomization.yaml overlaying this base (used with --emit-patch)")
+}
+
+func runRightsizeCommand(cmd *cobra.Command, args []string) error {
+	kubeconfig, _ := cmd.Flags().GetString("kubeconfig")
+
+	if rightsizePercentile <= 0 || rightsizePercentile > 100 {
+		return fmt.Errorf("--percentile must be between 0 and 100")
+	}
+	if rightsizeHeadroom < 0 {
+		return fmt.Errorf("--headroom cannot be negative")
+	}
+	if rightsizeKustomizeBase != "" && rightsizeEmitPatch == "" {
+		return fmt.Errorf("--kustomize-base requires --emit-patch")
+	}
+
+	client, err := kubernetes.NewClient(kubeconfig)
+	if err != nil {
+		return fmt.Errorf("failed to create Kubernetes client: %w", err)
+	}
+
+	fmt.Println("📏 Rightsizing Recommendations")
+	fmt.Println(strings.Repeat("=", 80))
+	if rightsizeSamples > 1 {
+		fmt.Printf("Taking %d samples %s apart...\n", rightsizeSamples, rightsizeInterval)
+	}
+
+	report, err := client.GetRightsizingReport(kubernetes.RightsizingOptions{
+		Namespace:  rightsizeNamespace,
+		Percentile: rightsizePercentile,
+		Headroom:   rightsizeHeadroom,
+		Samples:    rightsizeSamples,
+		Interval:   rightsizeInterval,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to compute rightsizing: %w", err)
+	}
+
+	fmt.Printf("Sized at p%g usage + %.0f%% headroom over %d sample(s)\n", rightsizePercentile, rightsizeHeadroom*100, report.Samples)
+	if report.Samples < kubernetes.MinRightsizingSamples {
+		fmt.Printf("⚠️  Fewer than %d samples: usage-based recommendations are low confidence and are not written as patches\n", kubernetes.MinRightsizingSamples)
+	}
+	fmt.Println()
+
+	showRightsizing(report)
+
+	if rightsizeEmitPatch != "" {
+		return writeRightsizingPatches(report)
+	}
+
+	return nil
+}
+
+func showRightsizing(report *kubernetes.RightsizingReport) {
+	rightsizeTable := table.NewTable([]string{"Workload", "Namespace", "Container", "CPU Request", "CPU Limit", "Memory Request", "Memory Limit", "Source"})
+
+	rows := 0
+	for _, workload := range report.Workloads {
+		for _, container := range workload.Containers {
+			if !container.Changed && !rightsizeShowAll {
+				continue
+			}
+			rows++
+
+			source := container.Source
+			if container.LowConfidence {
+				source += fmt.Sprintf(" (low confidence, %d samples)", container.Samples)
+			}
+
+			rightsizeTable.AddRow([]string{
+				workload.Kind + "/" + workload.Name,
+				workload.Namespace,
+				container.Container,
+				kubernetes.FormatResourceChange(container.CPURequest, container.RecommendedCPURequest, false),
+				kubernetes.FormatResourceChange(container.CPULimit, container.RecommendedCPULimit, false),
+				kubernetes.FormatResourceChange(container.MemoryRequest, container.RecommendedMemoryRequest, true),
+				kubernetes.FormatResourceChange(container.MemoryLimit, container.RecommendedMemoryLimit, true),
+				source,
+			})
+		}
+	}
+
+	if rows == 0 {
+		fmt.Println("✅ All container requests match observed usage!")
+		fmt.Println()
+		return
+	}
+
+	rightsizeTable.Render()
+	fmt.Println()
+
+	if report.MonthlySavings >= 0 {
+		fmt.Printf("💰 Estimated monthly savings: $%.2f\n", report.MonthlySavings)
+	} else {
+		fmt.Printf("💰 Estimated monthly cost increase: $%.2f (some workloads are under-requested)\n", -report.MonthlySavings)
+	}
+	fmt.Println()
+}
+
+func writeRightsizingPatches(report *kubernetes.RightsizingReport) error {
+	patches, err := kubernetes.BuildRightsizingPatches(report, rightsizeKustomizeBase)
+	if err != nil {
+		return err
+	}
+
+	if len(patches) == 0 {
+		fmt.Println("No Deployment, StatefulSet or DaemonSet needs a patch.")
+		return nil
+	}
+
+	if err := os.MkdirAll(rightsizeEmitPatch, 0755); err != nil {
+		return fmt.Errorf("failed to create directory %s: %w", rightsizeEmitPatch, err)
+	}
+
+	fmt.Printf("📝 PATCHES (%s)\n", rightsizeEmitPatch)
+	fmt.Println(strings.Repeat("-", 40))
+	for _, patch := range patches {
+		path := filepath.Join(rightsizeEmitPatch, patch.Filename)
+		if err := os.WriteFile(path, patch.Content, 0644); err != nil {
+			return fmt.Errorf("failed to write %s: %w", path, err)
+		}
+		fmt.Printf("  • %s\n", path)
+	}
+	fmt.Println()
+	fmt.Println("💡 Review and commit the patches; nothing was applied to the cluster.")
+
+	return nil
+}
@@ -0,0 +1,182 @@
+package cmd
+
+import (
+	"fmt"
+	"strings"
+
+	"k8s-cli/pkg/kubernetes"
+	"k8s-cli/pkg/table"
+
+	"github.com/spf13/cobra"
+)
+
+var rolloutCmd = &cobra.Command{
+	Use:   "rollout",
+	Short: "Track deployment rollouts and revision history",
+	Long:  `Reconstruct a Deployment's rollout history from its ReplicaSet chain: revisions, image changes between them, change-cause and rollout time. The status subcommand also reports whether the latest rollout is progressing, stalled or complete, and flags it as suspicious when restarts or warning events started after it.`,
+}
+
+var rolloutStatusCmd = &cobra.Command{
+	Use:   "status <deploy/name>",
+	Short: "Show the state of the latest rollout and problems that followed it",
+	Args:  cobra.ExactArgs(1),
+	RunE:  runRolloutStatusCommand,
+}
+
+var rolloutHistoryCmd = &cobra.Command{
+	Use:   "history <deploy/name>",
+	Short: "Show revisions with image changes and change-cause",
+	Args:  cobra.ExactArgs(1),
+	RunE:  runRolloutHistoryCommand,
+}
+
+var (
+	rolloutNamespace string
+	rolloutMaxEvents int
+)
+
+func init() {
+	rootCmd.AddCommand(rolloutCmd)
+	rolloutCmd.AddCommand(rolloutStatusCmd)
+	rolloutCmd.AddCommand(rolloutHistoryCmd)
+	rolloutCmd.PersistentFlags().StringVarP(&rolloutNamespace, "namespace", "n", "default", "Namespace of the deployment")
+	rolloutStatusCmd.Flags().IntVar(&rolloutMaxEvents, "max-events", 10, "Maximum number of warning events to show")
+}
+
+func getRolloutStatus(cmd *cobra.Command, target string) (*kubernetes.RolloutStatus, error) {
+	kubeconfig, _ := cmd.Flags().GetString("kubeconfig")
+
+	client, err := kubernetes.NewClient(kubeconfig)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create Kubernetes client: %w", err)
+	}
+
+	status, err := client.GetRolloutStatus(target, rolloutNamespace)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get rollout of %s: %w", target, err)
+	}
+	return status, nil
+}
+
+func runRolloutStatusCommand(cmd *cobra.Command, args []string) error {
+	status, err := getRolloutStatus(cmd, args[0])
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("🚢 Rollout Status: deployment/%s (namespace %s)\n", status.Name, status.Namespace)
+	fmt.Println(strings.Repeat("=", 80))
+	fmt.Println()
+
+	state := status.State
+	switch status.State {
+	case "Stalled":
+		state = "🔴 " + state
+	case "Progressing", "Paused":
+		state = "🟡 " + state
+	default:
+		state = "🟢 " + state
+	}
+
+	overviewTable := table.NewTable([]string{"Metric", "Value"})
+	overviewTable.AddRow([]string{"State", state})
+	overviewTable.AddRow([]string{"Message", status.Message})
+	overviewTable.AddRow([]string{"Revision", fmt.Sprintf("%d", status.Revision)})
+	overviewTable.AddRow([]string{"Updated/Ready/Available", fmt.Sprintf("%d/%d/%d of %d", status.UpdatedReplicas, status.ReadyReplicas, status.AvailableReplicas, status.DesiredReplicas)})
+	if !status.RolloutTime.IsZero() {
+		overviewTable.AddRow([]string{"Rolled Out", status.RolloutTime.Format("2006-01-02 15:04:05")})
+	}
+	overviewTable.AddRow([]string{"Restarts Since Rollout", fmt.Sprintf("%d", status.Restarts)})
+	if len(status.History) > 0 {
+		latest := status.History[len(status.History)-1]
+		overviewTable.AddRow([]string{"Change Cause", latest.ChangeCause})
+		if len(latest.ImageChanges) > 0 {
+			overviewTable.AddRow([]string{"Image Changes", strings.Join(latest.ImageChanges, ", ")})
+		}
+	}
+	overviewTable.Render()
+	fmt.Println()
+
+	if len(status.Events) > 0 {
+		fmt.Println("⚠️  WARNING EVENTS SINCE ROLLOUT")
+		fmt.Println(strings.Repeat("-", 40))
+
+		eventTable := table.NewTable([]string{"Object", "Reason", "Count", "Last Seen", "Message"})
+		for i, event := range status.Events {
+			if rolloutMaxEvents > 0 && i >= rolloutMaxEvents {
+				break
+			}
+			message := event.Message
+			if len(message) > 60 {
+				message = message[:57] + "..."
+			}
+			eventTable.AddRow([]string{
+				event.Object,
+				event.Reason,
+				fmt.Sprintf("%d", event.Count),
+				event.LastTime.Format("15:04:05"),
+				message,
+			})
+		}
+		eventTable.Render()
+		fmt.Println()
+	}
+
+	if status.Suspicious {
+		fmt.Println("🔴 SUSPICIOUS DEPLOY")
+		fmt.Println(strings.Repeat("-", 40))
+	}
+	for _, finding := range status.Findings {
+		fmt.Printf("  • %s\n", finding)
+	}
+	if !status.Suspicious && status.State == "Complete" {
+		fmt.Println("✅ No restarts or warning events since the rollout!")
+	}
+
+	return nil
+}
+
+func runRolloutHistoryCommand(cmd *cobra.Command, args []string) error {
+	status, err := getRolloutStatus(cmd, args[0])
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("📜 Rollout History: deployment/%s (namespace %s)\n", status.Name, status.Namespace)
+	fmt.Println(strings.Repeat("=", 80))
+	fmt.Println()
+
+	if len(status.History) == 0 {
+		fmt.Println("No revisions found.")
+		return nil
+	}
+
+	historyTable := table.NewTable([]string{"Revision", "ReplicaSet", "Replicas", "Rolled Out", "Change Cause", "Image Changes"})
+	for i := len(status.History) - 1; i >= 0; i-- {
+		revision := status.History[i]
+
+		label := fmt.Sprintf("%d", revision.Revision)
+		if revision.Current {
+			label += " (current)"
+		}
+
+		changes := "-"
+		if len(revision.ImageChanges) > 0 {
+			changes = strings.Join(revision.ImageChanges, ", ")
+		} else if i == 0 {
+			changes = strings.Join(revision.Images, ", ")
+		}
+
+		historyTable.AddRow([]string{
+			label,
+			revision.ReplicaSet,
+			fmt.Sprintf("%d/%d", revision.ReadyReplicas, revision.Replicas),
+			revision.CreatedAt.Format("2006-01-02 15:04:05"),
+			revision.ChangeCause,
+			changes,
+		})
+	}
+	historyTable.Render()
+
+	return nil
+}
@@ -0,0 +1,95 @@
+package cmd
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"k8s-cli/pkg/export"
+	"k8s-cli/pkg/kubernetes"
+	"k8s-cli/pkg/recommendations"
+
+	"github.com/spf13/cobra"
+)
+
+var snapshotCmd = &cobra.Command{
+	Use:   "snapshot",
+	Short: "Capture the cluster state to a file for later comparison",
+	Long:  `Persist a full export (metrics, costs, logs, events) together with the workload analysis, installed components and recommendations. Compare two snapshots offline with 'k8s-cli diff', e.g. before and after a maintenance window.`,
+}
+
+var snapshotSaveCmd = &cobra.Command{
+	Use:   "save",
+	Short: "Save a snapshot of the cluster",
+	RunE:  runSnapshotSaveCommand,
+}
+
+var (
+	snapshotOutput    string
+	snapshotNamespace string
+	snapshotHours     int
+	snapshotCatalog   string
+)
+
+func init() {
+	rootCmd.AddCommand(snapshotCmd)
+	snapshotCmd.AddCommand(snapshotSaveCmd)
+	snapshotSaveCmd.Flags().StringVarP(&snapshotOutput, "output", "o", "", "Snapshot file (default k8s-snapshot-<timestamp>.json)")
+	snapshotSaveCmd.Flags().StringVarP(&snapshotNamespace, "namespace", "n", "", "Namespace to capture (empty for all)")
+	snapshotSaveCmd.Flags().IntVar(&snapshotHours, "hours", 24, "Hours of events/logs to include")
+	snapshotSaveCmd.Flags().StringVar(&snapshotCatalog, "catalog", "", "Component version catalog file for recommendations")
+}
+
+func runSnapshotSaveCommand(cmd *cobra.Command, args []string) error {
+	kubeconfig, _ := cmd.Flags().GetString("kubeconfig")
+
+	client, err := kubernetes.NewClient(kubeconfig)
+	if err != nil {
+		return fmt.Errorf("failed to create Kubernetes client: %w", err)
+	}
+
+	fmt.Println("📸 Capturing cluster snapshot...")
+	fmt.Println(strings.Repeat("=", 50))
+
+	snapshot := &export.Snapshot{
+		ExportData: *collectExportData(client, snapshotNamespace, snapshotHours, true, true, true, true),
+		Server:     client.Config.Host,
+	}
+
+	fmt.Println("🖥️  Collecting versions and nodes...")
+	if version, err := client.GetClusterVersion(); err == nil {
+		snapshot.ClusterVersion = version
+	}
+	if nodes, err := client.GetNodeAnalysis(); err == nil {
+		snapshot.Nodes = nodes
+	}
+
+	fmt.Println("🔍 Collecting workload analysis...")
+	if workload, err := client.GetWorkloadAnalysis(snapshotNamespace); err == nil {
+		snapshot.Workload = workload
+	}
+
+	fmt.Println("🧩 Collecting installed components...")
+	if components, err := client.GetInstalledComponents(); err == nil {
+		snapshot.Components = components
+	}
+
+	fmt.Println("💡 Collecting recommendations...")
+	analyzer := recommendations.NewRecommendationAnalyzer(client)
+	analyzer.SetComponentCatalogPath(snapshotCatalog)
+	if recs, err := analyzer.AnalyzeCluster(); err == nil {
+		snapshot.Recommendations = recs
+	}
+
+	path := snapshotOutput
+	if path == "" {
+		path = fmt.Sprintf("k8s-snapshot-%s.json", time.Now().Format("2006-01-02-15-04-05"))
+	}
+	if err := export.SaveSnapshot(snapshot, path); err != nil {
+		return err
+	}
+
+	fmt.Printf("✅ Snapshot saved to: %s\n", path)
+	fmt.Println("💡 Compare it later with 'k8s-cli diff <before.json> <after.json>'")
+	return nil
+}
@@ -0,0 +1,120 @@
+package cmd
+
+import (
+	"fmt"
+	"strings"
+
+	"k8s-cli/pkg/kubernetes"
+	"k8s-cli/pkg/table"
+
+	"github.com/spf13/cobra"
+)
+
+var upgradeCheckCmd = &cobra.Command{
+	Use:   "upgrade-check",
+	Short: "Detect deprecated and removed APIs before a Kubernetes upgrade",
+	Long:  `Scan live objects, last-applied configurations and Helm release manifests for apiVersions that are deprecated or removed in the target Kubernetes version.`,
+	RunE:  runUpgradeCheckCommand,
+}
+
+var (
+	upgradeTargetVersion string
+	showServedDeprecated bool
+)
+
+func init() {
+	rootCmd.AddCommand(upgradeCheckCmd)
+	upgradeCheckCmd.Flags().StringVar(&upgradeTargetVersion, "target", "", "Target Kubernetes version, e.g. 1.32 (default: next minor version)")
+	upgradeCheckCmd.Flags().BoolVar(&showServedDeprecated, "served", false, "Show deprecated API versions still served by the cluster")
+}
+
+func runUpgradeCheckCommand(cmd *cobra.Command, args []string) error {
+	kubeconfig, _ := cmd.Flags().GetString("kubeconfig")
+
+	client, err := kubernetes.NewClient(kubeconfig)
+	if err != nil {
+		return fmt.Errorf("failed to create Kubernetes client: %w", err)
+	}
+
+	result, err := client.GetUpgradeCheck(upgradeTargetVersion)
+	if err != nil {
+		return fmt.Errorf("failed to run upgrade check: %w", err)
+	}
+
+	fmt.Printf("⬆️  Upgrade Check: %s → %s\n", result.CurrentVersion, result.TargetVersion)
+	fmt.Println(strings.Repeat("=", 80))
+	fmt.Println()
+
+	summaryTable := table.NewTable([]string{"Metric", "Value"})
+	summaryTable.AddRow([]string{"Current Version", result.CurrentVersion})
+	summaryTable.AddRow([]string{"Target Version", result.TargetVersion})
+	summaryTable.AddRow([]string{"Objects Using Removed APIs", fmt.Sprintf("%d", result.RemovedCount)})
+	summaryTable.AddRow([]string{"Objects Using Deprecated APIs", fmt.Sprintf("%d", result.DeprecatedCount)})
+	summaryTable.AddRow([]string{"Deprecated APIs Served", fmt.Sprintf("%d", len(result.ServedDeprecatedAPIs))})
+	summaryTable.Render()
+	fmt.Println()
+
+	showDeprecatedAPIFindings(result.Findings)
+
+	if showServedDeprecated {
+		showServedDeprecatedAPIs(result.ServedDeprecatedAPIs)
+	}
+
+	return nil
+}
+
+func showDeprecatedAPIFindings(findings []kubernetes.DeprecatedAPIFinding) {
+	if len(findings) == 0 {
+		fmt.Println("✅ No objects using deprecated or removed APIs found!")
+		fmt.Println()
+		return
+	}
+
+	fmt.Println("🚧 DEPRECATED API USAGE")
+	fmt.Println(strings.Repeat("-", 40))
+
+	findingsTable := table.NewTable([]string{"Kind", "Name", "Namespace", "API Version", "Replacement", "Status", "Source"})
+	for _, finding := range findings {
+		status := finding.Status
+		if finding.Severity == "High" {
+			status = "🔴 " + status
+		} else {
+			status = "🟡 " + status
+		}
+
+		namespace := finding.Namespace
+		if namespace == "" {
+			namespace = "-"
+		}
+
+		findingsTable.AddRow([]string{
+			finding.Kind,
+			finding.Name,
+			namespace,
+			finding.APIVersion,
+			finding.Replacement,
+			status,
+			finding.Source,
+		})
+	}
+	findingsTable.Render()
+	fmt.Println()
+}
+
+func showServedDeprecatedAPIs(apis []kubernetes.DeprecatedAPI) {
+	if len(apis) == 0 {
+		fmt.Println("✅ The cluster does not serve any deprecated API versions from the deprecation table.")
+		fmt.Println()
+		return
+	}
+
+	fmt.Println("📡 DEPRECATED APIS SERVED BY THE CLUSTER")
+	fmt.Println(strings.Repeat("-", 40))
+
+	servedTable := table.NewTable([]string{"API Version", "Kind", "Deprecated In", "Removed In", "Replacement"})
+	for _, api := range apis {
+		servedTable.AddRow([]string{api.APIVersion, api.Kind, api.DeprecatedIn, api.RemovedIn, api.Replacement})
+	}
+	servedTable.Render()
+	fmt.Println()
+}
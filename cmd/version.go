@@ -2,6 +2,7 @@ package cmd
 
 import (
 	"fmt"
+	"strings"
 
 	"k8s-cli/pkg/kubernetes"
 	"k8s-cli/pkg/table"
@@ -16,8 +17,15 @@ var versionCmd = &cobra.Command{
 	RunE:  runVersionCommand,
 }
 
+var (
+	checkComponentVersions bool
+	versionCatalogPath     string
+)
+
 func init() {
 	rootCmd.AddCommand(versionCmd)
+	versionCmd.Flags().BoolVar(&checkComponentVersions, "check-versions", false, "Compare installed components against the version catalog")
+	versionCmd.Flags().StringVar(&versionCatalogPath, "catalog", "", "Component version catalog file (default: embedded catalog, overridden by ~/.k8s-cli/component-catalog.json)")
 }
 
 func runVersionCommand(cmd *cobra.Command, args []string) error {
@@ -71,11 +79,77 @@ func runVersionCommand(cmd *cobra.Command, args []string) error {
 	fmt.Printf("   Found %d components:\n", len(components))
 	fmt.Println()
 
-	componentTable := table.NewTable([]string{"Component", "Namespace", "Status", "Version", "Ready", "Source"})
+	componentTable := table.NewTable([]string{"Component", "Namespace", "Status", "Version", "Ready", "Source", "Chart", "Revision"})
 	for _, comp := range components {
-		componentTable.AddRow([]string{comp.Name, comp.Namespace, comp.Status, comp.Version, comp.Ready, comp.Source})
+		chart, revision := "-", "-"
+		if comp.Chart != "" {
+			chart = comp.Chart + "-" + comp.ChartVersion
+		}
+		if comp.Revision > 0 {
+			revision = fmt.Sprintf("%d", comp.Revision)
+		}
+		componentTable.AddRow([]string{comp.Name, comp.Namespace, comp.Status, comp.Version, comp.Ready, comp.Source, chart, revision})
 	}
 	componentTable.Render()
 
+	if checkComponentVersions {
+		if err := showComponentVersionStatus(client, versionCatalogPath); err != nil {
+			fmt.Printf("Warning: Could not check component versions: %v\n", err)
+		}
+	}
+
+	return nil
+}
+
+func showComponentVersionStatus(client *kubernetes.Client, catalogPath string) error {
+	catalog, err := kubernetes.LoadComponentCatalog(catalogPath)
+	if err != nil {
+		return err
+	}
+
+	statuses, err := client.GetComponentVersionStatus(catalog)
+	if err != nil {
+		return err
+	}
+
+	fmt.Println()
+	fmt.Printf("📦 Component Version Check (catalog updated %s):\n", catalog.Updated)
+
+	if len(statuses) == 0 {
+		fmt.Println("   No installed components matched the version catalog.")
+		return nil
+	}
+
+	statusTable := table.NewTable([]string{"Component", "Namespace", "Installed", "Latest", "EOL", "Status", "Issues"})
+	for _, status := range statuses {
+		state := status.Status
+		switch status.Severity {
+		case "High":
+			state = "🔴 " + state
+		case "Medium":
+			state = "🟠 " + state
+		case "Low":
+			state = "🟡 " + state
+		default:
+			state = "🟢 " + state
+		}
+
+		eol := status.EOL
+		if eol == "" {
+			eol = "-"
+		}
+
+		statusTable.AddRow([]string{
+			status.Name,
+			status.Namespace,
+			status.InstalledVersion,
+			status.LatestVersion,
+			eol,
+			state,
+			strings.Join(status.Issues, "; "),
+		})
+	}
+	statusTable.Render()
+
 	return nil
 }
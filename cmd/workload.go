@@ -13,7 +13,7 @@ import (
 var workloadCmd = &cobra.Command{
 	Use:   "workload",
 	Short: "Analyze workload health and performance across the cluster",
-	Long:  `Comprehensive analysis of deployments, statefulsets, daemonsets, and pods to identify health issues, performance problems, and optimization opportunities.`,
+	Long:  `Comprehensive analysis of deployments, statefulsets, daemonsets, jobs, cronjobs, and pods to identify health issues, performance problems, and optimization opportunities.`,
 	RunE:  runWorkloadCommand,
 }
 
@@ -21,10 +21,15 @@ var (
 	showWorkloadDeployments  bool
 	showWorkloadStatefulSets bool
 	showWorkloadDaemonSets   bool
+	showWorkloadJobs         bool
+	showWorkloadCronJobs     bool
 	showWorkloadPods         bool
+	showWorkloadPDBs         bool
+	showWorkloadAutoscalers  bool
 	showWorkloadSummary      bool
 	workloadNamespace        string
 	onlyUnhealthy            bool
+	workloadNotifyConfig     string
 )
 
 func init() {
@@ -32,10 +37,15 @@ func init() {
 	workloadCmd.Flags().BoolVar(&showWorkloadDeployments, "deployments", true, "Show deployment analysis")
 	workloadCmd.Flags().BoolVar(&showWorkloadStatefulSets, "statefulsets", true, "Show statefulset analysis")
 	workloadCmd.Flags().BoolVar(&showWorkloadDaemonSets, "daemonsets", true, "Show daemonset analysis")
+	workloadCmd.Flags().BoolVar(&showWorkloadJobs, "jobs", true, "Show job analysis")
+	workloadCmd.Flags().BoolVar(&showWorkloadCronJobs, "cronjobs", true, "Show cronjob analysis")
 	workloadCmd.Flags().BoolVar(&showWorkloadPods, "pods", false, "Show detailed pod analysis")
+	workloadCmd.Flags().BoolVar(&showWorkloadPDBs, "pdbs", true, "Show PodDisruptionBudget audit")
+	workloadCmd.Flags().BoolVar(&showWorkloadAutoscalers, "autoscalers", true, "Show HPA and VPA analysis")
 	workloadCmd.Flags().BoolVar(&showWorkloadSummary, "summary", true, "Show workload summary")
 	workloadCmd.Flags().StringVarP(&workloadNamespace, "namespace", "n", "", "Namespace to analyze (empty for all)")
 	workloadCmd.Flags().BoolVar(&onlyUnhealthy, "unhealthy-only", false, "Show only unhealthy workloads")
+	workloadCmd.Flags().StringVar(&workloadNotifyConfig, "notify-config", "", "Notifier configuration file for forwarding critical workloads (see 'k8s-cli notify --help')")
 }
 
 func runWorkloadCommand(cmd *cobra.Command, args []string) error {
@@ -46,6 +56,11 @@ func runWorkloadCommand(cmd *cobra.Command, args []string) error {
 		return fmt.Errorf("failed to create Kubernetes client: %w", err)
 	}
 
+	notifier, err := loadNotifier(workloadNotifyConfig)
+	if err != nil {
+		return err
+	}
+
 	fmt.Println("🔍 Workload Health Analysis")
 	fmt.Println(strings.Repeat("=", 80))
 	fmt.Println()
@@ -71,10 +86,28 @@ func runWorkloadCommand(cmd *cobra.Command, args []string) error {
 		showDaemonSetAnalysis(analysis.DaemonSetAnalysis)
 	}
 
+	if showWorkloadJobs {
+		showJobAnalysis(analysis.JobAnalysis)
+	}
+
+	if showWorkloadCronJobs {
+		showCronJobAnalysis(analysis.CronJobAnalysis)
+	}
+
+	if showWorkloadPDBs {
+		showPDBAnalysis(analysis.PDBAnalysis)
+	}
+
+	if showWorkloadAutoscalers {
+		showAutoscalerAnalysis(analysis.HPAAnalysis, analysis.VPAAnalysis)
+	}
+
 	if showWorkloadPods {
 		showPodsAnalysis(analysis.PodAnalysis)
 	}
 
+	sendAlerts(cmd.OutOrStdout(), notifier, alertsFromWorkloadAnalysis(analysis))
+
 	return nil
 }
 
@@ -102,6 +135,18 @@ func showWorkloadOverview(summary *kubernetes.WorkloadSummary) {
 	}
 	summaryTable.AddRow([]string{"DaemonSets", fmt.Sprintf("%d", summary.TotalDaemonSets), fmt.Sprintf("%d", summary.HealthyDaemonSets), daemonSetRate})
 
+	jobRate := "N/A"
+	if summary.TotalJobs > 0 {
+		jobRate = fmt.Sprintf("%.1f%%", float64(summary.HealthyJobs)/float64(summary.TotalJobs)*100)
+	}
+	summaryTable.AddRow([]string{"Jobs", fmt.Sprintf("%d", summary.TotalJobs), fmt.Sprintf("%d", summary.HealthyJobs), jobRate})
+
+	cronJobRate := "N/A"
+	if summary.TotalCronJobs > 0 {
+		cronJobRate = fmt.Sprintf("%.1f%%", float64(summary.HealthyCronJobs)/float64(summary.TotalCronJobs)*100)
+	}
+	summaryTable.AddRow([]string{"CronJobs", fmt.Sprintf("%d", summary.TotalCronJobs), fmt.Sprintf("%d", summary.HealthyCronJobs), cronJobRate})
+
 	podRate := "N/A"
 	if summary.TotalPods > 0 {
 		podRate = fmt.Sprintf("%.1f%%", float64(summary.HealthyPods)/float64(summary.TotalPods)*100)
@@ -149,6 +194,9 @@ func showDeploymentAnalysis(deployments []kubernetes.DeploymentHealth) {
 		if deploy.UnavailableReplicas > 0 {
 			replicas += fmt.Sprintf(" (-%d)", deploy.UnavailableReplicas)
 		}
+		if deploy.Autoscaler != "" {
+			replicas += fmt.Sprintf(" [%s]", deploy.Autoscaler)
+		}
 
 		status := deploy.Status
 		if deploy.Status == "Critical" {
@@ -212,6 +260,9 @@ func showStatefulSetAnalysis(statefulSets []kubernetes.StatefulSetHealth) {
 		}
 
 		replicas := fmt.Sprintf("%d/%d", ss.ReadyReplicas, ss.Replicas)
+		if ss.Autoscaler != "" {
+			replicas += fmt.Sprintf(" [%s]", ss.Autoscaler)
+		}
 
 		status := ss.Status
 		if ss.Status == "Critical" {
@@ -299,6 +350,214 @@ func showDaemonSetAnalysis(daemonSets []kubernetes.DaemonSetHealth) {
 	fmt.Println()
 }
 
+func showJobAnalysis(jobs []kubernetes.JobHealth) {
+	if len(jobs) == 0 {
+		return
+	}
+
+	fmt.Println("📦 JOB ANALYSIS")
+	fmt.Println(strings.Repeat("-", 40))
+
+	jobTable := table.NewTable([]string{"Name", "Namespace", "Phase", "Completions", "Failed", "Duration", "Age", "Status", "Issues"})
+
+	for _, job := range jobs {
+		if onlyUnhealthy && job.HealthScore >= 80 {
+			continue
+		}
+
+		issues := "-"
+		if len(job.Issues) > 0 {
+			issues = strings.Join(job.Issues, "; ")
+		}
+
+		jobTable.AddRow([]string{
+			job.Name,
+			job.Namespace,
+			job.Phase,
+			job.Completions,
+			fmt.Sprintf("%d/%d", job.Failed, job.BackoffLimit),
+			job.Duration,
+			job.Age,
+			statusWithIcon(job.Status),
+			issues,
+		})
+	}
+	jobTable.Render()
+	fmt.Println()
+}
+
+func showCronJobAnalysis(cronJobs []kubernetes.CronJobHealth) {
+	if len(cronJobs) == 0 {
+		return
+	}
+
+	fmt.Println("⏰ CRONJOB ANALYSIS")
+	fmt.Println(strings.Repeat("-", 40))
+
+	cronJobTable := table.NewTable([]string{"Name", "Namespace", "Schedule", "Last Schedule", "Last Success", "Active", "Avg Duration", "Trend", "Status", "Issues"})
+
+	var recommendations []string
+	for _, cronJob := range cronJobs {
+		if onlyUnhealthy && cronJob.HealthScore >= 80 {
+			continue
+		}
+
+		schedule := cronJob.Schedule
+		if cronJob.Suspended {
+			schedule += " (suspended)"
+		}
+
+		issues := "-"
+		if len(cronJob.Issues) > 0 {
+			issues = strings.Join(cronJob.Issues, "; ")
+		}
+
+		cronJobTable.AddRow([]string{
+			cronJob.Name,
+			cronJob.Namespace,
+			schedule,
+			cronJob.LastSchedule,
+			cronJob.LastSuccessful,
+			fmt.Sprintf("%d (%s)", cronJob.ActiveJobs, cronJob.ConcurrencyPolicy),
+			cronJob.AverageDuration,
+			cronJob.DurationTrend,
+			statusWithIcon(cronJob.Status),
+			issues,
+		})
+
+		for _, recommendation := range cronJob.Recommendations {
+			recommendations = append(recommendations, fmt.Sprintf("%s/%s: %s", cronJob.Namespace, cronJob.Name, recommendation))
+		}
+	}
+	cronJobTable.Render()
+
+	if len(recommendations) > 0 {
+		fmt.Println()
+		for _, recommendation := range recommendations {
+			fmt.Printf("💡 %s\n", recommendation)
+		}
+	}
+	fmt.Println()
+}
+
+func showPDBAnalysis(audits []kubernetes.PDBAudit) {
+	if len(audits) == 0 {
+		return
+	}
+
+	fmt.Println("🛡️  POD DISRUPTION BUDGETS")
+	fmt.Println(strings.Repeat("-", 40))
+
+	pdbTable := table.NewTable([]string{"Name", "Namespace", "Min Available", "Max Unavailable", "Pods", "Healthy", "Allowed", "Status", "Issues"})
+
+	for _, audit := range audits {
+		if onlyUnhealthy && audit.Status == "Healthy" {
+			continue
+		}
+
+		status := audit.Status
+		if audit.Status == "Critical" {
+			status = "🔴 " + status
+		} else if audit.Status == "Warning" {
+			status = "🟡 " + status
+		} else {
+			status = "🟢 " + status
+		}
+
+		issues := "-"
+		if len(audit.Issues) > 0 {
+			issues = strings.Join(audit.Issues, "; ")
+		}
+
+		pdbTable.AddRow([]string{
+			audit.Name,
+			audit.Namespace,
+			audit.MinAvailable,
+			audit.MaxUnavailable,
+			fmt.Sprintf("%d", audit.MatchedPods),
+			fmt.Sprintf("%d/%d", audit.CurrentHealthy, audit.DesiredHealthy),
+			fmt.Sprintf("%d", audit.DisruptionsAllowed),
+			status,
+			issues,
+		})
+	}
+	pdbTable.Render()
+	fmt.Println()
+}
+
+func showAutoscalerAnalysis(hpas []kubernetes.HPAStatus, vpas []kubernetes.VPAStatus) {
+	if len(hpas) > 0 {
+		fmt.Println("📈 HORIZONTAL POD AUTOSCALERS")
+		fmt.Println(strings.Repeat("-", 40))
+
+		hpaTable := table.NewTable([]string{"Name", "Namespace", "Target", "Current/Desired", "Min/Max", "Metrics", "Status", "Issues"})
+		for _, hpa := range hpas {
+			if onlyUnhealthy && hpa.Status == "Healthy" {
+				continue
+			}
+
+			issues := "-"
+			if len(hpa.Issues) > 0 {
+				issues = strings.Join(hpa.Issues, "; ")
+			}
+
+			hpaTable.AddRow([]string{
+				hpa.Name,
+				hpa.Namespace,
+				hpa.Target,
+				fmt.Sprintf("%d/%d", hpa.CurrentReplicas, hpa.DesiredReplicas),
+				fmt.Sprintf("%d/%d", hpa.MinReplicas, hpa.MaxReplicas),
+				strings.Join(hpa.Metrics, ", "),
+				statusWithIcon(hpa.Status),
+				issues,
+			})
+		}
+		hpaTable.Render()
+		fmt.Println()
+	}
+
+	if len(vpas) > 0 {
+		fmt.Println("📐 VERTICAL POD AUTOSCALERS")
+		fmt.Println(strings.Repeat("-", 40))
+
+		vpaTable := table.NewTable([]string{"Name", "Namespace", "Target", "Mode", "Container", "CPU Target", "Memory Target", "Status"})
+		for _, vpa := range vpas {
+			if onlyUnhealthy && vpa.Status == "Healthy" {
+				continue
+			}
+
+			if len(vpa.Recommendations) == 0 {
+				vpaTable.AddRow([]string{vpa.Name, vpa.Namespace, vpa.Target, vpa.UpdateMode, "-", "-", "-", statusWithIcon(vpa.Status)})
+				continue
+			}
+			for _, recommendation := range vpa.Recommendations {
+				vpaTable.AddRow([]string{
+					vpa.Name,
+					vpa.Namespace,
+					vpa.Target,
+					vpa.UpdateMode,
+					recommendation.Container,
+					formatMilliCores(recommendation.CPUTarget),
+					formatMemoryBytes(recommendation.MemoryTarget),
+					statusWithIcon(vpa.Status),
+				})
+			}
+		}
+		vpaTable.Render()
+		fmt.Println()
+	}
+}
+
+func statusWithIcon(status string) string {
+	switch status {
+	case "Critical":
+		return "🔴 " + status
+	case "Warning":
+		return "🟡 " + status
+	}
+	return "🟢 " + status
+}
+
 func showPodsAnalysis(pods []kubernetes.PodHealth) {
 	if len(pods) == 0 {
 		return
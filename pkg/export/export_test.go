@@ -0,0 +1,111 @@
+package export
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+
+	"k8s-cli/pkg/kubernetes"
+	"k8s-cli/pkg/recommendations"
+)
+
+func TestSnapshotDiff(t *testing.T) {
+	before := &Snapshot{
+		ExportData: ExportData{
+			Timestamp: time.Date(2025, 6, 1, 8, 0, 0, 0, time.UTC),
+			CostAnalysis: &kubernetes.CostAnalysis{
+				TotalMonthlyCost: 300,
+				NamespaceCosts:   []kubernetes.NamespaceCost{{Name: "prod", MonthlyCost: 200}, {Name: "staging", MonthlyCost: 80}, {Name: "legacy", MonthlyCost: 20}},
+			},
+		},
+		ClusterVersion: &kubernetes.ClusterInfo{GitVersion: "v1.29.4"},
+		Nodes:          &kubernetes.NodeAnalysis{Nodes: []kubernetes.NodeDetail{{Name: "node-a", KubeletVersion: "v1.29.4"}, {Name: "node-b", KubeletVersion: "v1.29.4"}}},
+		Workload: &kubernetes.WorkloadAnalysis{
+			DeploymentAnalysis: []kubernetes.DeploymentHealth{{Name: "api", Namespace: "prod", HealthScore: 100, Status: "Healthy"}, {Name: "web", Namespace: "prod", HealthScore: 60, Status: "Warning"}},
+			WorkloadSummary:    kubernetes.WorkloadSummary{OverallHealthScore: 80},
+		},
+		Components:      []kubernetes.ComponentInfo{{Name: "ingress-nginx", Namespace: "ingress", Version: "1.9.0"}, {Name: "old-dashboard", Namespace: "kube-system", Version: "2.0.0"}},
+		Recommendations: []recommendations.Recommendation{{Type: "Security", Title: "Privileged pods"}, {Type: "Version", Title: "Upgrade Kubernetes"}},
+	}
+
+	after := &Snapshot{
+		ExportData: ExportData{
+			Timestamp: time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC),
+			CostAnalysis: &kubernetes.CostAnalysis{
+				TotalMonthlyCost: 330,
+				NamespaceCosts:   []kubernetes.NamespaceCost{{Name: "prod", MonthlyCost: 250}, {Name: "staging", MonthlyCost: 80}},
+			},
+		},
+		ClusterVersion: &kubernetes.ClusterInfo{GitVersion: "v1.30.1"},
+		Nodes:          &kubernetes.NodeAnalysis{Nodes: []kubernetes.NodeDetail{{Name: "node-a", KubeletVersion: "v1.30.1"}, {Name: "node-c", KubeletVersion: "v1.30.1"}}},
+		Workload: &kubernetes.WorkloadAnalysis{
+			DeploymentAnalysis: []kubernetes.DeploymentHealth{{Name: "api", Namespace: "prod", HealthScore: 40, Status: "Critical"}, {Name: "web", Namespace: "prod", HealthScore: 100, Status: "Healthy"}},
+			WorkloadSummary:    kubernetes.WorkloadSummary{OverallHealthScore: 70},
+		},
+		Components:      []kubernetes.ComponentInfo{{Name: "ingress-nginx", Namespace: "ingress", Version: "1.10.1"}, {Name: "cert-manager", Namespace: "cert-manager", Version: "1.14.0"}},
+		Recommendations: []recommendations.Recommendation{{Type: "Security", Title: "Privileged pods"}, {Type: "Resource", Title: "Missing requests"}},
+	}
+
+	// Diffs are computed from files on disk, as the diff command does
+	dir := t.TempDir()
+	for name, snapshot := range map[string]*Snapshot{"before.json": before, "after.json": after} {
+		if err := SaveSnapshot(snapshot, filepath.Join(dir, name)); err != nil {
+			t.Fatalf("failed to save %s: %v", name, err)
+		}
+	}
+	loadedBefore, err := LoadSnapshot(filepath.Join(dir, "before.json"))
+	if err != nil {
+		t.Fatalf("failed to load before.json: %v", err)
+	}
+	loadedAfter, err := LoadSnapshot(filepath.Join(dir, "after.json"))
+	if err != nil {
+		t.Fatalf("failed to load after.json: %v", err)
+	}
+
+	diff := DiffSnapshots(loadedBefore, loadedAfter)
+
+	if !diff.HasChanges() || !diff.Before.Equal(before.Timestamp) {
+		t.Fatalf("expected changes since %s, got %+v", before.Timestamp, diff)
+	}
+	if len(diff.AddedNodes) != 1 || diff.AddedNodes[0] != "node-c" || len(diff.RemovedNodes) != 1 || diff.RemovedNodes[0] != "node-b" {
+		t.Errorf("unexpected node changes: added %v, removed %v", diff.AddedNodes, diff.RemovedNodes)
+	}
+
+	expectedVersions := []VersionChange{
+		{Kind: "Control Plane", Name: "kubernetes", Before: "v1.29.4", After: "v1.30.1"},
+		{Kind: "Node", Name: "node-a", Before: "v1.29.4", After: "v1.30.1"},
+		{Kind: "Component", Name: "ingress/ingress-nginx", Before: "1.9.0", After: "1.10.1"},
+	}
+	if len(diff.VersionChanges) != len(expectedVersions) {
+		t.Fatalf("expected %d version changes, got %+v", len(expectedVersions), diff.VersionChanges)
+	}
+	for i, expected := range expectedVersions {
+		if diff.VersionChanges[i] != expected {
+			t.Errorf("expected version change %+v, got %+v", expected, diff.VersionChanges[i])
+		}
+	}
+	if len(diff.AddedComponents) != 1 || diff.AddedComponents[0] != "cert-manager/cert-manager (1.14.0)" || len(diff.RemovedComponents) != 1 {
+		t.Errorf("unexpected component changes: added %v, removed %v", diff.AddedComponents, diff.RemovedComponents)
+	}
+
+	// Largest delta first; staging did not change
+	if len(diff.CostChanges) != 2 || diff.CostChanges[0].Namespace != "prod" || diff.CostChanges[0].Delta != 50 || diff.CostChanges[1].Namespace != "legacy" || diff.CostChanges[1].Delta != -20 {
+		t.Errorf("unexpected cost changes %+v", diff.CostChanges)
+	}
+	if diff.TotalCostBefore != 300 || diff.TotalCostAfter != 330 {
+		t.Errorf("expected total cost 300 → 330, got %.2f → %.2f", diff.TotalCostBefore, diff.TotalCostAfter)
+	}
+
+	// Regressions first
+	if diff.OverallHealthBefore != 80 || diff.OverallHealthAfter != 70 || len(diff.HealthChanges) != 2 || diff.HealthChanges[0].Workload != "Deployment prod/api" || diff.HealthChanges[0].AfterStatus != "Critical" {
+		t.Errorf("unexpected health changes %d → %d %+v", diff.OverallHealthBefore, diff.OverallHealthAfter, diff.HealthChanges)
+	}
+
+	if len(diff.NewRecommendations) != 1 || diff.NewRecommendations[0].Title != "Missing requests" || len(diff.ResolvedRecommendations) != 1 || diff.ResolvedRecommendations[0].Title != "Upgrade Kubernetes" {
+		t.Errorf("unexpected recommendation changes: new %+v, resolved %+v", diff.NewRecommendations, diff.ResolvedRecommendations)
+	}
+
+	if DiffSnapshots(loadedAfter, loadedAfter).HasChanges() {
+		t.Errorf("expected no changes when comparing a snapshot with itself")
+	}
+}
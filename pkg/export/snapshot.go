@@ -0,0 +1,324 @@
+package export
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"os"
+	"sort"
+	"time"
+
+	"k8s-cli/pkg/kubernetes"
+	"k8s-cli/pkg/recommendations"
+)
+
+// Snapshot is an ExportData plus the analyses needed to compare the cluster
+// at two points in time. The embedded export fields keep the file readable
+// by anything that consumes the JSON export.
+type Snapshot struct {
+	ExportData
+	Server          string                           `json:"server,omitempty"`
+	ClusterVersion  *kubernetes.ClusterInfo          `json:"cluster_version,omitempty"`
+	Nodes           *kubernetes.NodeAnalysis         `json:"nodes,omitempty"`
+	Workload        *kubernetes.WorkloadAnalysis     `json:"workload,omitempty"`
+	Components      []kubernetes.ComponentInfo       `json:"components,omitempty"`
+	Recommendations []recommendations.Recommendation `json:"recommendations,omitempty"`
+}
+
+type SnapshotDiff struct {
+	Before                  time.Time
+	After                   time.Time
+	AddedNodes              []string
+	RemovedNodes            []string
+	VersionChanges          []VersionChange
+	AddedComponents         []string
+	RemovedComponents       []string
+	TotalCostBefore         float64
+	TotalCostAfter          float64
+	CostChanges             []CostChange
+	OverallHealthBefore     int
+	OverallHealthAfter      int
+	HealthChanges           []HealthChange
+	NewRecommendations      []recommendations.Recommendation
+	ResolvedRecommendations []recommendations.Recommendation
+}
+
+type VersionChange struct {
+	Kind   string
+	Name   string
+	Before string
+	After  string
+}
+
+type CostChange struct {
+	Namespace string
+	Before    float64
+	After     float64
+	Delta     float64
+}
+
+type HealthChange struct {
+	Workload     string
+	BeforeScore  int
+	AfterScore   int
+	BeforeStatus string
+	AfterStatus  string
+}
+
+func SaveSnapshot(snapshot *Snapshot, path string) error {
+	content, err := json.MarshalIndent(snapshot, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to encode snapshot: %w", err)
+	}
+
+	if err := os.WriteFile(path, content, 0644); err != nil {
+		return fmt.Errorf("failed to write snapshot %s: %w", path, err)
+	}
+
+	return nil
+}
+
+func LoadSnapshot(path string) (*Snapshot, error) {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read snapshot %s: %w", path, err)
+	}
+
+	var snapshot Snapshot
+	if err := json.Unmarshal(content, &snapshot); err != nil {
+		return nil, fmt.Errorf("failed to parse snapshot %s: %w", path, err)
+	}
+
+	return &snapshot, nil
+}
+
+// HasChanges reports whether anything besides the timestamps differs.
+func (d *SnapshotDiff) HasChanges() bool {
+	return len(d.AddedNodes) > 0 || len(d.RemovedNodes) > 0 || len(d.VersionChanges) > 0 ||
+		len(d.AddedComponents) > 0 || len(d.RemovedComponents) > 0 || len(d.CostChanges) > 0 ||
+		d.OverallHealthBefore != d.OverallHealthAfter || len(d.HealthChanges) > 0 ||
+		len(d.NewRecommendations) > 0 || len(d.ResolvedRecommendations) > 0
+}
+
+// DiffSnapshots compares two saved snapshots without contacting the cluster.
+func DiffSnapshots(before, after *Snapshot) *SnapshotDiff {
+	diff := &SnapshotDiff{
+		Before: before.Timestamp,
+		After:  after.Timestamp,
+	}
+
+	diffNodes(diff, before, after)
+	diffComponents(diff, before, after)
+	diffCosts(diff, before, after)
+	diffHealth(diff, before, after)
+	diffRecommendations(diff, before, after)
+
+	return diff
+}
+
+func diffNodes(diff *SnapshotDiff, before, after *Snapshot) {
+	if before.ClusterVersion != nil && after.ClusterVersion != nil && before.ClusterVersion.GitVersion != after.ClusterVersion.GitVersion {
+		diff.VersionChanges = append(diff.VersionChanges, VersionChange{
+			Kind:   "Control Plane",
+			Name:   "kubernetes",
+			Before: before.ClusterVersion.GitVersion,
+			After:  after.ClusterVersion.GitVersion,
+		})
+	}
+
+	beforeNodes := snapshotNodes(before)
+	afterNodes := snapshotNodes(after)
+
+	for _, name := range sortedNames(afterNodes) {
+		beforeVersion, existed := beforeNodes[name]
+		if !existed {
+			diff.AddedNodes = append(diff.AddedNodes, name)
+			continue
+		}
+		if beforeVersion != "" && afterNodes[name] != "" && beforeVersion != afterNodes[name] {
+			diff.VersionChanges = append(diff.VersionChanges, VersionChange{Kind: "Node", Name: name, Before: beforeVersion, After: afterNodes[name]})
+		}
+	}
+	for _, name := range sortedNames(beforeNodes) {
+		if _, exists := afterNodes[name]; !exists {
+			diff.RemovedNodes = append(diff.RemovedNodes, name)
+		}
+	}
+}
+
+// snapshotNodes maps node names to kubelet versions, falling back to the
+// node metrics (without versions) when the node analysis is missing.
+func snapshotNodes(snapshot *Snapshot) map[string]string {
+	nodes := make(map[string]string)
+	if snapshot.Nodes != nil {
+		for _, node := range snapshot.Nodes.Nodes {
+			nodes[node.Name] = node.KubeletVersion
+		}
+		return nodes
+	}
+	for _, node := range snapshot.NodeMetrics {
+		nodes[node.Name] = ""
+	}
+	return nodes
+}
+
+func diffComponents(diff *SnapshotDiff, before, after *Snapshot) {
+	beforeComponents := make(map[string]string)
+	for _, component := range before.Components {
+		beforeComponents[component.Namespace+"/"+component.Name] = component.Version
+	}
+	afterComponents := make(map[string]string)
+	for _, component := range after.Components {
+		afterComponents[component.Namespace+"/"+component.Name] = component.Version
+	}
+
+	for _, name := range sortedNames(afterComponents) {
+		beforeVersion, existed := beforeComponents[name]
+		if !existed {
+			diff.AddedComponents = append(diff.AddedComponents, fmt.Sprintf("%s (%s)", name, afterComponents[name]))
+			continue
+		}
+		if beforeVersion != afterComponents[name] {
+			diff.VersionChanges = append(diff.VersionChanges, VersionChange{Kind: "Component", Name: name, Before: beforeVersion, After: afterComponents[name]})
+		}
+	}
+	for _, name := range sortedNames(beforeComponents) {
+		if _, exists := afterComponents[name]; !exists {
+			diff.RemovedComponents = append(diff.RemovedComponents, fmt.Sprintf("%s (%s)", name, beforeComponents[name]))
+		}
+	}
+}
+
+func diffCosts(diff *SnapshotDiff, before, after *Snapshot) {
+	beforeCosts := make(map[string]float64)
+	if before.CostAnalysis != nil {
+		diff.TotalCostBefore = before.CostAnalysis.TotalMonthlyCost
+		for _, namespace := range before.CostAnalysis.NamespaceCosts {
+			beforeCosts[namespace.Name] = namespace.MonthlyCost
+		}
+	}
+	afterCosts := make(map[string]float64)
+	if after.CostAnalysis != nil {
+		diff.TotalCostAfter = after.CostAnalysis.TotalMonthlyCost
+		for _, namespace := range after.CostAnalysis.NamespaceCosts {
+			afterCosts[namespace.Name] = namespace.MonthlyCost
+		}
+	}
+
+	namespaces := make(map[string]string)
+	for name := range beforeCosts {
+		namespaces[name] = name
+	}
+	for name := range afterCosts {
+		namespaces[name] = name
+	}
+
+	for _, name := range sortedNames(namespaces) {
+		delta := afterCosts[name] - beforeCosts[name]
+		if math.Abs(delta) < 0.01 {
+			continue
+		}
+		diff.CostChanges = append(diff.CostChanges, CostChange{Namespace: name, Before: beforeCosts[name], After: afterCosts[name], Delta: delta})
+	}
+
+	sort.SliceStable(diff.CostChanges, func(i, j int) bool {
+		return math.Abs(diff.CostChanges[i].Delta) > math.Abs(diff.CostChanges[j].Delta)
+	})
+}
+
+type workloadScore struct {
+	score  int
+	status string
+}
+
+func diffHealth(diff *SnapshotDiff, before, after *Snapshot) {
+	if before.Workload != nil {
+		diff.OverallHealthBefore = before.Workload.WorkloadSummary.OverallHealthScore
+	}
+	if after.Workload != nil {
+		diff.OverallHealthAfter = after.Workload.WorkloadSummary.OverallHealthScore
+	}
+
+	beforeScores := workloadScores(before.Workload)
+	afterScores := workloadScores(after.Workload)
+	for name, current := range afterScores {
+		previous, existed := beforeScores[name]
+		if !existed || previous.score == current.score {
+			continue
+		}
+		diff.HealthChanges = append(diff.HealthChanges, HealthChange{
+			Workload:     name,
+			BeforeScore:  previous.score,
+			AfterScore:   current.score,
+			BeforeStatus: previous.status,
+			AfterStatus:  current.status,
+		})
+	}
+
+	// Regressions first
+	sort.Slice(diff.HealthChanges, func(i, j int) bool {
+		deltaI := diff.HealthChanges[i].AfterScore - diff.HealthChanges[i].BeforeScore
+		deltaJ := diff.HealthChanges[j].AfterScore - diff.HealthChanges[j].BeforeScore
+		if deltaI != deltaJ {
+			return deltaI < deltaJ
+		}
+		return diff.HealthChanges[i].Workload < diff.HealthChanges[j].Workload
+	})
+}
+
+func workloadScores(analysis *kubernetes.WorkloadAnalysis) map[string]workloadScore {
+	scores := make(map[string]workloadScore)
+	if analysis == nil {
+		return scores
+	}
+
+	for _, deploy := range analysis.DeploymentAnalysis {
+		scores["Deployment "+deploy.Namespace+"/"+deploy.Name] = workloadScore{deploy.HealthScore, deploy.Status}
+	}
+	for _, ss := range analysis.StatefulSetAnalysis {
+		scores["StatefulSet "+ss.Namespace+"/"+ss.Name] = workloadScore{ss.HealthScore, ss.Status}
+	}
+	for _, ds := range analysis.DaemonSetAnalysis {
+		scores["DaemonSet "+ds.Namespace+"/"+ds.Name] = workloadScore{ds.HealthScore, ds.Status}
+	}
+	for _, job := range analysis.JobAnalysis {
+		scores["Job "+job.Namespace+"/"+job.Name] = workloadScore{job.HealthScore, job.Status}
+	}
+	for _, cronJob := range analysis.CronJobAnalysis {
+		scores["CronJob "+cronJob.Namespace+"/"+cronJob.Name] = workloadScore{cronJob.HealthScore, cronJob.Status}
+	}
+
+	return scores
+}
+
+func diffRecommendations(diff *SnapshotDiff, before, after *Snapshot) {
+	key := func(rec recommendations.Recommendation) string {
+		return rec.Type + "|" + rec.Title
+	}
+
+	beforeRecs := make(map[string]bool)
+	for _, rec := range before.Recommendations {
+		beforeRecs[key(rec)] = true
+	}
+	afterRecs := make(map[string]bool)
+	for _, rec := range after.Recommendations {
+		afterRecs[key(rec)] = true
+		if !beforeRecs[key(rec)] {
+			diff.NewRecommendations = append(diff.NewRecommendations, rec)
+		}
+	}
+	for _, rec := range before.Recommendations {
+		if !afterRecs[key(rec)] {
+			diff.ResolvedRecommendations = append(diff.ResolvedRecommendations, rec)
+		}
+	}
+}
+
+func sortedNames(values map[string]string) []string {
+	names := make([]string, 0, len(values))
+	for name := range values {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
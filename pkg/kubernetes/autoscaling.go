@@ -0,0 +1,397 @@
+package kubernetes
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	autoscalingv2 "k8s.io/api/autoscaling/v2"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+type HPAStatus struct {
+	Name            string
+	Namespace       string
+	Target          string
+	MinReplicas     int32
+	MaxReplicas     int32
+	CurrentReplicas int32
+	DesiredReplicas int32
+	Metrics         []string
+	ResourceMetrics bool
+	Status          string
+	Issues          []string
+}
+
+type VPAStatus struct {
+	Name            string
+	Namespace       string
+	Target          string
+	UpdateMode      string
+	Recommendations []ContainerRecommendation
+	Status          string
+	Issues          []string
+}
+
+type ContainerRecommendation struct {
+	Container    string
+	CPUTarget    int64
+	MemoryTarget int64
+}
+
+// workloadAutoscalers indexes HPAs and VPAs by "namespace/Kind/name" of
+// their scale target.
+type workloadAutoscalers struct {
+	hpas map[string]*HPAStatus
+	vpas map[string]*VPAStatus
+}
+
+var vpaResource = schema.GroupVersionResource{Group: "autoscaling.k8s.io", Version: "v1", Resource: "verticalpodautoscalers"}
+
+func (c *Client) listHPAs(namespace string) ([]HPAStatus, error) {
+	hpas, err := c.Clientset.AutoscalingV2().HorizontalPodAutoscalers(namespace).List(c.Context, metav1.ListOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get horizontal pod autoscalers: %w", err)
+	}
+
+	var statuses []HPAStatus
+	for i := range hpas.Items {
+		statuses = append(statuses, auditHPA(&hpas.Items[i]))
+	}
+	sort.SliceStable(statuses, func(i, j int) bool {
+		if eventSeverityRank(statuses[i].Status) != eventSeverityRank(statuses[j].Status) {
+			return eventSeverityRank(statuses[i].Status) > eventSeverityRank(statuses[j].Status)
+		}
+		return statuses[i].Namespace+"/"+statuses[i].Name < statuses[j].Namespace+"/"+statuses[j].Name
+	})
+
+	return statuses, nil
+}
+
+// listVPAs reads VerticalPodAutoscaler objects through the dynamic client;
+// it fails when the VPA CRDs are not installed.
+func (c *Client) listVPAs(namespace string) ([]VPAStatus, error) {
+	vpas, err := c.DynamicClient.Resource(vpaResource).Namespace(namespace).List(c.Context, metav1.ListOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get vertical pod autoscalers: %w", err)
+	}
+
+	var statuses []VPAStatus
+	for i := range vpas.Items {
+		statuses = append(statuses, parseVPA(&vpas.Items[i]))
+	}
+	sort.SliceStable(statuses, func(i, j int) bool {
+		if eventSeverityRank(statuses[i].Status) != eventSeverityRank(statuses[j].Status) {
+			return eventSeverityRank(statuses[i].Status) > eventSeverityRank(statuses[j].Status)
+		}
+		return statuses[i].Namespace+"/"+statuses[i].Name < statuses[j].Namespace+"/"+statuses[j].Name
+	})
+
+	return statuses, nil
+}
+
+func auditHPA(hpa *autoscalingv2.HorizontalPodAutoscaler) HPAStatus {
+	status := HPAStatus{
+		Name:            hpa.Name,
+		Namespace:       hpa.Namespace,
+		Target:          hpa.Spec.ScaleTargetRef.Kind + "/" + hpa.Spec.ScaleTargetRef.Name,
+		MinReplicas:     1,
+		MaxReplicas:     hpa.Spec.MaxReplicas,
+		CurrentReplicas: hpa.Status.CurrentReplicas,
+		DesiredReplicas: hpa.Status.DesiredReplicas,
+	}
+	if hpa.Spec.MinReplicas != nil {
+		status.MinReplicas = *hpa.Spec.MinReplicas
+	}
+
+	var missing []string
+	for _, metric := range hpa.Spec.Metrics {
+		if metric.Type == autoscalingv2.ResourceMetricSourceType || metric.Type == autoscalingv2.ContainerResourceMetricSourceType {
+			status.ResourceMetrics = true
+		}
+
+		name, target, value := describeHPAMetric(metric, findHPAMetricStatus(metric, hpa.Status.CurrentMetrics))
+		if value == "" {
+			value = "<unknown>"
+			missing = append(missing, name)
+		}
+		status.Metrics = append(status.Metrics, fmt.Sprintf("%s: %s/%s", name, value, target))
+	}
+
+	for _, condition := range hpa.Status.Conditions {
+		if condition.Status != corev1.ConditionFalse {
+			continue
+		}
+		switch condition.Type {
+		case autoscalingv2.ScalingActive:
+			status.Issues = append(status.Issues, fmt.Sprintf("Scaling inactive (%s): %s", condition.Reason, condition.Message))
+		case autoscalingv2.AbleToScale:
+			status.Issues = append(status.Issues, fmt.Sprintf("Unable to scale (%s): %s", condition.Reason, condition.Message))
+		}
+	}
+	if len(missing) > 0 {
+		status.Issues = append(status.Issues, fmt.Sprintf("Missing metrics: %s", strings.Join(missing, ", ")))
+	}
+
+	pinned := status.MaxReplicas > 0 && status.CurrentReplicas >= status.MaxReplicas && status.DesiredReplicas >= status.MaxReplicas
+	if pinned {
+		status.Issues = append(status.Issues, fmt.Sprintf("Pinned at max replicas (%d)", status.MaxReplicas))
+	}
+
+	switch {
+	case len(missing) > 0 || hasHPAConditionFalse(hpa, autoscalingv2.ScalingActive):
+		status.Status = "Critical"
+	case len(status.Issues) > 0:
+		status.Status = "Warning"
+	default:
+		status.Status = "Healthy"
+	}
+
+	return status
+}
+
+func hasHPAConditionFalse(hpa *autoscalingv2.HorizontalPodAutoscaler, conditionType autoscalingv2.HorizontalPodAutoscalerConditionType) bool {
+	for _, condition := range hpa.Status.Conditions {
+		if condition.Type == conditionType && condition.Status == corev1.ConditionFalse {
+			return true
+		}
+	}
+	return false
+}
+
+// findHPAMetricStatus returns the current reading for a metric spec. The
+// status list only holds metrics the HPA could read, so it is matched by
+// metric identity rather than by position.
+func findHPAMetricStatus(metric autoscalingv2.MetricSpec, statuses []autoscalingv2.MetricStatus) *autoscalingv2.MetricStatus {
+	for i := range statuses {
+		current := &statuses[i]
+		if current.Type != metric.Type {
+			continue
+		}
+		switch metric.Type {
+		case autoscalingv2.ResourceMetricSourceType:
+			if metric.Resource != nil && current.Resource != nil && metric.Resource.Name == current.Resource.Name {
+				return current
+			}
+		case autoscalingv2.ContainerResourceMetricSourceType:
+			if metric.ContainerResource != nil && current.ContainerResource != nil &&
+				metric.ContainerResource.Name == current.ContainerResource.Name &&
+				metric.ContainerResource.Container == current.ContainerResource.Container {
+				return current
+			}
+		case autoscalingv2.PodsMetricSourceType:
+			if metric.Pods != nil && current.Pods != nil && metric.Pods.Metric.Name == current.Pods.Metric.Name {
+				return current
+			}
+		case autoscalingv2.ObjectMetricSourceType:
+			if metric.Object != nil && current.Object != nil &&
+				metric.Object.Metric.Name == current.Object.Metric.Name &&
+				metric.Object.DescribedObject.Kind == current.Object.DescribedObject.Kind &&
+				metric.Object.DescribedObject.Name == current.Object.DescribedObject.Name {
+				return current
+			}
+		case autoscalingv2.ExternalMetricSourceType:
+			if metric.External != nil && current.External != nil && metric.External.Metric.Name == current.External.Metric.Name {
+				return current
+			}
+		}
+	}
+	return nil
+}
+
+// describeHPAMetric returns the metric name, its target and its current
+// value the way kubectl describe hpa prints them; value is empty when the
+// HPA has no reading for the metric.
+func describeHPAMetric(metric autoscalingv2.MetricSpec, current *autoscalingv2.MetricStatus) (name, target, value string) {
+	switch metric.Type {
+	case autoscalingv2.ResourceMetricSourceType:
+		if metric.Resource == nil {
+			break
+		}
+		name = string(metric.Resource.Name)
+		target = formatMetricTarget(metric.Resource.Target)
+		if current != nil && current.Resource != nil {
+			value = formatMetricValue(current.Resource.Current)
+		}
+		return name, target, value
+	case autoscalingv2.ContainerResourceMetricSourceType:
+		if metric.ContainerResource == nil {
+			break
+		}
+		name = fmt.Sprintf("%s (%s)", metric.ContainerResource.Name, metric.ContainerResource.Container)
+		target = formatMetricTarget(metric.ContainerResource.Target)
+		if current != nil && current.ContainerResource != nil {
+			value = formatMetricValue(current.ContainerResource.Current)
+		}
+		return name, target, value
+	case autoscalingv2.PodsMetricSourceType:
+		if metric.Pods == nil {
+			break
+		}
+		name = metric.Pods.Metric.Name
+		target = formatMetricTarget(metric.Pods.Target)
+		if current != nil && current.Pods != nil {
+			value = formatMetricValue(current.Pods.Current)
+		}
+		return name, target, value
+	case autoscalingv2.ObjectMetricSourceType:
+		if metric.Object == nil {
+			break
+		}
+		name = fmt.Sprintf("%s (%s/%s)", metric.Object.Metric.Name, metric.Object.DescribedObject.Kind, metric.Object.DescribedObject.Name)
+		target = formatMetricTarget(metric.Object.Target)
+		if current != nil && current.Object != nil {
+			value = formatMetricValue(current.Object.Current)
+		}
+		return name, target, value
+	case autoscalingv2.ExternalMetricSourceType:
+		if metric.External == nil {
+			break
+		}
+		name = metric.External.Metric.Name
+		target = formatMetricTarget(metric.External.Target)
+		if current != nil && current.External != nil {
+			value = formatMetricValue(current.External.Current)
+		}
+		return name, target, value
+	}
+	return strings.ToLower(string(metric.Type)), "<unknown>", ""
+}
+
+func formatMetricTarget(target autoscalingv2.MetricTarget) string {
+	switch {
+	case target.AverageUtilization != nil:
+		return fmt.Sprintf("%d%%", *target.AverageUtilization)
+	case target.AverageValue != nil:
+		return target.AverageValue.String()
+	case target.Value != nil:
+		return target.Value.String()
+	}
+	return "<unknown>"
+}
+
+func formatMetricValue(value autoscalingv2.MetricValueStatus) string {
+	switch {
+	case value.AverageUtilization != nil:
+		return fmt.Sprintf("%d%%", *value.AverageUtilization)
+	case value.AverageValue != nil:
+		return value.AverageValue.String()
+	case value.Value != nil:
+		return value.Value.String()
+	}
+	return ""
+}
+
+func parseVPA(obj *unstructured.Unstructured) VPAStatus {
+	status := VPAStatus{
+		Name:       obj.GetName(),
+		Namespace:  obj.GetNamespace(),
+		UpdateMode: "Auto",
+	}
+
+	kind, _, _ := unstructured.NestedString(obj.Object, "spec", "targetRef", "kind")
+	name, _, _ := unstructured.NestedString(obj.Object, "spec", "targetRef", "name")
+	status.Target = kind + "/" + name
+	if mode, found, _ := unstructured.NestedString(obj.Object, "spec", "updatePolicy", "updateMode"); found && mode != "" {
+		status.UpdateMode = mode
+	}
+
+	containers, _, _ := unstructured.NestedSlice(obj.Object, "status", "recommendation", "containerRecommendations")
+	for _, item := range containers {
+		container, ok := item.(map[string]interface{})
+		if !ok {
+			continue
+		}
+
+		recommendation := ContainerRecommendation{}
+		recommendation.Container, _, _ = unstructured.NestedString(container, "containerName")
+		if cpu, found, _ := unstructured.NestedString(container, "target", "cpu"); found {
+			if quantity, err := resource.ParseQuantity(cpu); err == nil {
+				recommendation.CPUTarget = quantity.MilliValue()
+			}
+		}
+		if memory, found, _ := unstructured.NestedString(container, "target", "memory"); found {
+			if quantity, err := resource.ParseQuantity(memory); err == nil {
+				recommendation.MemoryTarget = quantity.Value()
+			}
+		}
+		status.Recommendations = append(status.Recommendations, recommendation)
+	}
+
+	if len(status.Recommendations) == 0 {
+		status.Issues = append(status.Issues, "No recommendation yet")
+		status.Status = "Warning"
+	} else {
+		status.Status = "Healthy"
+	}
+
+	return status
+}
+
+// vpaTarget sums the VPA target of the pod's containers; containers the
+// VPA has no recommendation for are skipped.
+func vpaTarget(vpa *VPAStatus, pod *corev1.Pod) (cpu, memory int64, found bool) {
+	for _, container := range pod.Spec.Containers {
+		for _, recommendation := range vpa.Recommendations {
+			if recommendation.Container == container.Name {
+				cpu += recommendation.CPUTarget
+				memory += recommendation.MemoryTarget
+				found = true
+			}
+		}
+	}
+	return cpu, memory, found
+}
+
+func newWorkloadAutoscalers(hpas []HPAStatus, vpas []VPAStatus) workloadAutoscalers {
+	autoscalers := workloadAutoscalers{
+		hpas: make(map[string]*HPAStatus),
+		vpas: make(map[string]*VPAStatus),
+	}
+	for i := range hpas {
+		autoscalers.hpas[hpas[i].Namespace+"/"+hpas[i].Target] = &hpas[i]
+	}
+	for i := range vpas {
+		autoscalers.vpas[vpas[i].Namespace+"/"+vpas[i].Target] = &vpas[i]
+	}
+	return autoscalers
+}
+
+func (a workloadAutoscalers) lookup(namespace, workload string) (*HPAStatus, *VPAStatus) {
+	return a.hpas[namespace+"/"+workload], a.vpas[namespace+"/"+workload]
+}
+
+// checkAutoscaling returns a description of the autoscalers acting on a
+// workload, their issues and the health score penalty they carry.
+func checkAutoscaling(hpa *HPAStatus, vpa *VPAStatus) (autoscaler string, issues, recommendations []string, penalty int) {
+	var parts []string
+
+	if hpa != nil {
+		parts = append(parts, fmt.Sprintf("HPA %d-%d", hpa.MinReplicas, hpa.MaxReplicas))
+		for _, issue := range hpa.Issues {
+			issues = append(issues, fmt.Sprintf("HPA %s: %s", hpa.Name, issue))
+		}
+		if hpa.Status == "Critical" {
+			recommendations = append(recommendations, "Check that metrics-server or the metrics adapter serves the HPA metrics")
+			penalty += 15
+		} else if hpa.Status == "Warning" {
+			recommendations = append(recommendations, "Raise HPA maxReplicas or the per-pod resources so it can keep up with load")
+			penalty += 10
+		}
+	}
+
+	if vpa != nil {
+		parts = append(parts, fmt.Sprintf("VPA %s", vpa.UpdateMode))
+		if hpa != nil && hpa.ResourceMetrics && vpa.UpdateMode != "Off" {
+			issues = append(issues, "HPA and VPA both act on CPU/memory")
+			recommendations = append(recommendations, "Set the VPA updateMode to Off or scale the HPA on custom metrics")
+			penalty += 10
+		}
+	}
+
+	return strings.Join(parts, ", "), issues, recommendations, penalty
+}
@@ -0,0 +1,416 @@
+package kubernetes
+
+import (
+	"fmt"
+	"sort"
+	"time"
+
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+type JobHealth struct {
+	Name            string
+	Namespace       string
+	Phase           string
+	Completions     string
+	Failed          int32
+	BackoffLimit    int32
+	Duration        string
+	Age             string
+	Status          string
+	HealthScore     int
+	Issues          []string
+	Recommendations []string
+}
+
+type CronJobHealth struct {
+	Name                string
+	Namespace           string
+	Schedule            string
+	Suspended           bool
+	ConcurrencyPolicy   string
+	LastSchedule        string
+	LastSuccessful      string
+	ActiveJobs          int
+	MissedRuns          int
+	ConsecutiveFailures int
+	AverageDuration     string
+	DurationTrend       string
+	Status              string
+	HealthScore         int
+	Issues              []string
+	Recommendations     []string
+}
+
+const (
+	defaultBackoffLimit               = 6
+	defaultSuccessfulJobsHistoryLimit = 3
+	defaultFailedJobsHistoryLimit     = 1
+	maxMissedCronRuns                 = 100
+	// A run is only counted as missed once the controller had time to start it
+	cronMissedRunGrace = 2 * time.Minute
+	longRunningJob     = 24 * time.Hour
+)
+
+func (c *Client) analyzeBatchWorkloads(namespace string) ([]JobHealth, []CronJobHealth, error) {
+	jobs, err := c.Clientset.BatchV1().Jobs(namespace).List(c.Context, metav1.ListOptions{})
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to get jobs: %w", err)
+	}
+
+	cronJobs, err := c.Clientset.BatchV1().CronJobs(namespace).List(c.Context, metav1.ListOptions{})
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to get cronjobs: %w", err)
+	}
+
+	jobHealth, cronJobHealth := analyzeBatchHealth(jobs.Items, cronJobs.Items, time.Now())
+	return jobHealth, cronJobHealth, nil
+}
+
+// analyzeBatchHealth reports standalone Jobs individually; Jobs created by a
+// CronJob are folded into their CronJob's run history instead.
+func analyzeBatchHealth(jobs []batchv1.Job, cronJobs []batchv1.CronJob, now time.Time) ([]JobHealth, []CronJobHealth) {
+	var jobAnalysis []JobHealth
+	runs := make(map[string][]batchv1.Job)
+	for _, job := range jobs {
+		if hasCronJobOwner(&job) {
+			key := job.Namespace + "/" + getObjectOwner(job.OwnerReferences)
+			runs[key] = append(runs[key], job)
+			continue
+		}
+		jobAnalysis = append(jobAnalysis, analyzeJobHealth(&job, now))
+	}
+
+	var cronJobAnalysis []CronJobHealth
+	for _, cronJob := range cronJobs {
+		cronJobAnalysis = append(cronJobAnalysis, analyzeCronJobHealth(&cronJob, runs[cronJob.Namespace+"/CronJob/"+cronJob.Name], now))
+	}
+
+	sort.Slice(jobAnalysis, func(i, j int) bool {
+		return jobAnalysis[i].HealthScore < jobAnalysis[j].HealthScore
+	})
+	sort.Slice(cronJobAnalysis, func(i, j int) bool {
+		return cronJobAnalysis[i].HealthScore < cronJobAnalysis[j].HealthScore
+	})
+
+	return jobAnalysis, cronJobAnalysis
+}
+
+func analyzeJobHealth(job *batchv1.Job, now time.Time) JobHealth {
+	completions := int32(1)
+	if job.Spec.Completions != nil {
+		completions = *job.Spec.Completions
+	}
+	backoffLimit := int32(defaultBackoffLimit)
+	if job.Spec.BackoffLimit != nil {
+		backoffLimit = *job.Spec.BackoffLimit
+	}
+
+	health := JobHealth{
+		Name:            job.Name,
+		Namespace:       job.Namespace,
+		Phase:           "Running",
+		Completions:     fmt.Sprintf("%d/%d", job.Status.Succeeded, completions),
+		Failed:          job.Status.Failed,
+		BackoffLimit:    backoffLimit,
+		Duration:        "-",
+		Age:             getSimpleAge(job.CreationTimestamp.Time),
+		Issues:          []string{},
+		Recommendations: []string{},
+	}
+
+	score := 100
+	finished, finishedAt := jobFinishedAt(job)
+
+	if job.Status.StartTime != nil {
+		end := now
+		if finished {
+			end = finishedAt
+		}
+		health.Duration = end.Sub(job.Status.StartTime.Time).Truncate(time.Second).String()
+	}
+
+	if failed := jobFailedCondition(job); failed != nil {
+		health.Phase = "Failed"
+		switch failed.Reason {
+		case "BackoffLimitExceeded":
+			health.Issues = append(health.Issues, fmt.Sprintf("Backoff limit reached (%d failed attempts, limit %d)", job.Status.Failed, backoffLimit))
+			health.Recommendations = append(health.Recommendations, "Inspect the logs of the failed pods before raising backoffLimit")
+		case "DeadlineExceeded":
+			deadline := int64(0)
+			if job.Spec.ActiveDeadlineSeconds != nil {
+				deadline = *job.Spec.ActiveDeadlineSeconds
+			}
+			health.Issues = append(health.Issues, fmt.Sprintf("Active deadline exceeded (%s)", time.Duration(deadline)*time.Second))
+			health.Recommendations = append(health.Recommendations, "Raise activeDeadlineSeconds or speed up the job")
+		default:
+			health.Issues = append(health.Issues, fmt.Sprintf("Job failed: %s", valueOrNone(failed.Reason)))
+		}
+		score -= 50
+	} else if finished {
+		health.Phase = "Complete"
+	} else if job.Spec.Suspend != nil && *job.Spec.Suspend {
+		health.Phase = "Suspended"
+		health.Issues = append(health.Issues, "Job is suspended")
+		score -= 25
+	} else {
+		if job.Status.Failed > 0 {
+			health.Issues = append(health.Issues, fmt.Sprintf("%d failed attempts (backoffLimit %d)", job.Status.Failed, backoffLimit))
+			score -= 15
+		}
+		if job.Status.StartTime != nil && job.Spec.ActiveDeadlineSeconds == nil && now.Sub(job.Status.StartTime.Time) > longRunningJob {
+			health.Issues = append(health.Issues, fmt.Sprintf("Running for %s without activeDeadlineSeconds", health.Duration))
+			health.Recommendations = append(health.Recommendations, "Set activeDeadlineSeconds so a hung job is stopped")
+			score -= 10
+		}
+	}
+
+	if finished && job.Spec.TTLSecondsAfterFinished == nil {
+		health.Recommendations = append(health.Recommendations, "Set ttlSecondsAfterFinished so finished Jobs are cleaned up")
+	}
+
+	health.HealthScore = score
+	health.Status = batchStatus(score)
+	return health
+}
+
+func analyzeCronJobHealth(cronJob *batchv1.CronJob, runs []batchv1.Job, now time.Time) CronJobHealth {
+	policy := string(cronJob.Spec.ConcurrencyPolicy)
+	if policy == "" {
+		policy = string(batchv1.AllowConcurrent)
+	}
+
+	health := CronJobHealth{
+		Name:              cronJob.Name,
+		Namespace:         cronJob.Namespace,
+		Schedule:          cronJob.Spec.Schedule,
+		Suspended:         cronJob.Spec.Suspend != nil && *cronJob.Spec.Suspend,
+		ConcurrencyPolicy: policy,
+		LastSchedule:      "Never",
+		LastSuccessful:    "Never",
+		ActiveJobs:        len(cronJob.Status.Active),
+		AverageDuration:   "-",
+		DurationTrend:     "-",
+		Issues:            []string{},
+		Recommendations:   []string{},
+	}
+	if cronJob.Status.LastScheduleTime != nil {
+		health.LastSchedule = getSimpleAge(cronJob.Status.LastScheduleTime.Time) + " ago"
+	}
+	if cronJob.Status.LastSuccessfulTime != nil {
+		health.LastSuccessful = getSimpleAge(cronJob.Status.LastSuccessfulTime.Time) + " ago"
+	}
+
+	score := 100
+
+	if health.Suspended {
+		health.Issues = append(health.Issues, "CronJob is suspended")
+		score -= 25
+	}
+
+	var interval time.Duration
+	schedule, err := parseCronSchedule(cronJob.Spec.Schedule)
+	location := time.UTC
+	if cronJob.Spec.TimeZone != nil {
+		if loaded, tzErr := time.LoadLocation(*cronJob.Spec.TimeZone); tzErr == nil {
+			location = loaded
+		} else {
+			health.Issues = append(health.Issues, fmt.Sprintf("Unknown time zone %q", *cronJob.Spec.TimeZone))
+			score -= 20
+		}
+	}
+	if err != nil {
+		health.Issues = append(health.Issues, fmt.Sprintf("Invalid schedule: %v", err))
+		score -= 50
+	} else {
+		next := schedule.next(now.In(location))
+		interval = schedule.next(next).Sub(next)
+
+		if !health.Suspended {
+			since := cronJob.CreationTimestamp.Time
+			if cronJob.Status.LastScheduleTime != nil {
+				since = cronJob.Status.LastScheduleTime.Time
+			}
+			health.MissedRuns = missedCronRuns(schedule, since.In(location), now.In(location))
+		}
+	}
+
+	if health.MissedRuns > 0 {
+		missed := fmt.Sprintf("%d", health.MissedRuns)
+		if health.MissedRuns >= maxMissedCronRuns {
+			missed += "+"
+		}
+		if policy == string(batchv1.ForbidConcurrent) && health.ActiveJobs > 0 {
+			health.Issues = append(health.Issues, fmt.Sprintf("%s runs skipped while the previous run is still active (concurrencyPolicy Forbid)", missed))
+		} else {
+			health.Issues = append(health.Issues, fmt.Sprintf("Missed %s scheduled runs since %s", missed, health.LastSchedule))
+		}
+		if cronJob.Spec.StartingDeadlineSeconds == nil && health.MissedRuns >= maxMissedCronRuns {
+			health.Recommendations = append(health.Recommendations, "Set startingDeadlineSeconds; the controller stops scheduling after 100 missed runs without it")
+		}
+		if health.MissedRuns >= 3 {
+			score -= 45
+		} else {
+			score -= 30
+		}
+	}
+
+	if policy == string(batchv1.AllowConcurrent) && health.ActiveJobs > 1 {
+		health.Issues = append(health.Issues, fmt.Sprintf("%d runs overlapping (concurrencyPolicy Allow)", health.ActiveJobs))
+		health.Recommendations = append(health.Recommendations, "Set concurrencyPolicy to Forbid or Replace if runs must not overlap")
+		score -= 20
+	}
+
+	// Most recent runs first
+	sort.Slice(runs, func(i, j int) bool {
+		return jobStartedAt(&runs[i]).After(jobStartedAt(&runs[j]))
+	})
+
+	var lastFailure string
+	for _, run := range runs {
+		finished, _ := jobFinishedAt(&run)
+		if !finished {
+			continue
+		}
+		failed := jobFailedCondition(&run)
+		if failed == nil {
+			break
+		}
+		if health.ConsecutiveFailures == 0 {
+			lastFailure = valueOrNone(failed.Reason)
+		}
+		health.ConsecutiveFailures++
+	}
+	if health.ConsecutiveFailures >= 3 {
+		health.Issues = append(health.Issues, fmt.Sprintf("%d consecutive failed runs (last: %s)", health.ConsecutiveFailures, lastFailure))
+		score -= 40
+	} else if health.ConsecutiveFailures > 0 {
+		health.Issues = append(health.Issues, fmt.Sprintf("Last run failed (%s)", lastFailure))
+		score -= 20
+	}
+
+	durations := successfulRunDurations(runs)
+	if len(durations) > 0 {
+		var total time.Duration
+		for _, duration := range durations {
+			total += duration
+		}
+		average := total / time.Duration(len(durations))
+		health.AverageDuration = average.Truncate(time.Second).String()
+
+		if trend, ok := durationTrend(durations); ok {
+			health.DurationTrend = fmt.Sprintf("%+.0f%%", trend*100)
+			if trend >= 0.5 {
+				health.Issues = append(health.Issues, fmt.Sprintf("Run duration trending up (%s)", health.DurationTrend))
+				score -= 10
+			}
+		}
+
+		if interval > 0 && average > interval {
+			switch policy {
+			case string(batchv1.AllowConcurrent):
+				health.Recommendations = append(health.Recommendations, fmt.Sprintf("Average run (%s) exceeds the schedule interval (%s); runs will pile up", health.AverageDuration, interval))
+			case string(batchv1.ReplaceConcurrent):
+				health.Recommendations = append(health.Recommendations, fmt.Sprintf("Average run (%s) exceeds the schedule interval (%s); runs are replaced before finishing", health.AverageDuration, interval))
+			}
+		}
+	}
+
+	successfulLimit := int32(defaultSuccessfulJobsHistoryLimit)
+	if cronJob.Spec.SuccessfulJobsHistoryLimit != nil {
+		successfulLimit = *cronJob.Spec.SuccessfulJobsHistoryLimit
+	}
+	failedLimit := int32(defaultFailedJobsHistoryLimit)
+	if cronJob.Spec.FailedJobsHistoryLimit != nil {
+		failedLimit = *cronJob.Spec.FailedJobsHistoryLimit
+	}
+	if failedLimit == 0 {
+		health.Recommendations = append(health.Recommendations, "failedJobsHistoryLimit is 0; failed runs are deleted before they can be inspected")
+	}
+	if successfulLimit > 10 || failedLimit > 10 {
+		health.Recommendations = append(health.Recommendations, fmt.Sprintf("High job history limits (%d successful, %d failed) keep many finished Jobs and pods around", successfulLimit, failedLimit))
+	}
+
+	if score < 0 {
+		score = 0
+	}
+	health.HealthScore = score
+	health.Status = batchStatus(score)
+	return health
+}
+
+// missedCronRuns counts scheduled times after since that should have started
+// by now, capped like the CronJob controller caps its own catch-up.
+func missedCronRuns(schedule *cronSchedule, since, now time.Time) int {
+	missed := 0
+	for t := schedule.next(since); !t.IsZero() && !t.After(now.Add(-cronMissedRunGrace)); t = schedule.next(t) {
+		missed++
+		if missed >= maxMissedCronRuns {
+			break
+		}
+	}
+	return missed
+}
+
+func jobFailedCondition(job *batchv1.Job) *batchv1.JobCondition {
+	for i, condition := range job.Status.Conditions {
+		if condition.Type == batchv1.JobFailed && condition.Status == corev1.ConditionTrue {
+			return &job.Status.Conditions[i]
+		}
+	}
+	return nil
+}
+
+func jobStartedAt(job *batchv1.Job) time.Time {
+	if job.Status.StartTime != nil {
+		return job.Status.StartTime.Time
+	}
+	return job.CreationTimestamp.Time
+}
+
+// successfulRunDurations returns the durations of succeeded runs, oldest
+// first. runs must be sorted most recent first.
+func successfulRunDurations(runs []batchv1.Job) []time.Duration {
+	var durations []time.Duration
+	for i := len(runs) - 1; i >= 0; i-- {
+		run := &runs[i]
+		finished, finishedAt := jobFinishedAt(run)
+		if !finished || jobFailedCondition(run) != nil || run.Status.StartTime == nil {
+			continue
+		}
+		durations = append(durations, finishedAt.Sub(run.Status.StartTime.Time))
+	}
+	return durations
+}
+
+// durationTrend compares the latest run to the average of the earlier ones
+// (oldest first). Two runs are enough, so the default history of three
+// successful Jobs yields a trend.
+func durationTrend(durations []time.Duration) (float64, bool) {
+	if len(durations) < 2 {
+		return 0, false
+	}
+
+	latest := durations[len(durations)-1]
+	var earlier time.Duration
+	for _, duration := range durations[:len(durations)-1] {
+		earlier += duration
+	}
+	if earlier <= 0 {
+		return 0, false
+	}
+
+	average := earlier / time.Duration(len(durations)-1)
+	return float64(latest)/float64(average) - 1, true
+}
+
+func batchStatus(score int) string {
+	if score >= 80 {
+		return "Healthy"
+	} else if score >= 60 {
+		return "Warning"
+	}
+	return "Critical"
+}
@@ -0,0 +1,581 @@
+package kubernetes
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+type ReplicaHeadroom struct {
+	Workload        string
+	Namespace       string
+	CPURequest      int64
+	MemoryRequest   int64
+	Replicas        int
+	Capped          bool
+	PerNode         []NodeReplicaCount
+	BlockingReasons []string
+}
+
+type NodeReplicaCount struct {
+	Node     string
+	Replicas int
+}
+
+type DrainSimulation struct {
+	Node        string
+	Feasible    bool
+	Placements  []DrainPlacement
+	SkippedPods int
+}
+
+type DrainPlacement struct {
+	Pod        string
+	Namespace  string
+	Workload   string
+	TargetNode string
+	Reason     string
+}
+
+type CapacityPlan struct {
+	TargetUtilization    float64
+	CPURequests          int64
+	MemoryRequests       int64
+	DaemonSetCPU         int64
+	DaemonSetMemory      int64
+	LargestPodCPU        int64
+	LargestPodMemory     int64
+	CurrentNodes         int
+	CurrentMonthlyCost   float64
+	PendingPods          int
+	PendingPodsPlaceable int
+	Options              []NodeMixOption
+}
+
+type NodeMixOption struct {
+	Instances         []InstanceCount
+	TotalNodes        int
+	MonthlyCost       float64
+	CPUUtilization    float64
+	MemoryUtilization float64
+}
+
+type InstanceCount struct {
+	Type  string
+	Count int
+}
+
+type instanceShape struct {
+	cpu    int64
+	memory int64
+}
+
+// Capacity of the instance types priced in nodeTypeCosts
+var nodeTypeShapes = map[string]instanceShape{
+	"t3.micro":  {2000, 1 << 30},
+	"t3.small":  {2000, 2 << 30},
+	"t3.medium": {2000, 4 << 30},
+	"t3.large":  {2000, 8 << 30},
+	"t3.xlarge": {4000, 16 << 30},
+	"m5.large":  {2000, 8 << 30},
+	"m5.xlarge": {4000, 16 << 30},
+	"c5.large":  {2000, 4 << 30},
+	"c5.xlarge": {4000, 8 << 30},
+}
+
+// Share of an instance's capacity left as allocatable after system and
+// kubelet reservations.
+const allocatableFraction = 0.9
+
+const maxSimulatedReplicas = 500
+
+// placementSimulator tracks node allocations while pods are placed or
+// removed, evaluating the same predicates as explainPendingPod.
+type placementSimulator struct {
+	nodes       []corev1.Node
+	allocations map[string]nodeAllocation
+	podsByNode  map[string][]corev1.Pod
+}
+
+func newPlacementSimulator(nodes []corev1.Node, pods []corev1.Pod) *placementSimulator {
+	simulator := &placementSimulator{
+		nodes:       nodes,
+		allocations: calculateNodeAllocations(nodes, pods),
+		podsByNode:  make(map[string][]corev1.Pod),
+	}
+	for _, pod := range pods {
+		if pod.Spec.NodeName != "" && isPodActive(&pod) {
+			simulator.podsByNode[pod.Spec.NodeName] = append(simulator.podsByNode[pod.Spec.NodeName], pod)
+		}
+	}
+	return simulator
+}
+
+func (s *placementSimulator) reasons(pod *corev1.Pod, node *corev1.Node, volumes []volumeConstraint) []string {
+	cpu, memory := getPodResourceRequests(pod)
+
+	var reasons []string
+	reasons = append(reasons, checkNodeSelection(pod, node)...)
+	reasons = append(reasons, checkTaints(pod, node)...)
+	reasons = append(reasons, checkVolumeAffinity(volumes, node)...)
+	reasons = append(reasons, checkResourceFit(cpu, memory, s.allocations[node.Name])...)
+	reasons = append(reasons, checkTopologySpread(pod, node, s.nodes, s.podsByNode)...)
+	reasons = append(reasons, checkPodAffinity(pod, node, s.nodes, s.podsByNode)...)
+	return reasons
+}
+
+// bestNode returns the fitting node with the most free CPU, mirroring the
+// scheduler's least-allocated scoring. It returns an empty name and the
+// reasons per node when nothing fits.
+func (s *placementSimulator) bestNode(pod *corev1.Pod, volumes []volumeConstraint) (string, map[string][]string) {
+	best := ""
+	rejected := make(map[string][]string)
+	for i := range s.nodes {
+		node := &s.nodes[i]
+		if reasons := s.reasons(pod, node, volumes); len(reasons) > 0 {
+			rejected[node.Name] = reasons
+			continue
+		}
+		if best == "" || s.allocations[node.Name].freeCPU > s.allocations[best].freeCPU {
+			best = node.Name
+		}
+	}
+	return best, rejected
+}
+
+func (s *placementSimulator) place(pod corev1.Pod, nodeName string) {
+	cpu, memory := getPodResourceRequests(&pod)
+	allocation := s.allocations[nodeName]
+	allocation.freeCPU -= cpu
+	allocation.freeMemory -= memory
+	allocation.freePods--
+	s.allocations[nodeName] = allocation
+
+	pod.Spec.NodeName = nodeName
+	s.podsByNode[nodeName] = append(s.podsByNode[nodeName], pod)
+}
+
+func (s *placementSimulator) removeNode(nodeName string) {
+	var remaining []corev1.Node
+	for _, node := range s.nodes {
+		if node.Name != nodeName {
+			remaining = append(remaining, node)
+		}
+	}
+	s.nodes = remaining
+	delete(s.allocations, nodeName)
+	delete(s.podsByNode, nodeName)
+}
+
+// GetPodTemplate returns the pod spec of a deployment, statefulset or pod
+// ("deploy/name", "sts/name", "pod/name"; a bare name is a deployment) to be
+// used for capacity simulations.
+func (c *Client) GetPodTemplate(target, namespace string) (*corev1.Pod, error) {
+	kind, name, found := strings.Cut(target, "/")
+	if !found {
+		kind, name = "deploy", target
+	}
+	if namespace == "" {
+		namespace = "default"
+	}
+
+	switch strings.ToLower(kind) {
+	case "deploy", "deployment", "deployments":
+		deployment, err := c.Clientset.AppsV1().Deployments(namespace).Get(c.Context, name, metav1.GetOptions{})
+		if err != nil {
+			return nil, fmt.Errorf("failed to get deployment %s: %w", name, err)
+		}
+		return podFromTemplate(name, namespace, deployment.Spec.Template), nil
+
+	case "sts", "statefulset", "statefulsets":
+		statefulSet, err := c.Clientset.AppsV1().StatefulSets(namespace).Get(c.Context, name, metav1.GetOptions{})
+		if err != nil {
+			return nil, fmt.Errorf("failed to get statefulset %s: %w", name, err)
+		}
+		return podFromTemplate(name, namespace, statefulSet.Spec.Template), nil
+
+	case "pod", "pods", "po":
+		pod, err := c.Clientset.CoreV1().Pods(namespace).Get(c.Context, name, metav1.GetOptions{})
+		if err != nil {
+			return nil, fmt.Errorf("failed to get pod %s: %w", name, err)
+		}
+		template := podFromTemplate(name, namespace, corev1.PodTemplateSpec{ObjectMeta: pod.ObjectMeta, Spec: pod.Spec})
+		template.Spec.NodeName = ""
+		return template, nil
+
+	default:
+		return nil, fmt.Errorf("unsupported target kind %q, expected deploy/, sts/ or pod/", kind)
+	}
+}
+
+// NewHypotheticalPod builds a single-container pod with the given requests.
+func NewHypotheticalPod(namespace string, cpu, memory resource.Quantity) *corev1.Pod {
+	if namespace == "" {
+		namespace = "default"
+	}
+	return &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{Name: "hypothetical", Namespace: namespace},
+		Spec: corev1.PodSpec{
+			Containers: []corev1.Container{{
+				Name: "app",
+				Resources: corev1.ResourceRequirements{Requests: corev1.ResourceList{
+					corev1.ResourceCPU:    cpu,
+					corev1.ResourceMemory: memory,
+				}},
+			}},
+		},
+	}
+}
+
+func podFromTemplate(name, namespace string, template corev1.PodTemplateSpec) *corev1.Pod {
+	return &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: namespace, Labels: template.Labels},
+		Spec:       template.Spec,
+	}
+}
+
+// GetReplicaHeadroom simulates how many more replicas of template fit on the
+// cluster's nodes.
+func (c *Client) GetReplicaHeadroom(template *corev1.Pod) (*ReplicaHeadroom, error) {
+	nodes, err := c.Clientset.CoreV1().Nodes().List(c.Context, metav1.ListOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get nodes: %w", err)
+	}
+
+	pods, err := c.Clientset.CoreV1().Pods("").List(c.Context, metav1.ListOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get pods: %w", err)
+	}
+
+	headroom := simulateReplicaHeadroom(template, nodes.Items, pods.Items, c.getVolumeConstraints(template))
+	return &headroom, nil
+}
+
+func simulateReplicaHeadroom(template *corev1.Pod, nodes []corev1.Node, pods []corev1.Pod, volumes []volumeConstraint) ReplicaHeadroom {
+	cpu, memory := getPodResourceRequests(template)
+	headroom := ReplicaHeadroom{
+		Workload:      template.Name,
+		Namespace:     template.Namespace,
+		CPURequest:    cpu,
+		MemoryRequest: memory,
+	}
+
+	simulator := newPlacementSimulator(nodes, pods)
+	perNode := make(map[string]int)
+
+	var rejected map[string][]string
+	for headroom.Replicas < maxSimulatedReplicas {
+		replica := *template.DeepCopy()
+		replica.Name = fmt.Sprintf("%s-simulated-%d", template.Name, headroom.Replicas)
+
+		var nodeName string
+		nodeName, rejected = simulator.bestNode(&replica, volumes)
+		if nodeName == "" {
+			break
+		}
+		simulator.place(replica, nodeName)
+		perNode[nodeName]++
+		headroom.Replicas++
+	}
+	headroom.Capped = headroom.Replicas >= maxSimulatedReplicas
+
+	for node, count := range perNode {
+		headroom.PerNode = append(headroom.PerNode, NodeReplicaCount{Node: node, Replicas: count})
+	}
+	sort.Slice(headroom.PerNode, func(i, j int) bool {
+		if headroom.PerNode[i].Replicas != headroom.PerNode[j].Replicas {
+			return headroom.PerNode[i].Replicas > headroom.PerNode[j].Replicas
+		}
+		return headroom.PerNode[i].Node < headroom.PerNode[j].Node
+	})
+
+	if !headroom.Capped {
+		headroom.BlockingReasons = summarizeRejections(rejected, len(nodes))
+	}
+
+	return headroom
+}
+
+// SimulateDrain checks whether the evictable pods of a node can be
+// rescheduled onto the remaining nodes.
+func (c *Client) SimulateDrain(nodeName string) (*DrainSimulation, error) {
+	nodes, err := c.Clientset.CoreV1().Nodes().List(c.Context, metav1.ListOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get nodes: %w", err)
+	}
+
+	found := false
+	for _, node := range nodes.Items {
+		if node.Name == nodeName {
+			found = true
+			break
+		}
+	}
+	if !found {
+		return nil, fmt.Errorf("node %s not found", nodeName)
+	}
+
+	pods, err := c.Clientset.CoreV1().Pods("").List(c.Context, metav1.ListOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get pods: %w", err)
+	}
+
+	volumes := make(map[string][]volumeConstraint)
+	for i := range pods.Items {
+		pod := &pods.Items[i]
+		if pod.Spec.NodeName == nodeName && len(pod.Spec.Volumes) > 0 {
+			volumes[pod.Namespace+"/"+pod.Name] = c.getVolumeConstraints(pod)
+		}
+	}
+
+	simulation := simulateDrain(nodeName, nodes.Items, pods.Items, volumes)
+	return &simulation, nil
+}
+
+func simulateDrain(nodeName string, nodes []corev1.Node, pods []corev1.Pod, volumes map[string][]volumeConstraint) DrainSimulation {
+	simulation := DrainSimulation{Node: nodeName, Feasible: true}
+
+	var evicted []corev1.Pod
+	for _, pod := range pods {
+		if pod.Spec.NodeName != nodeName || !isPodActive(&pod) {
+			continue
+		}
+		if isDaemonSetPod(&pod) || isMirrorPod(&pod) {
+			simulation.SkippedPods++
+			continue
+		}
+		evicted = append(evicted, pod)
+	}
+
+	simulator := newPlacementSimulator(nodes, pods)
+	simulator.removeNode(nodeName)
+
+	// Place the largest pods first, like a first-fit-decreasing bin packer
+	sort.SliceStable(evicted, func(i, j int) bool {
+		cpuI, memI := getPodResourceRequests(&evicted[i])
+		cpuJ, memJ := getPodResourceRequests(&evicted[j])
+		if cpuI != cpuJ {
+			return cpuI > cpuJ
+		}
+		return memI > memJ
+	})
+
+	for _, pod := range evicted {
+		placement := DrainPlacement{Pod: pod.Name, Namespace: pod.Namespace, Workload: getPodWorkload(&pod)}
+
+		candidate := *pod.DeepCopy()
+		candidate.Spec.NodeName = ""
+
+		target, rejected := simulator.bestNode(&candidate, volumes[pod.Namespace+"/"+pod.Name])
+		if target == "" {
+			simulation.Feasible = false
+			placement.Reason = strings.Join(summarizeRejections(rejected, len(simulator.nodes)), "; ")
+			if placement.Reason == "" {
+				placement.Reason = "no other nodes"
+			}
+		} else {
+			placement.TargetNode = target
+			simulator.place(candidate, target)
+		}
+
+		simulation.Placements = append(simulation.Placements, placement)
+	}
+
+	return simulation
+}
+
+func isDaemonSetPod(pod *corev1.Pod) bool {
+	return strings.HasPrefix(getPodOwner(pod), "DaemonSet/")
+}
+
+func isMirrorPod(pod *corev1.Pod) bool {
+	_, exists := pod.Annotations[corev1.MirrorPodAnnotationKey]
+	return exists
+}
+
+func summarizeRejections(rejected map[string][]string, totalNodes int) []string {
+	counts := make(map[string]int)
+	for _, reasons := range rejected {
+		seen := make(map[string]bool)
+		for _, reason := range reasons {
+			category := reasonCategory(reason)
+			if !seen[category] {
+				seen[category] = true
+				counts[category]++
+			}
+		}
+	}
+
+	var summary []string
+	for category, count := range counts {
+		summary = append(summary, fmt.Sprintf("%d/%d nodes: %s", count, totalNodes, category))
+	}
+	sort.Strings(summary)
+	return summary
+}
+
+// GetCapacityPlan sizes the cluster for its current requests at the target
+// utilization using the instance types of the pricing catalog.
+func (c *Client) GetCapacityPlan(targetUtilization float64) (*CapacityPlan, error) {
+	if targetUtilization <= 0 || targetUtilization > 1 {
+		return nil, fmt.Errorf("target utilization must be between 0 and 1, got %.2f", targetUtilization)
+	}
+
+	nodes, err := c.Clientset.CoreV1().Nodes().List(c.Context, metav1.ListOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get nodes: %w", err)
+	}
+
+	pods, err := c.Clientset.CoreV1().Pods("").List(c.Context, metav1.ListOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get pods: %w", err)
+	}
+
+	plan := buildCapacityPlan(nodes.Items, pods.Items, targetUtilization)
+	for i := range nodes.Items {
+		plan.CurrentMonthlyCost += c.getNodeCost(c.extractNodeType(&nodes.Items[i]))
+	}
+
+	return &plan, nil
+}
+
+func buildCapacityPlan(nodes []corev1.Node, pods []corev1.Pod, targetUtilization float64) CapacityPlan {
+	plan := CapacityPlan{TargetUtilization: targetUtilization, CurrentNodes: len(nodes)}
+
+	daemonSetRequests := make(map[string]instanceShape)
+	var pending []corev1.Pod
+
+	for i := range pods {
+		pod := &pods[i]
+		if !isPodActive(pod) {
+			continue
+		}
+		cpu, memory := getPodResourceRequests(pod)
+
+		if isDaemonSetPod(pod) {
+			usage := daemonSetRequests[pod.Spec.NodeName]
+			usage.cpu += cpu
+			usage.memory += memory
+			daemonSetRequests[pod.Spec.NodeName] = usage
+			continue
+		}
+
+		plan.CPURequests += cpu
+		plan.MemoryRequests += memory
+		plan.LargestPodCPU = max(plan.LargestPodCPU, cpu)
+		plan.LargestPodMemory = max(plan.LargestPodMemory, memory)
+
+		if pod.Spec.NodeName == "" && pod.Status.Phase == corev1.PodPending {
+			pending = append(pending, *pod)
+		}
+	}
+
+	// Every new node runs the DaemonSets, so reserve the heaviest per-node set
+	for _, usage := range daemonSetRequests {
+		plan.DaemonSetCPU = max(plan.DaemonSetCPU, usage.cpu)
+		plan.DaemonSetMemory = max(plan.DaemonSetMemory, usage.memory)
+	}
+
+	plan.PendingPods = len(pending)
+	simulator := newPlacementSimulator(nodes, pods)
+	for _, pod := range pending {
+		if nodeName, _ := simulator.bestNode(&pod, nil); nodeName != "" {
+			simulator.place(pod, nodeName)
+			plan.PendingPodsPlaceable++
+		}
+	}
+
+	plan.Options = planNodeMix(plan, 5)
+	return plan
+}
+
+// planNodeMix returns the cheapest single-type and two-type node pools that
+// hold the plan's requests at its target utilization.
+func planNodeMix(plan CapacityPlan, limit int) []NodeMixOption {
+	type usableShape struct {
+		name   string
+		cpu    float64
+		memory float64
+		cost   float64
+	}
+
+	var shapes []usableShape
+	for name, shape := range nodeTypeShapes {
+		cpu := float64(shape.cpu)*allocatableFraction*plan.TargetUtilization - float64(plan.DaemonSetCPU)
+		memory := float64(shape.memory)*allocatableFraction*plan.TargetUtilization - float64(plan.DaemonSetMemory)
+		if cpu < float64(plan.LargestPodCPU) || memory < float64(plan.LargestPodMemory) || cpu <= 0 || memory <= 0 {
+			continue
+		}
+		shapes = append(shapes, usableShape{name, cpu, memory, nodeTypeCosts[name]})
+	}
+	sort.Slice(shapes, func(i, j int) bool { return shapes[i].name < shapes[j].name })
+
+	cpuNeed, memoryNeed := float64(plan.CPURequests), float64(plan.MemoryRequests)
+	nodesNeeded := func(shape usableShape, cpu, memory float64) int {
+		if cpu <= 0 && memory <= 0 {
+			return 0
+		}
+		return int(math.Ceil(math.Max(cpu/shape.cpu, memory/shape.memory)))
+	}
+
+	newOption := func(counts ...InstanceCount) NodeMixOption {
+		option := NodeMixOption{}
+		var cpuCapacity, memoryCapacity float64
+		for _, count := range counts {
+			if count.Count == 0 {
+				continue
+			}
+			shape := nodeTypeShapes[count.Type]
+			option.Instances = append(option.Instances, count)
+			option.TotalNodes += count.Count
+			option.MonthlyCost += float64(count.Count) * nodeTypeCosts[count.Type]
+			cpuCapacity += float64(count.Count) * float64(shape.cpu) * allocatableFraction
+			memoryCapacity += float64(count.Count) * float64(shape.memory) * allocatableFraction
+		}
+		if cpuCapacity > 0 {
+			option.CPUUtilization = (cpuNeed + float64(int64(option.TotalNodes)*plan.DaemonSetCPU)) / cpuCapacity * 100
+			option.MemoryUtilization = (memoryNeed + float64(int64(option.TotalNodes)*plan.DaemonSetMemory)) / memoryCapacity * 100
+		}
+		return option
+	}
+
+	var options []NodeMixOption
+	for i, a := range shapes {
+		single := max(nodesNeeded(a, cpuNeed, memoryNeed), 1)
+		options = append(options, newOption(InstanceCount{a.name, single}))
+
+		for _, b := range shapes[i+1:] {
+			var best *NodeMixOption
+			for countA := 1; countA < single; countA++ {
+				countB := nodesNeeded(b, cpuNeed-float64(countA)*a.cpu, memoryNeed-float64(countA)*a.memory)
+				if countB == 0 {
+					continue
+				}
+				option := newOption(InstanceCount{a.name, countA}, InstanceCount{b.name, countB})
+				if best == nil || option.MonthlyCost < best.MonthlyCost {
+					best = &option
+				}
+			}
+			if best != nil {
+				options = append(options, *best)
+			}
+		}
+	}
+
+	sort.SliceStable(options, func(i, j int) bool {
+		if options[i].MonthlyCost != options[j].MonthlyCost {
+			return options[i].MonthlyCost < options[j].MonthlyCost
+		}
+		return options[i].TotalNodes < options[j].TotalNodes
+	})
+
+	if len(options) > limit {
+		options = options[:limit]
+	}
+	return options
+}
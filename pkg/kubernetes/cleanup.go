@@ -0,0 +1,489 @@
+package kubernetes
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+
+	appsv1 "k8s.io/api/apps/v1"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	discoveryv1 "k8s.io/api/discovery/v1"
+	networkingv1 "k8s.io/api/networking/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+type CleanupReport struct {
+	Findings    []CleanupFinding
+	Counts      map[string]int
+	MonthlyCost float64
+}
+
+type CleanupFinding struct {
+	Category    string
+	Kind        string
+	Namespace   string
+	Name        string
+	Age         string
+	Owner       string
+	Reason      string
+	MonthlyCost float64
+	FixRule     string
+}
+
+// cleanupInventory holds everything the cleanup rules look at, so they can
+// run without a cluster.
+type cleanupInventory struct {
+	namespaces      []corev1.Namespace
+	pods            []corev1.Pod
+	configMaps      []corev1.ConfigMap
+	secrets         []corev1.Secret
+	pvcs            []corev1.PersistentVolumeClaim
+	services        []corev1.Service
+	endpointSlices  []discoveryv1.EndpointSlice
+	serviceAccounts []corev1.ServiceAccount
+	ingresses       []networkingv1.Ingress
+	deployments     []appsv1.Deployment
+	replicaSets     []appsv1.ReplicaSet
+	statefulSets    []appsv1.StatefulSet
+	daemonSets      []appsv1.DaemonSet
+	jobs            []batchv1.Job
+	cronJobs        []batchv1.CronJob
+}
+
+const (
+	// Simplified cloud list prices, in line with nodeTypeCosts
+	storageCostPerGBMonth   = 0.10
+	loadBalancerMonthlyCost = 0.025 * 24 * 30
+
+	defaultRevisionHistoryLimit  = 10
+	deploymentRevisionAnnotation = "deployment.kubernetes.io/revision"
+)
+
+// Secrets managed by Kubernetes or Helm are never referenced by pods.
+var ignoredSecretTypes = map[corev1.SecretType]bool{
+	corev1.SecretTypeServiceAccountToken: true,
+	corev1.SecretTypeBootstrapToken:      true,
+	"helm.sh/release.v1":                 true,
+}
+
+// GetCleanupReport lists objects that look orphaned or unused: nothing
+// references them, they have no backends or they are finished leftovers.
+func (c *Client) GetCleanupReport(namespace string) (*CleanupReport, error) {
+	var inventory cleanupInventory
+
+	if namespace != "" {
+		ns, err := c.Clientset.CoreV1().Namespaces().Get(c.Context, namespace, metav1.GetOptions{})
+		if err != nil {
+			return nil, fmt.Errorf("failed to get namespace %s: %w", namespace, err)
+		}
+		inventory.namespaces = []corev1.Namespace{*ns}
+	} else {
+		namespaces, err := c.Clientset.CoreV1().Namespaces().List(c.Context, metav1.ListOptions{})
+		if err != nil {
+			return nil, fmt.Errorf("failed to get namespaces: %w", err)
+		}
+		inventory.namespaces = namespaces.Items
+	}
+
+	pods, err := c.Clientset.CoreV1().Pods(namespace).List(c.Context, metav1.ListOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get pods: %w", err)
+	}
+	inventory.pods = pods.Items
+
+	configMaps, err := c.Clientset.CoreV1().ConfigMaps(namespace).List(c.Context, metav1.ListOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get configmaps: %w", err)
+	}
+	inventory.configMaps = configMaps.Items
+
+	secrets, err := c.Clientset.CoreV1().Secrets(namespace).List(c.Context, metav1.ListOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get secrets: %w", err)
+	}
+	inventory.secrets = secrets.Items
+
+	pvcs, err := c.Clientset.CoreV1().PersistentVolumeClaims(namespace).List(c.Context, metav1.ListOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get persistent volume claims: %w", err)
+	}
+	inventory.pvcs = pvcs.Items
+
+	services, err := c.Clientset.CoreV1().Services(namespace).List(c.Context, metav1.ListOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get services: %w", err)
+	}
+	inventory.services = services.Items
+
+	endpointSlices, err := c.Clientset.DiscoveryV1().EndpointSlices(namespace).List(c.Context, metav1.ListOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get endpoint slices: %w", err)
+	}
+	inventory.endpointSlices = endpointSlices.Items
+
+	serviceAccounts, err := c.Clientset.CoreV1().ServiceAccounts(namespace).List(c.Context, metav1.ListOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get service accounts: %w", err)
+	}
+	inventory.serviceAccounts = serviceAccounts.Items
+
+	ingresses, err := c.Clientset.NetworkingV1().Ingresses(namespace).List(c.Context, metav1.ListOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get ingresses: %w", err)
+	}
+	inventory.ingresses = ingresses.Items
+
+	deployments, err := c.Clientset.AppsV1().Deployments(namespace).List(c.Context, metav1.ListOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get deployments: %w", err)
+	}
+	inventory.deployments = deployments.Items
+
+	replicaSets, err := c.Clientset.AppsV1().ReplicaSets(namespace).List(c.Context, metav1.ListOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get replicasets: %w", err)
+	}
+	inventory.replicaSets = replicaSets.Items
+
+	statefulSets, err := c.Clientset.AppsV1().StatefulSets(namespace).List(c.Context, metav1.ListOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get statefulsets: %w", err)
+	}
+	inventory.statefulSets = statefulSets.Items
+
+	daemonSets, err := c.Clientset.AppsV1().DaemonSets(namespace).List(c.Context, metav1.ListOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get daemonsets: %w", err)
+	}
+	inventory.daemonSets = daemonSets.Items
+
+	jobs, err := c.Clientset.BatchV1().Jobs(namespace).List(c.Context, metav1.ListOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get jobs: %w", err)
+	}
+	inventory.jobs = jobs.Items
+
+	cronJobs, err := c.Clientset.BatchV1().CronJobs(namespace).List(c.Context, metav1.ListOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get cronjobs: %w", err)
+	}
+	inventory.cronJobs = cronJobs.Items
+
+	return buildCleanupReport(&inventory), nil
+}
+
+func buildCleanupReport(inventory *cleanupInventory) *CleanupReport {
+	var findings []CleanupFinding
+	add := func(category, kind string, meta metav1.ObjectMeta, reason string) *CleanupFinding {
+		findings = append(findings, CleanupFinding{
+			Category:  category,
+			Kind:      kind,
+			Namespace: meta.Namespace,
+			Name:      meta.Name,
+			Age:       getSimpleAge(meta.CreationTimestamp.Time),
+			Owner:     getObjectOwner(meta.OwnerReferences),
+			Reason:    reason,
+		})
+		return &findings[len(findings)-1]
+	}
+
+	// Pod templates count as references too, so objects used by a
+	// CronJob or a Deployment scaled to zero are not reported as unused
+	type podSpecRef struct {
+		namespace string
+		spec      corev1.PodSpec
+	}
+	var specs []podSpecRef
+	for _, pod := range inventory.pods {
+		specs = append(specs, podSpecRef{pod.Namespace, pod.Spec})
+	}
+	for _, deploy := range inventory.deployments {
+		specs = append(specs, podSpecRef{deploy.Namespace, deploy.Spec.Template.Spec})
+	}
+	for _, ss := range inventory.statefulSets {
+		specs = append(specs, podSpecRef{ss.Namespace, ss.Spec.Template.Spec})
+	}
+	for _, ds := range inventory.daemonSets {
+		specs = append(specs, podSpecRef{ds.Namespace, ds.Spec.Template.Spec})
+	}
+	for _, job := range inventory.jobs {
+		specs = append(specs, podSpecRef{job.Namespace, job.Spec.Template.Spec})
+	}
+	for _, cronJob := range inventory.cronJobs {
+		specs = append(specs, podSpecRef{cronJob.Namespace, cronJob.Spec.JobTemplate.Spec.Template.Spec})
+	}
+
+	configMapRefs := make(map[string]bool)
+	secretRefs := make(map[string]bool)
+	serviceAccountRefs := make(map[string]bool)
+	for _, ref := range specs {
+		collectPodSpecReferences(ref.namespace, ref.spec, configMapRefs, secretRefs)
+		serviceAccount := ref.spec.ServiceAccountName
+		if serviceAccount == "" {
+			serviceAccount = "default"
+		}
+		serviceAccountRefs[ref.namespace+"/"+serviceAccount] = true
+	}
+	for _, sa := range inventory.serviceAccounts {
+		for _, ref := range sa.Secrets {
+			secretRefs[sa.Namespace+"/"+ref.Name] = true
+		}
+		for _, ref := range sa.ImagePullSecrets {
+			secretRefs[sa.Namespace+"/"+ref.Name] = true
+		}
+	}
+	for _, ingress := range inventory.ingresses {
+		for _, tls := range ingress.Spec.TLS {
+			secretRefs[ingress.Namespace+"/"+tls.SecretName] = true
+		}
+	}
+
+	for _, cm := range inventory.configMaps {
+		if isSystemNamespace(cm.Namespace) || cm.Name == "kube-root-ca.crt" || configMapRefs[cm.Namespace+"/"+cm.Name] {
+			continue
+		}
+		add("Unused ConfigMap", "ConfigMap", cm.ObjectMeta, "Not referenced by any pod or pod template")
+	}
+
+	for _, secret := range inventory.secrets {
+		if isSystemNamespace(secret.Namespace) || ignoredSecretTypes[secret.Type] || secretRefs[secret.Namespace+"/"+secret.Name] {
+			continue
+		}
+		add("Unused Secret", "Secret", secret.ObjectMeta, "Not referenced by any pod, ServiceAccount or Ingress")
+	}
+
+	mountedClaims := make(map[string]bool)
+	for _, pod := range inventory.pods {
+		if !isPodActive(&pod) {
+			continue
+		}
+		for _, volume := range pod.Spec.Volumes {
+			if volume.PersistentVolumeClaim != nil {
+				mountedClaims[pod.Namespace+"/"+volume.PersistentVolumeClaim.ClaimName] = true
+			}
+		}
+	}
+	for _, pvc := range inventory.pvcs {
+		if mountedClaims[pvc.Namespace+"/"+pvc.Name] {
+			continue
+		}
+		size := pvc.Spec.Resources.Requests[corev1.ResourceStorage]
+		if capacity, exists := pvc.Status.Capacity[corev1.ResourceStorage]; exists {
+			size = capacity
+		}
+		finding := add("Unmounted PVC", "PersistentVolumeClaim", pvc.ObjectMeta, fmt.Sprintf("%s %s not mounted by any running pod", size.String(), pvc.Status.Phase))
+		finding.MonthlyCost = float64(size.Value()) / (1024 * 1024 * 1024) * storageCostPerGBMonth
+	}
+
+	readyEndpoints := readyServiceEndpoints(inventory.endpointSlices)
+	for _, svc := range inventory.services {
+		if svc.Spec.Type == corev1.ServiceTypeExternalName || isSystemNamespace(svc.Namespace) || readyEndpoints[svc.Namespace+"/"+svc.Name] {
+			continue
+		}
+		if svc.Namespace == "default" && svc.Name == "kubernetes" {
+			continue
+		}
+		finding := add("Service Without Endpoints", "Service", svc.ObjectMeta, fmt.Sprintf("%s service has no ready endpoints", svc.Spec.Type))
+		if svc.Spec.Type == corev1.ServiceTypeLoadBalancer {
+			finding.MonthlyCost = loadBalancerMonthlyCost
+		}
+	}
+
+	historyLimits := make(map[string]int)
+	for _, deploy := range inventory.deployments {
+		limit := defaultRevisionHistoryLimit
+		if deploy.Spec.RevisionHistoryLimit != nil {
+			limit = int(*deploy.Spec.RevisionHistoryLimit)
+		}
+		historyLimits[deploy.Namespace+"/"+deploy.Name] = limit
+	}
+	oldReplicaSets := make(map[string][]appsv1.ReplicaSet)
+	for _, rs := range inventory.replicaSets {
+		if replicasOrDefault(rs.Spec.Replicas) != 0 || rs.Status.Replicas != 0 {
+			continue
+		}
+		owner := getObjectOwner(rs.OwnerReferences)
+		if owner == "<none>" {
+			add("Stale ReplicaSet", "ReplicaSet", rs.ObjectMeta, "Scaled to 0 and not owned by a Deployment")
+			continue
+		}
+		oldReplicaSets[rs.Namespace+"/"+owner] = append(oldReplicaSets[rs.Namespace+"/"+owner], rs)
+	}
+	for _, key := range sortedReplicaSetOwners(oldReplicaSets) {
+		replicaSets := oldReplicaSets[key]
+		sort.SliceStable(replicaSets, func(i, j int) bool {
+			return replicaSetRevision(&replicaSets[i]) > replicaSetRevision(&replicaSets[j])
+		})
+
+		namespace := replicaSets[0].Namespace
+		limit, found := historyLimits[namespace+"/"+replicaSetOwnerName(&replicaSets[0])]
+		if !found {
+			limit = defaultRevisionHistoryLimit
+		}
+		for i := limit; i < len(replicaSets); i++ {
+			add("Stale ReplicaSet", "ReplicaSet", replicaSets[i].ObjectMeta, fmt.Sprintf("Scaled to 0 beyond revisionHistoryLimit %d", limit))
+		}
+	}
+
+	for _, job := range inventory.jobs {
+		if hasCronJobOwner(&job) {
+			continue
+		}
+		finished, finishedAt := jobFinishedAt(&job)
+		if !finished {
+			continue
+		}
+		state := "Completed"
+		if job.Status.Failed > 0 && job.Status.Succeeded == 0 {
+			state = "Failed"
+		}
+		finding := add("Finished Job", "Job", job.ObjectMeta, fmt.Sprintf("%s %s ago", state, getSimpleAge(finishedAt)))
+		finding.FixRule = "completed-jobs"
+	}
+
+	for _, pod := range inventory.pods {
+		if pod.Status.Phase == corev1.PodFailed && pod.Status.Reason == "Evicted" {
+			finding := add("Evicted Pod", "Pod", pod.ObjectMeta, pod.Status.Message)
+			finding.FixRule = "failed-pods"
+		}
+	}
+
+	usedNamespaces := make(map[string]bool)
+	for _, meta := range inventoryObjectMetas(inventory) {
+		usedNamespaces[meta.Namespace] = true
+	}
+	for _, ns := range inventory.namespaces {
+		if isSystemNamespace(ns.Name) || ns.Name == "default" || ns.Status.Phase == corev1.NamespaceTerminating || usedNamespaces[ns.Name] {
+			continue
+		}
+		add("Empty Namespace", "Namespace", ns.ObjectMeta, "No pods, workloads, services or volume claims")
+	}
+
+	for _, sa := range inventory.serviceAccounts {
+		if isSystemNamespace(sa.Namespace) || sa.Name == "default" || serviceAccountRefs[sa.Namespace+"/"+sa.Name] {
+			continue
+		}
+		add("Unused ServiceAccount", "ServiceAccount", sa.ObjectMeta, "Not used by any pod or pod template")
+	}
+
+	sort.SliceStable(findings, func(i, j int) bool {
+		if findings[i].Category != findings[j].Category {
+			return findings[i].Category < findings[j].Category
+		}
+		return findings[i].Namespace+"/"+findings[i].Name < findings[j].Namespace+"/"+findings[j].Name
+	})
+
+	report := &CleanupReport{Findings: findings, Counts: make(map[string]int)}
+	for _, finding := range findings {
+		report.Counts[finding.Category]++
+		report.MonthlyCost += finding.MonthlyCost
+	}
+
+	return report
+}
+
+func collectPodSpecReferences(namespace string, spec corev1.PodSpec, configMaps, secrets map[string]bool) {
+	for _, volume := range spec.Volumes {
+		if volume.ConfigMap != nil {
+			configMaps[namespace+"/"+volume.ConfigMap.Name] = true
+		}
+		if volume.Secret != nil {
+			secrets[namespace+"/"+volume.Secret.SecretName] = true
+		}
+		if volume.Projected != nil {
+			for _, source := range volume.Projected.Sources {
+				if source.ConfigMap != nil {
+					configMaps[namespace+"/"+source.ConfigMap.Name] = true
+				}
+				if source.Secret != nil {
+					secrets[namespace+"/"+source.Secret.Name] = true
+				}
+			}
+		}
+	}
+
+	for _, ref := range spec.ImagePullSecrets {
+		secrets[namespace+"/"+ref.Name] = true
+	}
+
+	containers := append(append([]corev1.Container{}, spec.InitContainers...), spec.Containers...)
+	for _, container := range containers {
+		for _, source := range container.EnvFrom {
+			if source.ConfigMapRef != nil {
+				configMaps[namespace+"/"+source.ConfigMapRef.Name] = true
+			}
+			if source.SecretRef != nil {
+				secrets[namespace+"/"+source.SecretRef.Name] = true
+			}
+		}
+		for _, env := range container.Env {
+			if env.ValueFrom == nil {
+				continue
+			}
+			if env.ValueFrom.ConfigMapKeyRef != nil {
+				configMaps[namespace+"/"+env.ValueFrom.ConfigMapKeyRef.Name] = true
+			}
+			if env.ValueFrom.SecretKeyRef != nil {
+				secrets[namespace+"/"+env.ValueFrom.SecretKeyRef.Name] = true
+			}
+		}
+	}
+}
+
+// inventoryObjectMetas returns the objects that make a namespace non-empty.
+func inventoryObjectMetas(inventory *cleanupInventory) []metav1.ObjectMeta {
+	var metas []metav1.ObjectMeta
+	for _, pod := range inventory.pods {
+		metas = append(metas, pod.ObjectMeta)
+	}
+	for _, svc := range inventory.services {
+		metas = append(metas, svc.ObjectMeta)
+	}
+	for _, pvc := range inventory.pvcs {
+		metas = append(metas, pvc.ObjectMeta)
+	}
+	for _, deploy := range inventory.deployments {
+		metas = append(metas, deploy.ObjectMeta)
+	}
+	for _, ss := range inventory.statefulSets {
+		metas = append(metas, ss.ObjectMeta)
+	}
+	for _, ds := range inventory.daemonSets {
+		metas = append(metas, ds.ObjectMeta)
+	}
+	for _, job := range inventory.jobs {
+		metas = append(metas, job.ObjectMeta)
+	}
+	for _, cronJob := range inventory.cronJobs {
+		metas = append(metas, cronJob.ObjectMeta)
+	}
+	return metas
+}
+
+func replicaSetRevision(rs *appsv1.ReplicaSet) int {
+	revision, _ := strconv.Atoi(rs.Annotations[deploymentRevisionAnnotation])
+	return revision
+}
+
+func replicaSetOwnerName(rs *appsv1.ReplicaSet) string {
+	for _, owner := range rs.OwnerReferences {
+		if owner.Kind == "Deployment" {
+			return owner.Name
+		}
+	}
+	return ""
+}
+
+func sortedReplicaSetOwners(replicaSets map[string][]appsv1.ReplicaSet) []string {
+	owners := make([]string, 0, len(replicaSets))
+	for owner := range replicaSets {
+		owners = append(owners, owner)
+	}
+	sort.Strings(owners)
+	return owners
+}
+
+func isSystemNamespace(namespace string) bool {
+	return namespace == "kube-system" || namespace == "kube-public" || namespace == "kube-node-lease"
+}
@@ -0,0 +1,353 @@
+package kubernetes
+
+import (
+	_ "embed"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+//go:embed component_catalog.json
+var embeddedComponentCatalog []byte
+
+type ComponentCatalog struct {
+	Updated    string             `json:"updated"`
+	Components []CatalogComponent `json:"components"`
+}
+
+type CatalogComponent struct {
+	Name                string            `json:"name"`
+	Charts              []string          `json:"charts"`
+	Images              []string          `json:"images"`
+	LatestVersion       string            `json:"latestVersion"`
+	MinSupportedVersion string            `json:"minSupportedVersion"`
+	Releases            []CatalogRelease  `json:"releases"`
+	Advisories          []CatalogAdvisory `json:"advisories,omitempty"`
+}
+
+type CatalogRelease struct {
+	Version string `json:"version"`
+	EOL     string `json:"eol,omitempty"`
+	KubeMin string `json:"kubeMin,omitempty"`
+	KubeMax string `json:"kubeMax,omitempty"`
+}
+
+type CatalogAdvisory struct {
+	ID          string   `json:"id"`
+	Severity    string   `json:"severity"`
+	FixedIn     []string `json:"fixedIn"`
+	Description string   `json:"description"`
+}
+
+type ComponentVersionStatus struct {
+	Name             string
+	Namespace        string
+	CatalogName      string
+	InstalledVersion string
+	LatestVersion    string
+	EOL              string
+	Status           string
+	Severity         string
+	Issues           []string
+	Advisories       []string
+}
+
+// DefaultComponentCatalogPath is checked for a local catalog override when no
+// explicit path is given.
+func DefaultComponentCatalogPath() string {
+	return filepath.Join(homeDir(), ".k8s-cli", "component-catalog.json")
+}
+
+// LoadComponentCatalog returns the embedded catalog, with entries from the
+// given local file (or the default path, if present) overriding by name.
+func LoadComponentCatalog(path string) (*ComponentCatalog, error) {
+	var catalog ComponentCatalog
+	if err := json.Unmarshal(embeddedComponentCatalog, &catalog); err != nil {
+		return nil, fmt.Errorf("failed to parse embedded component catalog: %w", err)
+	}
+
+	explicit := path != ""
+	if !explicit {
+		path = DefaultComponentCatalogPath()
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if explicit || !os.IsNotExist(err) {
+			return nil, fmt.Errorf("failed to read component catalog %s: %w", path, err)
+		}
+		return &catalog, nil
+	}
+
+	var local ComponentCatalog
+	if err := json.Unmarshal(data, &local); err != nil {
+		return nil, fmt.Errorf("failed to parse component catalog %s: %w", path, err)
+	}
+
+	catalog.merge(&local)
+	return &catalog, nil
+}
+
+func (catalog *ComponentCatalog) merge(local *ComponentCatalog) {
+	index := make(map[string]int)
+	for i, comp := range catalog.Components {
+		index[comp.Name] = i
+	}
+
+	for _, comp := range local.Components {
+		if i, exists := index[comp.Name]; exists {
+			catalog.Components[i] = comp
+		} else {
+			catalog.Components = append(catalog.Components, comp)
+		}
+	}
+
+	if local.Updated != "" {
+		catalog.Updated = local.Updated
+	}
+}
+
+func (catalog *ComponentCatalog) lookup(comp *ComponentInfo) *CatalogComponent {
+	for i := range catalog.Components {
+		entry := &catalog.Components[i]
+
+		if comp.Chart != "" {
+			for _, chart := range entry.Charts {
+				if comp.Chart == chart {
+					return entry
+				}
+			}
+			continue
+		}
+
+		if comp.Image == "" {
+			continue
+		}
+		repository := imageRepository(comp.Image)
+		for _, image := range entry.Images {
+			if repository == image || strings.HasSuffix(repository, "/"+image) {
+				return entry
+			}
+		}
+	}
+	return nil
+}
+
+func (c *Client) GetComponentVersionStatus(catalog *ComponentCatalog) ([]ComponentVersionStatus, error) {
+	components, err := c.GetInstalledComponents()
+	if err != nil {
+		return nil, err
+	}
+
+	clusterInfo, err := c.GetClusterVersion()
+	if err != nil {
+		return nil, err
+	}
+
+	kubeVersion := fmt.Sprintf("%s.%s", clusterInfo.Major, strings.TrimSuffix(clusterInfo.Minor, "+"))
+
+	return EvaluateComponentVersions(components, catalog, kubeVersion, time.Now()), nil
+}
+
+// EvaluateComponentVersions compares installed components against the catalog.
+// Components without a catalog entry or a parseable version are skipped.
+func EvaluateComponentVersions(components []ComponentInfo, catalog *ComponentCatalog, kubeVersion string, now time.Time) []ComponentVersionStatus {
+	var results []ComponentVersionStatus
+
+	for i := range components {
+		comp := &components[i]
+		entry := catalog.lookup(comp)
+		if entry == nil {
+			continue
+		}
+
+		// The catalog tracks app versions; a chart version (ingress-nginx 4.x
+		// ships controller 1.x) cannot be compared against it
+		if comp.Chart != "" && comp.AppVersion == "" {
+			continue
+		}
+
+		installed, ok := parseSemver(comp.Version)
+		if !ok {
+			continue
+		}
+
+		status := ComponentVersionStatus{
+			Name:             comp.Name,
+			Namespace:        comp.Namespace,
+			CatalogName:      entry.Name,
+			InstalledVersion: comp.Version,
+			LatestVersion:    entry.LatestVersion,
+			Status:           "Up to date",
+			Severity:         "None",
+		}
+
+		raise := func(newStatus, severity string) {
+			if severityRank(severity) > severityRank(status.Severity) {
+				status.Status = newStatus
+				status.Severity = severity
+			}
+		}
+
+		if latest, ok := parseSemver(entry.LatestVersion); ok && compareSemver(installed, latest) < 0 {
+			status.Issues = append(status.Issues, fmt.Sprintf("Newer version %s available", entry.LatestVersion))
+			raise("Outdated", "Low")
+		}
+
+		for _, advisory := range entry.Advisories {
+			if advisory.affects(installed) {
+				status.Advisories = append(status.Advisories, advisory.ID)
+				status.Issues = append(status.Issues, fmt.Sprintf("%s: %s", advisory.ID, advisory.Description))
+				raise("Vulnerable", "High")
+			}
+		}
+
+		if release := entry.findRelease(installed); release != nil {
+			status.EOL = release.EOL
+			if release.EOL != "" {
+				if eol, err := time.Parse("2006-01-02", release.EOL); err == nil && now.After(eol) {
+					status.Issues = append(status.Issues, fmt.Sprintf("Release line %s reached end of life on %s", release.Version, release.EOL))
+					raise("EOL", "Medium")
+				}
+			}
+
+			if !kubeVersionInRange(kubeVersion, release.KubeMin, release.KubeMax) {
+				status.Issues = append(status.Issues, fmt.Sprintf("Not compatible with Kubernetes %s (supports %s)", kubeVersion, formatKubeRange(release.KubeMin, release.KubeMax)))
+				raise("Incompatible", "High")
+			}
+		}
+
+		if minSupported, ok := parseSemver(entry.MinSupportedVersion); ok && compareSemver(installed, minSupported) < 0 {
+			status.Issues = append(status.Issues, fmt.Sprintf("Below minimum supported version %s", entry.MinSupportedVersion))
+			raise("Unsupported", "Medium")
+		}
+
+		results = append(results, status)
+	}
+
+	sort.SliceStable(results, func(i, j int) bool {
+		return severityRank(results[i].Severity) > severityRank(results[j].Severity)
+	})
+
+	return results
+}
+
+func (entry *CatalogComponent) findRelease(version []int) *CatalogRelease {
+	for i := range entry.Releases {
+		line, ok := parseSemver(entry.Releases[i].Version)
+		if !ok {
+			continue
+		}
+		if line[0] == version[0] && line[1] == version[1] {
+			return &entry.Releases[i]
+		}
+	}
+	return nil
+}
+
+// affects reports whether version is vulnerable: a fix on the same minor line
+// must be at or below version, and lines older than every fix are affected.
+func (advisory *CatalogAdvisory) affects(version []int) bool {
+	var oldestFix []int
+	for _, fixed := range advisory.FixedIn {
+		fix, ok := parseSemver(fixed)
+		if !ok {
+			continue
+		}
+		if fix[0] == version[0] && fix[1] == version[1] {
+			return compareSemver(version, fix) < 0
+		}
+		if oldestFix == nil || compareSemver(fix, oldestFix) < 0 {
+			oldestFix = fix
+		}
+	}
+	return oldestFix != nil && compareSemver(version, oldestFix) < 0
+}
+
+func kubeVersionInRange(kubeVersion, min, max string) bool {
+	current, ok := parseSemver(kubeVersion)
+	if !ok {
+		return true
+	}
+	if lower, ok := parseSemver(min); ok && compareSemver(current, lower) < 0 {
+		return false
+	}
+	if upper, ok := parseSemver(max); ok && compareSemver(current[:2], upper[:2]) > 0 {
+		return false
+	}
+	return true
+}
+
+func formatKubeRange(min, max string) string {
+	switch {
+	case min != "" && max != "":
+		return fmt.Sprintf("%s-%s", min, max)
+	case min != "":
+		return min + "+"
+	case max != "":
+		return "up to " + max
+	default:
+		return "any"
+	}
+}
+
+// parseSemver parses versions such as "v1.13.2", "1.9" or "1.25.3-alpine"
+// into [major, minor, patch].
+func parseSemver(version string) ([]int, bool) {
+	version = strings.TrimPrefix(strings.TrimSpace(version), "v")
+	if i := strings.IndexAny(version, "-+@_"); i >= 0 {
+		version = version[:i]
+	}
+
+	parts := strings.Split(version, ".")
+	if len(parts) < 2 || len(parts) > 3 {
+		return nil, false
+	}
+
+	result := []int{0, 0, 0}
+	for i, part := range parts {
+		n, err := strconv.Atoi(part)
+		if err != nil {
+			return nil, false
+		}
+		result[i] = n
+	}
+
+	return result, true
+}
+
+func compareSemver(a, b []int) int {
+	for i := 0; i < len(a) && i < len(b); i++ {
+		if a[i] != b[i] {
+			if a[i] < b[i] {
+				return -1
+			}
+			return 1
+		}
+	}
+	return 0
+}
+
+func severityRank(severity string) int {
+	switch severity {
+	case "High":
+		return 3
+	case "Medium":
+		return 2
+	case "Low":
+		return 1
+	default:
+		return 0
+	}
+}
+
+// imageRepository returns the fully qualified repository of an image (for
+// example docker.io/library/traefik), so catalog image names match by suffix.
+func imageRepository(image string) string {
+	return parseImageReference(image).Name()
+}
@@ -0,0 +1,213 @@
+package kubernetes
+
+import (
+	"fmt"
+	"sort"
+
+	corev1 "k8s.io/api/core/v1"
+	policyv1 "k8s.io/api/policy/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+type ConsolidationPlan struct {
+	RemovableNodes []NodeConsolidation
+	BlockedNodes   []NodeConsolidation
+	MonthlySavings float64
+}
+
+type NodeConsolidation struct {
+	Node        string
+	Type        string
+	MonthlyCost float64
+	Moves       []DrainPlacement
+	Blockers    []string
+}
+
+const safeToEvictAnnotation = "cluster-autoscaler.kubernetes.io/safe-to-evict"
+
+// GetConsolidationPlan finds nodes that can be removed by repacking their
+// pods onto the remaining nodes, least utilized nodes first.
+func (c *Client) GetConsolidationPlan() (*ConsolidationPlan, error) {
+	nodes, err := c.Clientset.CoreV1().Nodes().List(c.Context, metav1.ListOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get nodes: %w", err)
+	}
+
+	pods, err := c.Clientset.CoreV1().Pods("").List(c.Context, metav1.ListOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get pods: %w", err)
+	}
+
+	pdbs, err := c.Clientset.PolicyV1().PodDisruptionBudgets("").List(c.Context, metav1.ListOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get pod disruption budgets: %w", err)
+	}
+
+	volumes := make(map[string][]volumeConstraint)
+	for i := range pods.Items {
+		pod := &pods.Items[i]
+		if pod.Spec.NodeName != "" && isPodActive(pod) && len(pod.Spec.Volumes) > 0 {
+			volumes[pod.Namespace+"/"+pod.Name] = c.getVolumeConstraints(pod)
+		}
+	}
+
+	nodeCosts := make(map[string]float64)
+	nodeTypes := make(map[string]string)
+	for i := range nodes.Items {
+		nodeTypes[nodes.Items[i].Name] = c.extractNodeType(&nodes.Items[i])
+		nodeCosts[nodes.Items[i].Name] = c.getNodeCost(nodeTypes[nodes.Items[i].Name])
+	}
+
+	plan := planConsolidation(nodes.Items, pods.Items, pdbs.Items, volumes, nodeCosts)
+	for i := range plan.RemovableNodes {
+		plan.RemovableNodes[i].Type = nodeTypes[plan.RemovableNodes[i].Node]
+	}
+	for i := range plan.BlockedNodes {
+		plan.BlockedNodes[i].Type = nodeTypes[plan.BlockedNodes[i].Node]
+	}
+
+	return &plan, nil
+}
+
+func planConsolidation(nodes []corev1.Node, pods []corev1.Pod, pdbs []policyv1.PodDisruptionBudget, volumes map[string][]volumeConstraint, nodeCosts map[string]float64) ConsolidationPlan {
+	var plan ConsolidationPlan
+
+	// Try the least requested nodes first: they are the cheapest to empty
+	allocations := calculateNodeAllocations(nodes, pods)
+	var candidates []corev1.Node
+	for _, node := range nodes {
+		if node.Spec.Unschedulable || getNodeStatus(&node) != "Ready" || isControlPlaneNode(&node) {
+			continue
+		}
+		candidates = append(candidates, node)
+	}
+	requestedShare := func(node *corev1.Node) float64 {
+		allocatable := node.Status.Allocatable.Cpu().MilliValue()
+		if allocatable == 0 {
+			return 1
+		}
+		return 1 - float64(allocations[node.Name].freeCPU)/float64(allocatable)
+	}
+	sort.SliceStable(candidates, func(i, j int) bool {
+		return requestedShare(&candidates[i]) < requestedShare(&candidates[j])
+	})
+
+	simulator := newPlacementSimulator(nodes, pods)
+
+	for _, node := range candidates {
+		if len(simulator.nodes) <= 1 {
+			break
+		}
+
+		consolidation := NodeConsolidation{Node: node.Name, MonthlyCost: nodeCosts[node.Name]}
+
+		var evicted []corev1.Pod
+		for _, pod := range simulator.podsByNode[node.Name] {
+			if isDaemonSetPod(&pod) || isMirrorPod(&pod) {
+				continue
+			}
+			consolidation.Blockers = append(consolidation.Blockers, evictionBlockers(&pod, pdbs)...)
+			evicted = append(evicted, pod)
+		}
+
+		if len(consolidation.Blockers) > 0 {
+			plan.BlockedNodes = append(plan.BlockedNodes, consolidation)
+			continue
+		}
+
+		trial := simulator.clone()
+		trial.removeNode(node.Name)
+
+		sort.SliceStable(evicted, func(i, j int) bool {
+			cpuI, _ := getPodResourceRequests(&evicted[i])
+			cpuJ, _ := getPodResourceRequests(&evicted[j])
+			return cpuI > cpuJ
+		})
+
+		for _, pod := range evicted {
+			candidate := *pod.DeepCopy()
+			candidate.Spec.NodeName = ""
+
+			target, rejected := trial.bestNode(&candidate, volumes[pod.Namespace+"/"+pod.Name])
+			if target == "" {
+				for _, reason := range summarizeRejections(rejected, len(trial.nodes)) {
+					consolidation.Blockers = append(consolidation.Blockers, fmt.Sprintf("%s/%s does not fit: %s", pod.Namespace, pod.Name, reason))
+				}
+				break
+			}
+
+			trial.place(candidate, target)
+			consolidation.Moves = append(consolidation.Moves, DrainPlacement{
+				Pod:        pod.Name,
+				Namespace:  pod.Namespace,
+				Workload:   getPodWorkload(&pod),
+				TargetNode: target,
+			})
+		}
+
+		if len(consolidation.Blockers) > 0 {
+			consolidation.Moves = nil
+			plan.BlockedNodes = append(plan.BlockedNodes, consolidation)
+			continue
+		}
+
+		simulator = trial
+		plan.RemovableNodes = append(plan.RemovableNodes, consolidation)
+		plan.MonthlySavings += consolidation.MonthlyCost
+	}
+
+	return plan
+}
+
+// evictionBlockers lists why a pod cannot be evicted safely: no controller
+// to recreate it, local storage, an opt-out annotation or an exhausted PDB.
+func evictionBlockers(pod *corev1.Pod, pdbs []policyv1.PodDisruptionBudget) []string {
+	var blockers []string
+	name := pod.Namespace + "/" + pod.Name
+
+	if pod.Annotations[safeToEvictAnnotation] == "false" {
+		blockers = append(blockers, fmt.Sprintf("%s is annotated %s=false", name, safeToEvictAnnotation))
+	}
+	if getPodOwner(pod) == "<none>" {
+		blockers = append(blockers, fmt.Sprintf("%s is not managed by a controller", name))
+	}
+
+	if pod.Annotations[safeToEvictAnnotation] != "true" {
+		for _, volume := range pod.Spec.Volumes {
+			if volume.HostPath != nil || (volume.EmptyDir != nil && volume.EmptyDir.Medium != corev1.StorageMediumMemory) {
+				blockers = append(blockers, fmt.Sprintf("%s uses local storage (volume %s)", name, volume.Name))
+				break
+			}
+		}
+	}
+
+	for _, pdb := range matchingPDBs(pdbs, pod.Namespace, pod.Labels) {
+		if pdb.Status.DisruptionsAllowed > 0 {
+			continue
+		}
+		blockers = append(blockers, fmt.Sprintf("%s is protected by PodDisruptionBudget %s (0 disruptions allowed)", name, pdb.Name))
+	}
+
+	return blockers
+}
+
+func isControlPlaneNode(node *corev1.Node) bool {
+	_, controlPlane := node.Labels["node-role.kubernetes.io/control-plane"]
+	_, master := node.Labels["node-role.kubernetes.io/master"]
+	return controlPlane || master
+}
+
+func (s *placementSimulator) clone() *placementSimulator {
+	clone := &placementSimulator{
+		nodes:       append([]corev1.Node(nil), s.nodes...),
+		allocations: make(map[string]nodeAllocation, len(s.allocations)),
+		podsByNode:  make(map[string][]corev1.Pod, len(s.podsByNode)),
+	}
+	for name, allocation := range s.allocations {
+		clone.allocations[name] = allocation
+	}
+	for name, pods := range s.podsByNode {
+		clone.podsByNode[name] = append([]corev1.Pod(nil), pods...)
+	}
+	return clone
+}
@@ -0,0 +1,365 @@
+package kubernetes
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+	"regexp"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/yaml"
+)
+
+type LogRule struct {
+	Name     string `json:"name"`
+	Severity string `json:"severity"`
+	Pattern  string `json:"pattern"`
+	regex    *regexp.Regexp
+}
+
+type ContainerLogOptions struct {
+	Namespace   string
+	TailLines   int64
+	Since       time.Duration
+	Previous    bool
+	Concurrency int
+	MaxSamples  int
+	Rules       []LogRule
+}
+
+type ContainerLogAnalysis struct {
+	Containers        []ContainerLogSummary
+	Patterns          []LogPattern
+	ContainersScanned int
+	LinesScanned      int
+	Errors            []string
+}
+
+type ContainerLogSummary struct {
+	Pod           string
+	Namespace     string
+	Container     string
+	Previous      bool
+	Lines         int
+	CriticalCount int
+	WarningCount  int
+	Rules         map[string]int
+}
+
+type LogPattern struct {
+	Rule      string
+	Severity  string
+	Signature string
+	Count     int
+	Pods      []string
+	Samples   []LogSample
+}
+
+type LogSample struct {
+	Pod       string
+	Namespace string
+	Container string
+	Line      string
+}
+
+type logMatch struct {
+	rule      *LogRule
+	signature string
+	line      string
+}
+
+// DefaultLogRules are evaluated in order; the first matching rule classifies a line.
+func DefaultLogRules() []LogRule {
+	return []LogRule{
+		{Name: "panic", Severity: "Critical", Pattern: `panic:|fatal error:|SIGSEGV|segmentation fault`},
+		{Name: "oom", Severity: "Critical", Pattern: `(?i)out of memory|OOMKilled|java\.lang\.OutOfMemoryError|cannot allocate memory`},
+		{Name: "stack-trace", Severity: "Critical", Pattern: `^Traceback \(most recent call last\)|^goroutine \d+ \[|Exception in thread|^[\w.$]+(Exception|Error): `},
+		{Name: "connection-refused", Severity: "Warning", Pattern: `(?i)connection refused|ECONNREFUSED|connection reset by peer|no route to host`},
+		{Name: "timeout", Severity: "Warning", Pattern: `(?i)timed out|deadline exceeded|i/o timeout`},
+		{Name: "error", Severity: "Warning", Pattern: `(?i)\blevel=error\b|"level":"error"|\b(ERROR|FATAL)\b`},
+	}
+}
+
+// LoadLogRules reads classification rules from a YAML or JSON list. Rules from
+// the file are evaluated before the defaults.
+func LoadLogRules(path string) ([]LogRule, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read log rules %s: %w", path, err)
+	}
+
+	var rules []LogRule
+	if err := yaml.Unmarshal(data, &rules); err != nil {
+		return nil, fmt.Errorf("failed to parse log rules %s: %w", path, err)
+	}
+
+	return append(rules, DefaultLogRules()...), nil
+}
+
+func compileLogRules(rules []LogRule) ([]LogRule, error) {
+	compiled := make([]LogRule, 0, len(rules))
+	for _, rule := range rules {
+		regex, err := regexp.Compile(rule.Pattern)
+		if err != nil {
+			return nil, fmt.Errorf("invalid pattern for rule %s: %w", rule.Name, err)
+		}
+		rule.regex = regex
+		if rule.Severity == "" {
+			rule.Severity = "Warning"
+		}
+		compiled = append(compiled, rule)
+	}
+	return compiled, nil
+}
+
+// GetContainerLogAnalysis reads container logs (or the previous instance's
+// logs for restarted containers) and clusters classified lines into patterns.
+func (c *Client) GetContainerLogAnalysis(options ContainerLogOptions) (*ContainerLogAnalysis, error) {
+	if len(options.Rules) == 0 {
+		options.Rules = DefaultLogRules()
+	}
+	rules, err := compileLogRules(options.Rules)
+	if err != nil {
+		return nil, err
+	}
+	if options.Concurrency <= 0 {
+		options.Concurrency = 5
+	}
+	if options.MaxSamples <= 0 {
+		options.MaxSamples = 3
+	}
+
+	pods, err := c.Clientset.CoreV1().Pods(options.Namespace).List(c.Context, metav1.ListOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get pods: %w", err)
+	}
+
+	type target struct {
+		pod       *corev1.Pod
+		container string
+	}
+
+	var targets []target
+	for i := range pods.Items {
+		pod := &pods.Items[i]
+		for _, status := range pod.Status.ContainerStatuses {
+			if options.Previous && status.RestartCount == 0 {
+				continue
+			}
+			if !options.Previous && status.State.Running == nil && status.State.Terminated == nil {
+				continue
+			}
+			targets = append(targets, target{pod, status.Name})
+		}
+	}
+
+	aggregator := newLogPatternAggregator(options.MaxSamples)
+	analysis := &ContainerLogAnalysis{}
+
+	var mu sync.Mutex
+	var wg sync.WaitGroup
+	semaphore := make(chan struct{}, options.Concurrency)
+
+	for _, t := range targets {
+		wg.Add(1)
+		semaphore <- struct{}{}
+
+		go func(t target) {
+			defer wg.Done()
+			defer func() { <-semaphore }()
+
+			summary, matches, err := c.scanContainerLogs(t.pod, t.container, options, rules)
+
+			mu.Lock()
+			defer mu.Unlock()
+
+			if err != nil {
+				analysis.Errors = append(analysis.Errors, fmt.Sprintf("%s/%s/%s: %v", t.pod.Namespace, t.pod.Name, t.container, err))
+				return
+			}
+
+			analysis.ContainersScanned++
+			analysis.LinesScanned += summary.Lines
+			if summary.CriticalCount > 0 || summary.WarningCount > 0 {
+				analysis.Containers = append(analysis.Containers, summary)
+			}
+			for _, match := range matches {
+				aggregator.add(match, summary)
+			}
+		}(t)
+	}
+	wg.Wait()
+
+	sort.Slice(analysis.Containers, func(i, j int) bool {
+		a, b := analysis.Containers[i], analysis.Containers[j]
+		if a.CriticalCount != b.CriticalCount {
+			return a.CriticalCount > b.CriticalCount
+		}
+		return a.WarningCount > b.WarningCount
+	})
+	sort.Strings(analysis.Errors)
+	analysis.Patterns = aggregator.patterns()
+
+	return analysis, nil
+}
+
+func (c *Client) scanContainerLogs(pod *corev1.Pod, container string, options ContainerLogOptions, rules []LogRule) (ContainerLogSummary, []logMatch, error) {
+	logOptions := &corev1.PodLogOptions{
+		Container: container,
+		Previous:  options.Previous,
+	}
+	if options.TailLines > 0 {
+		logOptions.TailLines = &options.TailLines
+	}
+	if options.Since > 0 {
+		seconds := int64(options.Since.Seconds())
+		logOptions.SinceSeconds = &seconds
+	}
+
+	stream, err := c.Clientset.CoreV1().Pods(pod.Namespace).GetLogs(pod.Name, logOptions).Stream(c.Context)
+	if err != nil {
+		return ContainerLogSummary{}, nil, fmt.Errorf("failed to stream logs: %w", err)
+	}
+	defer stream.Close()
+
+	summary, matches, err := classifyLogLines(stream, rules)
+	summary.Pod = pod.Name
+	summary.Namespace = pod.Namespace
+	summary.Container = container
+	summary.Previous = options.Previous
+
+	return summary, matches, err
+}
+
+func classifyLogLines(r io.Reader, rules []LogRule) (ContainerLogSummary, []logMatch, error) {
+	summary := ContainerLogSummary{Rules: make(map[string]int)}
+	var matches []logMatch
+
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 64*1024), 1024*1024)
+
+	for scanner.Scan() {
+		line := scanner.Text()
+		summary.Lines++
+
+		for i := range rules {
+			rule := &rules[i]
+			if !rule.regex.MatchString(line) {
+				continue
+			}
+
+			summary.Rules[rule.Name]++
+			if rule.Severity == "Critical" {
+				summary.CriticalCount++
+			} else {
+				summary.WarningCount++
+			}
+			matches = append(matches, logMatch{rule: rule, signature: logSignature(line), line: line})
+			break
+		}
+	}
+
+	if err := scanner.Err(); err != nil {
+		return summary, matches, fmt.Errorf("failed to read logs: %w", err)
+	}
+
+	return summary, matches, nil
+}
+
+var logSignatureReplacements = []struct {
+	regex       *regexp.Regexp
+	replacement string
+}{
+	{regexp.MustCompile(`^\S*\d{4}-\d{2}-\d{2}[T ]\d{2}:\d{2}:\d{2}(\.\d+)?(Z|[+-]\d{2}:?\d{2})?\S*\s*`), ""},
+	{regexp.MustCompile(`[0-9a-fA-F]{8}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{12}`), "<uuid>"},
+	{regexp.MustCompile(`\b\d{1,3}(\.\d{1,3}){3}(:\d+)?\b`), "<ip>"},
+	{regexp.MustCompile(`0x[0-9a-fA-F]+|\b[0-9a-f]{12,}\b`), "<hex>"},
+	{regexp.MustCompile(`"[^"]*"`), `"<str>"`},
+	{regexp.MustCompile(`\d+`), "<n>"},
+}
+
+// logSignature normalizes variable parts of a log line (timestamps, IDs,
+// addresses, numbers) so that occurrences of the same message cluster together.
+func logSignature(line string) string {
+	signature := strings.TrimSpace(line)
+	for _, r := range logSignatureReplacements {
+		signature = r.regex.ReplaceAllString(signature, r.replacement)
+	}
+	if runes := []rune(signature); len(runes) > 160 {
+		signature = string(runes[:157]) + "..."
+	}
+	return signature
+}
+
+type logPatternAggregator struct {
+	maxSamples int
+	patternMap map[string]*LogPattern
+	podSamples map[string]map[string]bool
+	podSets    map[string]map[string]bool
+}
+
+func newLogPatternAggregator(maxSamples int) *logPatternAggregator {
+	return &logPatternAggregator{
+		maxSamples: maxSamples,
+		patternMap: make(map[string]*LogPattern),
+		podSamples: make(map[string]map[string]bool),
+		podSets:    make(map[string]map[string]bool),
+	}
+}
+
+// add records a match, keeping at most one sample line per pod and
+// maxSamples samples per pattern.
+func (a *logPatternAggregator) add(match logMatch, summary ContainerLogSummary) {
+	key := match.rule.Name + "|" + match.signature
+	pattern, exists := a.patternMap[key]
+	if !exists {
+		pattern = &LogPattern{
+			Rule:      match.rule.Name,
+			Severity:  match.rule.Severity,
+			Signature: match.signature,
+		}
+		a.patternMap[key] = pattern
+		a.podSamples[key] = make(map[string]bool)
+		a.podSets[key] = make(map[string]bool)
+	}
+
+	pattern.Count++
+	pod := summary.Namespace + "/" + summary.Pod
+	a.podSets[key][pod] = true
+
+	if !a.podSamples[key][pod] && len(pattern.Samples) < a.maxSamples {
+		a.podSamples[key][pod] = true
+		pattern.Samples = append(pattern.Samples, LogSample{
+			Pod:       summary.Pod,
+			Namespace: summary.Namespace,
+			Container: summary.Container,
+			Line:      match.line,
+		})
+	}
+}
+
+func (a *logPatternAggregator) patterns() []LogPattern {
+	result := make([]LogPattern, 0, len(a.patternMap))
+	for key, pattern := range a.patternMap {
+		pattern.Pods = sortedKeys(a.podSets[key])
+		result = append(result, *pattern)
+	}
+
+	sort.Slice(result, func(i, j int) bool {
+		if result[i].Severity != result[j].Severity {
+			return result[i].Severity == "Critical"
+		}
+		if result[i].Count != result[j].Count {
+			return result[i].Count > result[j].Count
+		}
+		return result[i].Signature < result[j].Signature
+	})
+
+	return result
+}
@@ -3,6 +3,7 @@ package kubernetes
 import (
 	"fmt"
 	"sort"
+	"strings"
 
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -14,6 +15,8 @@ type CostAnalysis struct {
 	NamespaceCosts         []NamespaceCost
 	UnderutilizedResources []UnderutilizedResource
 	CostOptimizations      []CostOptimization
+	Consolidation          *ConsolidationPlan
+	UnusedResources        []CleanupFinding
 }
 
 type NodeCost struct {
@@ -91,7 +94,24 @@ func (c *Client) GetCostAnalysis() (*CostAnalysis, error) {
 		return nil, fmt.Errorf("failed to find underutilized resources: %w", err)
 	}
 
-	optimizations := c.generateCostOptimizations(nodeCosts, namespaceCosts, underutilized)
+	// Consolidation is best effort: without it the heuristic node advice is used
+	consolidation, _ := c.GetConsolidationPlan()
+
+	// Only the priceable part of the cleanup report (volumes, load balancers) feeds the cost
+	var unused []CleanupFinding
+	cleanup, _ := c.GetCleanupReport("")
+	if cleanup != nil {
+		for _, finding := range cleanup.Findings {
+			if finding.MonthlyCost > 0 {
+				unused = append(unused, finding)
+			}
+		}
+		sort.SliceStable(unused, func(i, j int) bool {
+			return unused[i].MonthlyCost > unused[j].MonthlyCost
+		})
+	}
+
+	optimizations := c.generateCostOptimizations(nodeCosts, namespaceCosts, underutilized, consolidation, cleanup)
 
 	totalCost := 0.0
 	for _, nc := range nodeCosts {
@@ -104,6 +124,8 @@ func (c *Client) GetCostAnalysis() (*CostAnalysis, error) {
 		NamespaceCosts:         namespaceCosts,
 		UnderutilizedResources: underutilized,
 		CostOptimizations:      optimizations,
+		Consolidation:          consolidation,
+		UnusedResources:        unused,
 	}, nil
 }
 
@@ -204,31 +226,45 @@ func (c *Client) findUnderutilizedResources() ([]UnderutilizedResource, error) {
 
 	var underutilized []UnderutilizedResource
 	for _, util := range utilizations {
-		if util.CPUUtilization < 20 || util.MemUtilization < 20 {
-			pod, err := c.Clientset.CoreV1().Pods(util.Namespace).Get(c.Context, util.Name, metav1.GetOptions{})
-			if err != nil {
-				continue
-			}
+		hasVPA := util.VPACPUTarget > 0 || util.VPAMemTarget > 0
+		if hasVPA && util.Status != UtilizationUnderutilized {
+			continue
+		}
+		if !hasVPA && util.CPUUtilization >= 20 && util.MemUtilization >= 20 {
+			continue
+		}
 
-			cpuReq, memReq := getPodResourceRequests(pod)
+		pod, err := c.Clientset.CoreV1().Pods(util.Namespace).Get(c.Context, util.Name, metav1.GetOptions{})
+		if err != nil {
+			continue
+		}
 
-			cpuWaste := int64(float64(cpuReq) * (100 - util.CPUUtilization) / 100)
-			memWaste := int64(float64(memReq) * (100 - util.MemUtilization) / 100)
+		cpuReq, memReq := getPodResourceRequests(pod)
 
-			estimatedSavings := c.estimateResourceSavings(cpuWaste, memWaste)
+		var cpuWaste, memWaste int64
+		var recommendation string
+		if hasVPA {
+			// Waste is whatever is requested above the VPA target
+			cpuWaste = max(cpuReq-util.VPACPUTarget, 0)
+			memWaste = max(memReq-util.VPAMemTarget, 0)
+			recommendation = util.Recommendation
+		} else {
+			cpuWaste = int64(float64(cpuReq) * (100 - util.CPUUtilization) / 100)
+			memWaste = int64(float64(memReq) * (100 - util.MemUtilization) / 100)
+			recommendation = c.generateRightsizingRecommendation(cpuReq, memReq, util.CPUUtilization, util.MemUtilization)
+		}
 
-			recommendation := c.generateRightsizingRecommendation(util.CPUUtilization, util.MemUtilization)
+		estimatedSavings := c.estimateResourceSavings(cpuWaste, memWaste)
 
-			underutilized = append(underutilized, UnderutilizedResource{
-				Type:             "Pod",
-				Name:             util.Name,
-				Namespace:        util.Namespace,
-				CPUWaste:         formatCPU(cpuWaste),
-				MemoryWaste:      formatBytes(memWaste),
-				EstimatedSavings: estimatedSavings,
-				Recommendation:   recommendation,
-			})
-		}
+		underutilized = append(underutilized, UnderutilizedResource{
+			Type:             "Pod",
+			Name:             util.Name,
+			Namespace:        util.Namespace,
+			CPUWaste:         formatCPU(cpuWaste),
+			MemoryWaste:      formatBytes(memWaste),
+			EstimatedSavings: estimatedSavings,
+			Recommendation:   recommendation,
+		})
 	}
 
 	sort.Slice(underutilized, func(i, j int) bool {
@@ -238,7 +274,7 @@ func (c *Client) findUnderutilizedResources() ([]UnderutilizedResource, error) {
 	return underutilized, nil
 }
 
-func (c *Client) generateCostOptimizations(nodeCosts []NodeCost, namespaceCosts []NamespaceCost, underutilized []UnderutilizedResource) []CostOptimization {
+func (c *Client) generateCostOptimizations(nodeCosts []NodeCost, namespaceCosts []NamespaceCost, underutilized []UnderutilizedResource, consolidation *ConsolidationPlan, cleanup *CleanupReport) []CostOptimization {
 	var optimizations []CostOptimization
 
 	totalWastedCost := 0.0
@@ -263,7 +299,21 @@ func (c *Client) generateCostOptimizations(nodeCosts []NodeCost, namespaceCosts
 		}
 	}
 
-	if inefficientNodes > 0 && len(nodeCosts) > 1 {
+	if consolidation != nil {
+		if len(consolidation.RemovableNodes) > 0 {
+			var names []string
+			for _, node := range consolidation.RemovableNodes {
+				names = append(names, node.Node)
+			}
+			optimizations = append(optimizations, CostOptimization{
+				Type:             "Node Consolidation",
+				Description:      fmt.Sprintf("Remove %d nodes (%s) by repacking their pods onto the remaining nodes", len(names), strings.Join(names, ", ")),
+				PotentialSavings: consolidation.MonthlySavings,
+				Priority:         "Medium",
+				Action:           "Drain and remove the nodes listed in the consolidation plan, then shrink the node group",
+			})
+		}
+	} else if inefficientNodes > 0 && len(nodeCosts) > 1 {
 		potentialSavings := 0.0
 		for _, node := range nodeCosts {
 			if node.CPUUtilization < 30 && node.MemUtilization < 30 {
@@ -280,6 +330,16 @@ func (c *Client) generateCostOptimizations(nodeCosts []NodeCost, namespaceCosts
 		})
 	}
 
+	if cleanup != nil && cleanup.MonthlyCost > 0 {
+		optimizations = append(optimizations, CostOptimization{
+			Type:             "Unused Resources",
+			Description:      fmt.Sprintf("Delete %d unmounted volumes and load balancers without endpoints", countPricedFindings(cleanup)),
+			PotentialSavings: cleanup.MonthlyCost,
+			Priority:         "Medium",
+			Action:           "Review 'k8s-cli cleanup-report' and delete what is no longer needed",
+		})
+	}
+
 	if len(namespaceCosts) > 0 {
 		highCostNamespaces := 0
 		for _, ns := range namespaceCosts {
@@ -360,12 +420,33 @@ func (c *Client) estimateResourceSavings(cpuWaste, memWaste int64) float64 {
 	return (cpuCores * cpuCostPerCore) + (memGB * memCostPerGB)
 }
 
-func (c *Client) generateRightsizingRecommendation(cpuUtil, memUtil float64) string {
-	if cpuUtil < 10 && memUtil < 10 {
-		return "Consider reducing requests by 50-70%"
-	} else if cpuUtil < 20 && memUtil < 20 {
-		return "Consider reducing requests by 30-50%"
-	} else {
-		return "Consider reducing requests by 10-30%"
+// generateRightsizingRecommendation sizes the pod's requests at its current
+// usage plus the default headroom, e.g. "cpu: 500m → 120m".
+func (c *Client) generateRightsizingRecommendation(cpuRequests, memRequests int64, cpuUtil, memUtil float64) string {
+	var changes []string
+
+	recommendedCPU := roundUpCPU(float64(cpuRequests) * cpuUtil / 100 * (1 + defaultRightsizingHeadroom))
+	if cpuRequests > 0 && significantChange(cpuRequests, recommendedCPU) {
+		changes = append(changes, "cpu: "+FormatResourceChange(cpuRequests, recommendedCPU, false))
+	}
+
+	recommendedMemory := roundUpMemory(float64(memRequests) * memUtil / 100 * (1 + defaultRightsizingHeadroom))
+	if memRequests > 0 && significantChange(memRequests, recommendedMemory) {
+		changes = append(changes, "memory: "+FormatResourceChange(memRequests, recommendedMemory, true))
+	}
+
+	if len(changes) == 0 {
+		return "Requests match usage"
+	}
+	return "Set requests " + strings.Join(changes, ", ")
+}
+
+func countPricedFindings(cleanup *CleanupReport) int {
+	count := 0
+	for _, finding := range cleanup.Findings {
+		if finding.MonthlyCost > 0 {
+			count++
+		}
 	}
+	return count
 }
@@ -0,0 +1,353 @@
+package kubernetes
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/util/yaml"
+)
+
+const lastAppliedConfigAnnotation = "kubectl.kubernetes.io/last-applied-configuration"
+
+type DeprecatedAPI struct {
+	APIVersion   string
+	Kind         string
+	Resource     string
+	DeprecatedIn string
+	RemovedIn    string
+	Replacement  string
+}
+
+type DeprecatedAPIFinding struct {
+	Kind         string
+	Name         string
+	Namespace    string
+	APIVersion   string
+	Replacement  string
+	DeprecatedIn string
+	RemovedIn    string
+	Source       string
+	Status       string
+	Severity     string
+}
+
+type UpgradeCheckResult struct {
+	CurrentVersion       string
+	TargetVersion        string
+	Findings             []DeprecatedAPIFinding
+	ServedDeprecatedAPIs []DeprecatedAPI
+	RemovedCount         int
+	DeprecatedCount      int
+}
+
+// deprecatedAPIs is the embedded deprecation table, based on the upstream
+// Kubernetes deprecated API migration guide.
+var deprecatedAPIs = []DeprecatedAPI{
+	{"extensions/v1beta1", "Deployment", "deployments", "1.9", "1.16", "apps/v1"},
+	{"extensions/v1beta1", "DaemonSet", "daemonsets", "1.9", "1.16", "apps/v1"},
+	{"extensions/v1beta1", "ReplicaSet", "replicasets", "1.9", "1.16", "apps/v1"},
+	{"extensions/v1beta1", "NetworkPolicy", "networkpolicies", "1.9", "1.16", "networking.k8s.io/v1"},
+	{"extensions/v1beta1", "PodSecurityPolicy", "podsecuritypolicies", "1.10", "1.16", "policy/v1beta1"},
+	{"apps/v1beta1", "Deployment", "deployments", "1.9", "1.16", "apps/v1"},
+	{"apps/v1beta1", "StatefulSet", "statefulsets", "1.9", "1.16", "apps/v1"},
+	{"apps/v1beta2", "Deployment", "deployments", "1.9", "1.16", "apps/v1"},
+	{"apps/v1beta2", "StatefulSet", "statefulsets", "1.9", "1.16", "apps/v1"},
+	{"apps/v1beta2", "DaemonSet", "daemonsets", "1.9", "1.16", "apps/v1"},
+	{"apps/v1beta2", "ReplicaSet", "replicasets", "1.9", "1.16", "apps/v1"},
+	{"extensions/v1beta1", "Ingress", "ingresses", "1.14", "1.22", "networking.k8s.io/v1"},
+	{"networking.k8s.io/v1beta1", "Ingress", "ingresses", "1.19", "1.22", "networking.k8s.io/v1"},
+	{"networking.k8s.io/v1beta1", "IngressClass", "ingressclasses", "1.19", "1.22", "networking.k8s.io/v1"},
+	{"apiextensions.k8s.io/v1beta1", "CustomResourceDefinition", "customresourcedefinitions", "1.16", "1.22", "apiextensions.k8s.io/v1"},
+	{"admissionregistration.k8s.io/v1beta1", "MutatingWebhookConfiguration", "mutatingwebhookconfigurations", "1.16", "1.22", "admissionregistration.k8s.io/v1"},
+	{"admissionregistration.k8s.io/v1beta1", "ValidatingWebhookConfiguration", "validatingwebhookconfigurations", "1.16", "1.22", "admissionregistration.k8s.io/v1"},
+	{"apiregistration.k8s.io/v1beta1", "APIService", "apiservices", "1.19", "1.22", "apiregistration.k8s.io/v1"},
+	{"certificates.k8s.io/v1beta1", "CertificateSigningRequest", "certificatesigningrequests", "1.19", "1.22", "certificates.k8s.io/v1"},
+	{"coordination.k8s.io/v1beta1", "Lease", "leases", "1.19", "1.22", "coordination.k8s.io/v1"},
+	{"rbac.authorization.k8s.io/v1beta1", "ClusterRole", "clusterroles", "1.17", "1.22", "rbac.authorization.k8s.io/v1"},
+	{"rbac.authorization.k8s.io/v1beta1", "ClusterRoleBinding", "clusterrolebindings", "1.17", "1.22", "rbac.authorization.k8s.io/v1"},
+	{"rbac.authorization.k8s.io/v1beta1", "Role", "roles", "1.17", "1.22", "rbac.authorization.k8s.io/v1"},
+	{"rbac.authorization.k8s.io/v1beta1", "RoleBinding", "rolebindings", "1.17", "1.22", "rbac.authorization.k8s.io/v1"},
+	{"scheduling.k8s.io/v1beta1", "PriorityClass", "priorityclasses", "1.14", "1.22", "scheduling.k8s.io/v1"},
+	{"storage.k8s.io/v1beta1", "CSIDriver", "csidrivers", "1.19", "1.22", "storage.k8s.io/v1"},
+	{"storage.k8s.io/v1beta1", "CSINode", "csinodes", "1.17", "1.22", "storage.k8s.io/v1"},
+	{"storage.k8s.io/v1beta1", "StorageClass", "storageclasses", "1.19", "1.22", "storage.k8s.io/v1"},
+	{"storage.k8s.io/v1beta1", "VolumeAttachment", "volumeattachments", "1.19", "1.22", "storage.k8s.io/v1"},
+	{"batch/v1beta1", "CronJob", "cronjobs", "1.21", "1.25", "batch/v1"},
+	{"discovery.k8s.io/v1beta1", "EndpointSlice", "endpointslices", "1.21", "1.25", "discovery.k8s.io/v1"},
+	{"events.k8s.io/v1beta1", "Event", "events", "1.22", "1.25", "events.k8s.io/v1"},
+	{"autoscaling/v2beta1", "HorizontalPodAutoscaler", "horizontalpodautoscalers", "1.22", "1.25", "autoscaling/v2"},
+	{"policy/v1beta1", "PodDisruptionBudget", "poddisruptionbudgets", "1.21", "1.25", "policy/v1"},
+	{"policy/v1beta1", "PodSecurityPolicy", "podsecuritypolicies", "1.21", "1.25", ""},
+	{"node.k8s.io/v1beta1", "RuntimeClass", "runtimeclasses", "1.22", "1.25", "node.k8s.io/v1"},
+	{"autoscaling/v2beta2", "HorizontalPodAutoscaler", "horizontalpodautoscalers", "1.23", "1.26", "autoscaling/v2"},
+	{"flowcontrol.apiserver.k8s.io/v1beta1", "FlowSchema", "flowschemas", "1.23", "1.26", "flowcontrol.apiserver.k8s.io/v1"},
+	{"flowcontrol.apiserver.k8s.io/v1beta1", "PriorityLevelConfiguration", "prioritylevelconfigurations", "1.23", "1.26", "flowcontrol.apiserver.k8s.io/v1"},
+	{"storage.k8s.io/v1beta1", "CSIStorageCapacity", "csistoragecapacities", "1.24", "1.27", "storage.k8s.io/v1"},
+	{"flowcontrol.apiserver.k8s.io/v1beta2", "FlowSchema", "flowschemas", "1.26", "1.29", "flowcontrol.apiserver.k8s.io/v1"},
+	{"flowcontrol.apiserver.k8s.io/v1beta2", "PriorityLevelConfiguration", "prioritylevelconfigurations", "1.26", "1.29", "flowcontrol.apiserver.k8s.io/v1"},
+	{"flowcontrol.apiserver.k8s.io/v1beta3", "FlowSchema", "flowschemas", "1.29", "1.32", "flowcontrol.apiserver.k8s.io/v1"},
+	{"flowcontrol.apiserver.k8s.io/v1beta3", "PriorityLevelConfiguration", "prioritylevelconfigurations", "1.29", "1.32", "flowcontrol.apiserver.k8s.io/v1"},
+}
+
+type manifestObject struct {
+	APIVersion string `json:"apiVersion"`
+	Kind       string `json:"kind"`
+	Metadata   struct {
+		Name      string `json:"name"`
+		Namespace string `json:"namespace"`
+	} `json:"metadata"`
+}
+
+func (c *Client) GetUpgradeCheck(targetVersion string) (*UpgradeCheckResult, error) {
+	clusterInfo, err := c.GetClusterVersion()
+	if err != nil {
+		return nil, err
+	}
+
+	currentMinor, _ := strconv.Atoi(strings.TrimSuffix(clusterInfo.Minor, "+"))
+	current := fmt.Sprintf("%s.%d", clusterInfo.Major, currentMinor)
+
+	if targetVersion == "" {
+		targetVersion = fmt.Sprintf("%s.%d", clusterInfo.Major, currentMinor+1)
+	}
+	if _, err := parseMinorVersion(targetVersion); err != nil {
+		return nil, err
+	}
+
+	result := &UpgradeCheckResult{
+		CurrentVersion: current,
+		TargetVersion:  targetVersion,
+	}
+
+	served := c.getServedGroupVersions()
+	for _, api := range deprecatedAPIs {
+		if served[api.APIVersion] {
+			result.ServedDeprecatedAPIs = append(result.ServedDeprecatedAPIs, api)
+		}
+	}
+
+	var findings []DeprecatedAPIFinding
+
+	liveFindings, err := c.scanLiveObjects(served, targetVersion)
+	if err != nil {
+		return nil, fmt.Errorf("failed to scan live objects: %w", err)
+	}
+	findings = append(findings, liveFindings...)
+
+	if releases, err := c.GetHelmReleases(""); err == nil {
+		for _, release := range releases {
+			source := fmt.Sprintf("helm:%s/%s", release.Namespace, release.Name)
+			manifestFindings, err := FindDeprecatedAPIsInManifests(strings.NewReader(release.Manifest), source, targetVersion)
+			if err != nil {
+				continue
+			}
+			for i := range manifestFindings {
+				if manifestFindings[i].Namespace == "" {
+					manifestFindings[i].Namespace = release.Namespace
+				}
+			}
+			findings = append(findings, manifestFindings...)
+		}
+	}
+
+	sort.Slice(findings, func(i, j int) bool {
+		if findings[i].Severity != findings[j].Severity {
+			return findings[i].Severity == "High"
+		}
+		return findings[i].Kind+findings[i].Namespace+findings[i].Name < findings[j].Kind+findings[j].Namespace+findings[j].Name
+	})
+
+	for _, finding := range findings {
+		if finding.Severity == "High" {
+			result.RemovedCount++
+		} else {
+			result.DeprecatedCount++
+		}
+	}
+	result.Findings = findings
+
+	return result, nil
+}
+
+func (c *Client) getServedGroupVersions() map[string]bool {
+	served := make(map[string]bool)
+
+	groups, err := c.Clientset.Discovery().ServerGroups()
+	if err != nil {
+		return served
+	}
+
+	for _, group := range groups.Groups {
+		for _, version := range group.Versions {
+			served[version.GroupVersion] = true
+		}
+	}
+
+	return served
+}
+
+// scanLiveObjects lists every kind in the deprecation table through the
+// dynamic client and inspects the apiVersion each object was last applied with.
+func (c *Client) scanLiveObjects(served map[string]bool, targetVersion string) ([]DeprecatedAPIFinding, error) {
+	var findings []DeprecatedAPIFinding
+	listed := make(map[schema.GroupVersionResource]bool)
+
+	for _, api := range deprecatedAPIs {
+		listVersion := api.Replacement
+		if listVersion == "" || !served[listVersion] {
+			listVersion = api.APIVersion
+		}
+		if !served[listVersion] {
+			continue
+		}
+
+		gv, err := schema.ParseGroupVersion(listVersion)
+		if err != nil {
+			continue
+		}
+		gvr := gv.WithResource(api.Resource)
+		if listed[gvr] {
+			continue
+		}
+		listed[gvr] = true
+
+		objects, err := c.DynamicClient.Resource(gvr).List(c.Context, metav1.ListOptions{})
+		if err != nil {
+			continue
+		}
+
+		listedViaDeprecated := listVersion == api.APIVersion
+
+		for _, obj := range objects.Items {
+			apiVersion := obj.GetAPIVersion()
+			kind := obj.GetKind()
+			source := "live"
+
+			if lastApplied, ok := obj.GetAnnotations()[lastAppliedConfigAnnotation]; ok && !listedViaDeprecated {
+				var applied manifestObject
+				if err := json.Unmarshal([]byte(lastApplied), &applied); err != nil {
+					continue
+				}
+				apiVersion = applied.APIVersion
+				kind = applied.Kind
+				source = "last-applied"
+			} else if !listedViaDeprecated {
+				continue
+			}
+
+			deprecated := lookupDeprecatedAPI(apiVersion, kind)
+			if deprecated == nil {
+				continue
+			}
+
+			finding := newDeprecatedAPIFinding(deprecated, kind, obj.GetName(), obj.GetNamespace(), source, targetVersion)
+			if finding != nil {
+				findings = append(findings, *finding)
+			}
+		}
+	}
+
+	return findings, nil
+}
+
+// FindDeprecatedAPIsInManifests scans a multi-document YAML or JSON stream
+// for objects using deprecated apiVersions.
+func FindDeprecatedAPIsInManifests(r io.Reader, source, targetVersion string) ([]DeprecatedAPIFinding, error) {
+	var findings []DeprecatedAPIFinding
+
+	reader := yaml.NewYAMLReader(bufio.NewReader(r))
+	for {
+		doc, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return findings, fmt.Errorf("failed to read manifest: %w", err)
+		}
+
+		var obj manifestObject
+		if err := yaml.Unmarshal(doc, &obj); err != nil || obj.Kind == "" {
+			continue
+		}
+
+		deprecated := lookupDeprecatedAPI(obj.APIVersion, obj.Kind)
+		if deprecated == nil {
+			continue
+		}
+
+		finding := newDeprecatedAPIFinding(deprecated, obj.Kind, obj.Metadata.Name, obj.Metadata.Namespace, source, targetVersion)
+		if finding != nil {
+			findings = append(findings, *finding)
+		}
+	}
+
+	return findings, nil
+}
+
+func lookupDeprecatedAPI(apiVersion, kind string) *DeprecatedAPI {
+	for i := range deprecatedAPIs {
+		if deprecatedAPIs[i].APIVersion == apiVersion && deprecatedAPIs[i].Kind == kind {
+			return &deprecatedAPIs[i]
+		}
+	}
+	return nil
+}
+
+func newDeprecatedAPIFinding(api *DeprecatedAPI, kind, name, namespace, source, targetVersion string) *DeprecatedAPIFinding {
+	target, err := parseMinorVersion(targetVersion)
+	if err != nil {
+		return nil
+	}
+	removedIn, _ := parseMinorVersion(api.RemovedIn)
+	deprecatedIn, _ := parseMinorVersion(api.DeprecatedIn)
+
+	finding := &DeprecatedAPIFinding{
+		Kind:         kind,
+		Name:         name,
+		Namespace:    namespace,
+		APIVersion:   api.APIVersion,
+		Replacement:  api.Replacement,
+		DeprecatedIn: api.DeprecatedIn,
+		RemovedIn:    api.RemovedIn,
+		Source:       source,
+	}
+
+	switch {
+	case removedIn <= target:
+		finding.Status = fmt.Sprintf("Removed in %s", api.RemovedIn)
+		finding.Severity = "High"
+	case deprecatedIn <= target:
+		finding.Status = fmt.Sprintf("Deprecated since %s", api.DeprecatedIn)
+		finding.Severity = "Low"
+	default:
+		return nil
+	}
+
+	if finding.Replacement == "" {
+		finding.Replacement = "none (removed without replacement)"
+	}
+
+	return finding
+}
+
+// parseMinorVersion accepts "1.32", "v1.32" or "v1.32.1" and returns 32.
+func parseMinorVersion(version string) (int, error) {
+	parts := strings.Split(strings.TrimPrefix(version, "v"), ".")
+	if len(parts) < 2 || parts[0] != "1" {
+		return 0, fmt.Errorf("invalid Kubernetes version %q, expected format 1.<minor>", version)
+	}
+
+	minor, err := strconv.Atoi(strings.TrimSuffix(parts[1], "+"))
+	if err != nil {
+		return 0, fmt.Errorf("invalid Kubernetes version %q: %w", version, err)
+	}
+
+	return minor, nil
+}
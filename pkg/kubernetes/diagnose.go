@@ -0,0 +1,566 @@
+package kubernetes
+
+import (
+	"bufio"
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+)
+
+type PodDiagnosis struct {
+	Pod            string
+	Namespace      string
+	Node           string
+	Phase          string
+	Restarts       int32
+	Containers     []ContainerDiagnosis
+	Events         []ClusterEvent
+	NodeConditions []string
+	Causes         []DiagnosisCause
+}
+
+type ContainerDiagnosis struct {
+	Name           string
+	State          string
+	Ready          bool
+	RestartCount   int32
+	ExitCode       int32
+	Reason         string
+	Signal         string
+	LastFinished   time.Time
+	CPULimit       int64
+	MemoryLimit    int64
+	CPUUsage       int64
+	MemoryUsage    int64
+	LivenessProbe  string
+	ReadinessProbe string
+	StartupProbe   string
+	LogTail        []string
+	PreviousLogs   bool
+}
+
+type DiagnosisCause struct {
+	Title      string
+	Container  string
+	Confidence int
+	Evidence   []string
+	Fix        string
+}
+
+type containerUsage struct {
+	cpu    int64
+	memory int64
+}
+
+var configErrorPattern = regexp.MustCompile(`(?i)no such file or directory|config(uration)? (file )?not found|missing (required )?(env|environment|config)|environment variable .* (not set|required)|permission denied`)
+
+// DiagnoseTarget diagnoses "pod/<name>", "deploy/<name>" or a bare pod name.
+// For deployments the least healthy pods are diagnosed, up to maxPods.
+func (c *Client) DiagnoseTarget(target, namespace string, tailLines int64, maxPods int) ([]PodDiagnosis, error) {
+	kind, name, found := strings.Cut(target, "/")
+	if !found {
+		kind, name = "pod", target
+	}
+	if namespace == "" {
+		namespace = "default"
+	}
+
+	var pods []corev1.Pod
+
+	switch strings.ToLower(kind) {
+	case "pod", "pods", "po":
+		pod, err := c.Clientset.CoreV1().Pods(namespace).Get(c.Context, name, metav1.GetOptions{})
+		if err != nil {
+			return nil, fmt.Errorf("failed to get pod %s: %w", name, err)
+		}
+		pods = []corev1.Pod{*pod}
+
+	case "deploy", "deployment", "deployments":
+		deployment, err := c.Clientset.AppsV1().Deployments(namespace).Get(c.Context, name, metav1.GetOptions{})
+		if err != nil {
+			return nil, fmt.Errorf("failed to get deployment %s: %w", name, err)
+		}
+		selector, err := metav1.LabelSelectorAsSelector(deployment.Spec.Selector)
+		if err != nil {
+			return nil, fmt.Errorf("invalid selector for deployment %s: %w", name, err)
+		}
+		podList, err := c.Clientset.CoreV1().Pods(namespace).List(c.Context, metav1.ListOptions{LabelSelector: selector.String()})
+		if err != nil {
+			return nil, fmt.Errorf("failed to get pods: %w", err)
+		}
+		pods = rankPodsForDiagnosis(podList.Items, selector)
+		if len(pods) == 0 {
+			return nil, fmt.Errorf("deployment %s has no pods", name)
+		}
+		if maxPods > 0 && len(pods) > maxPods {
+			pods = pods[:maxPods]
+		}
+
+	default:
+		return nil, fmt.Errorf("unsupported target kind %q (use pod/<name> or deploy/<name>)", kind)
+	}
+
+	var diagnoses []PodDiagnosis
+	for i := range pods {
+		diagnoses = append(diagnoses, c.DiagnosePod(&pods[i], tailLines))
+	}
+
+	return diagnoses, nil
+}
+
+// rankPodsForDiagnosis orders pods with the most restarts and unready
+// containers first.
+func rankPodsForDiagnosis(pods []corev1.Pod, selector labels.Selector) []corev1.Pod {
+	var ranked []corev1.Pod
+	for _, pod := range pods {
+		if selector.Matches(labels.Set(pod.Labels)) {
+			ranked = append(ranked, pod)
+		}
+	}
+
+	score := func(pod *corev1.Pod) int {
+		total := int(getTotalRestarts(pod))
+		for _, status := range pod.Status.ContainerStatuses {
+			if !status.Ready {
+				total += 100
+			}
+		}
+		return total
+	}
+
+	sort.SliceStable(ranked, func(i, j int) bool {
+		return score(&ranked[i]) > score(&ranked[j])
+	})
+
+	return ranked
+}
+
+func (c *Client) DiagnosePod(pod *corev1.Pod, tailLines int64) PodDiagnosis {
+	var events []ClusterEvent
+	if coreEvents, err := c.getEventsForPod(pod); err == nil {
+		for i := range coreEvents {
+			events = append(events, clusterEventFromCoreV1(&coreEvents[i]))
+		}
+		events = aggregateClusterEvents(events, time.Time{})
+	}
+
+	logs := make(map[string][]string)
+	previous := make(map[string]bool)
+	for _, status := range pod.Status.ContainerStatuses {
+		usePrevious := status.RestartCount > 0
+		lines, err := c.getContainerLogTail(pod, status.Name, tailLines, usePrevious)
+		if err != nil && usePrevious {
+			usePrevious = false
+			lines, err = c.getContainerLogTail(pod, status.Name, tailLines, false)
+		}
+		if err == nil {
+			logs[status.Name] = lines
+			previous[status.Name] = usePrevious
+		}
+	}
+
+	usage := make(map[string]containerUsage)
+	if c.MetricsClient != nil {
+		if metrics, err := c.MetricsClient.MetricsV1beta1().PodMetricses(pod.Namespace).Get(c.Context, pod.Name, metav1.GetOptions{}); err == nil {
+			for _, container := range metrics.Containers {
+				cpu := container.Usage[corev1.ResourceCPU]
+				memory := container.Usage[corev1.ResourceMemory]
+				usage[container.Name] = containerUsage{cpu: cpu.MilliValue(), memory: memory.Value()}
+			}
+		}
+	}
+
+	var nodeConditions []corev1.NodeCondition
+	if pod.Spec.NodeName != "" {
+		if node, err := c.Clientset.CoreV1().Nodes().Get(c.Context, pod.Spec.NodeName, metav1.GetOptions{}); err == nil {
+			nodeConditions = node.Status.Conditions
+		}
+	}
+
+	diagnosis := buildPodDiagnosis(pod, events, logs, usage, nodeConditions)
+	for i := range diagnosis.Containers {
+		diagnosis.Containers[i].PreviousLogs = previous[diagnosis.Containers[i].Name]
+	}
+
+	return diagnosis
+}
+
+func (c *Client) getContainerLogTail(pod *corev1.Pod, container string, tailLines int64, previous bool) ([]string, error) {
+	options := &corev1.PodLogOptions{Container: container, Previous: previous}
+	if tailLines > 0 {
+		options.TailLines = &tailLines
+	}
+
+	stream, err := c.Clientset.CoreV1().Pods(pod.Namespace).GetLogs(pod.Name, options).Stream(c.Context)
+	if err != nil {
+		return nil, err
+	}
+	defer stream.Close()
+
+	var lines []string
+	scanner := bufio.NewScanner(stream)
+	scanner.Buffer(make([]byte, 64*1024), 1024*1024)
+	for scanner.Scan() {
+		lines = append(lines, scanner.Text())
+	}
+
+	return lines, scanner.Err()
+}
+
+// buildPodDiagnosis combines the collected signals into container details and
+// a list of likely causes, most likely first.
+func buildPodDiagnosis(pod *corev1.Pod, events []ClusterEvent, logs map[string][]string, usage map[string]containerUsage, nodeConditions []corev1.NodeCondition) PodDiagnosis {
+	diagnosis := PodDiagnosis{
+		Pod:       pod.Name,
+		Namespace: pod.Namespace,
+		Node:      pod.Spec.NodeName,
+		Phase:     string(pod.Status.Phase),
+		Restarts:  getTotalRestarts(pod),
+		Events:    events,
+	}
+
+	specs := make(map[string]corev1.Container)
+	for _, container := range pod.Spec.Containers {
+		specs[container.Name] = container
+	}
+
+	liveness, readiness, startup := countProbeFailures(events)
+
+	for _, status := range pod.Status.ContainerStatuses {
+		spec := specs[status.Name]
+		container := ContainerDiagnosis{
+			Name:           status.Name,
+			State:          containerStateName(status.State),
+			Ready:          status.Ready,
+			RestartCount:   status.RestartCount,
+			LivenessProbe:  formatProbe(spec.LivenessProbe),
+			ReadinessProbe: formatProbe(spec.ReadinessProbe),
+			StartupProbe:   formatProbe(spec.StartupProbe),
+			LogTail:        logs[status.Name],
+		}
+
+		if cpu, ok := spec.Resources.Limits[corev1.ResourceCPU]; ok {
+			container.CPULimit = cpu.MilliValue()
+		}
+		if memory, ok := spec.Resources.Limits[corev1.ResourceMemory]; ok {
+			container.MemoryLimit = memory.Value()
+		}
+		if u, ok := usage[status.Name]; ok {
+			container.CPUUsage = u.cpu
+			container.MemoryUsage = u.memory
+		}
+
+		terminated := status.LastTerminationState.Terminated
+		if status.State.Terminated != nil {
+			terminated = status.State.Terminated
+		}
+		if terminated != nil {
+			container.ExitCode = terminated.ExitCode
+			container.Reason = terminated.Reason
+			container.Signal = exitCodeSignal(terminated.ExitCode, terminated.Signal)
+			container.LastFinished = terminated.FinishedAt.Time
+		}
+		if status.State.Waiting != nil && status.State.Waiting.Reason != "" {
+			container.State = status.State.Waiting.Reason
+		}
+
+		diagnosis.Containers = append(diagnosis.Containers, container)
+		diagnosis.Causes = append(diagnosis.Causes, diagnoseContainer(container, status, spec, liveness, readiness, startup)...)
+	}
+
+	for _, condition := range nodeConditions {
+		abnormal := (condition.Type == corev1.NodeReady && condition.Status != corev1.ConditionTrue) ||
+			(condition.Type != corev1.NodeReady && condition.Status == corev1.ConditionTrue)
+		if !abnormal {
+			continue
+		}
+		description := fmt.Sprintf("%s=%s", condition.Type, condition.Status)
+		if condition.Message != "" {
+			description += ": " + condition.Message
+		}
+		diagnosis.NodeConditions = append(diagnosis.NodeConditions, description)
+	}
+
+	if len(diagnosis.NodeConditions) > 0 {
+		diagnosis.Causes = append(diagnosis.Causes, DiagnosisCause{
+			Title:      fmt.Sprintf("Node %s is unhealthy or under resource pressure", pod.Spec.NodeName),
+			Confidence: 55,
+			Evidence:   diagnosis.NodeConditions,
+			Fix:        "Check kubelet and node resources; cordon and drain the node if the condition persists.",
+		})
+	}
+
+	for _, event := range events {
+		if strings.Contains(event.Reason, "FailedMount") || strings.Contains(event.Reason, "FailedAttachVolume") {
+			diagnosis.Causes = append(diagnosis.Causes, DiagnosisCause{
+				Title:      "Volume cannot be mounted",
+				Confidence: 80,
+				Evidence:   []string{fmt.Sprintf("%s (x%d): %s", event.Reason, event.Count, event.Message)},
+				Fix:        "Verify the referenced PVC, ConfigMap or Secret exists and the volume is not attached elsewhere.",
+			})
+			break
+		}
+	}
+
+	sort.SliceStable(diagnosis.Causes, func(i, j int) bool {
+		return diagnosis.Causes[i].Confidence > diagnosis.Causes[j].Confidence
+	})
+
+	return diagnosis
+}
+
+func diagnoseContainer(container ContainerDiagnosis, status corev1.ContainerStatus, spec corev1.Container, liveness, readiness, startup probeFailure) []DiagnosisCause {
+	var causes []DiagnosisCause
+	add := func(title string, confidence int, fix string, evidence ...string) {
+		causes = append(causes, DiagnosisCause{
+			Title:      title,
+			Container:  container.Name,
+			Confidence: confidence,
+			Evidence:   evidence,
+			Fix:        fix,
+		})
+	}
+
+	if status.State.Waiting != nil {
+		waiting := status.State.Waiting
+		switch waiting.Reason {
+		case "CreateContainerConfigError", "CreateContainerError":
+			add("Container configuration references a missing ConfigMap, Secret or key", 90,
+				"Create the missing ConfigMap/Secret or fix the reference in the pod spec.", waiting.Message)
+		case "ImagePullBackOff", "ErrImagePull", "InvalidImageName":
+			add("Image cannot be pulled", 90,
+				"Check the image name and tag, and that imagePullSecrets grant access to the registry.", waiting.Message)
+		case "RunContainerError":
+			add("Container runtime failed to start the container", 75,
+				"Check the command, volume mounts and securityContext of the container.", waiting.Message)
+		}
+	}
+
+	if status.RestartCount == 0 && container.ExitCode == 0 {
+		return causes
+	}
+
+	exitEvidence := fmt.Sprintf("Last exit code %d", container.ExitCode)
+	if container.Reason != "" {
+		exitEvidence += fmt.Sprintf(" (%s)", container.Reason)
+	}
+	if container.Signal != "" {
+		exitEvidence += ", " + container.Signal
+	}
+
+	livenessFailing := liveness.count > 0 && spec.LivenessProbe != nil
+
+	switch {
+	case container.Reason == "OOMKilled":
+		evidence := []string{exitEvidence}
+		if container.MemoryLimit > 0 {
+			evidence = append(evidence, fmt.Sprintf("Memory limit %s", formatBytes(container.MemoryLimit)))
+		}
+		if container.MemoryUsage > 0 {
+			evidence = append(evidence, fmt.Sprintf("Current memory usage %s", formatBytes(container.MemoryUsage)))
+		}
+		add("Container is killed for exceeding its memory limit", 95,
+			"Raise the memory limit (and request), or reduce the application's memory footprint (heap size, caches, leaks).", evidence...)
+
+	case livenessFailing && (container.ExitCode == 137 || container.ExitCode == 143):
+		add("Liveness probe failures restart the container", 85,
+			"Add a startupProbe or increase initialDelaySeconds/failureThreshold/timeoutSeconds so slow starts are not killed.",
+			exitEvidence, fmt.Sprintf("Liveness probe failed %d times: %s", liveness.count, liveness.message), "Probe: "+container.LivenessProbe)
+
+	case container.ExitCode == 126 || container.ExitCode == 127:
+		add("Container command is not found or not executable", 90,
+			"Check the image entrypoint, command and args, and file permissions.", exitEvidence)
+
+	case container.ExitCode == 137:
+		add("Container is killed by SIGKILL", 50,
+			"Check for node memory pressure or evictions; the container may also be exceeding a limit enforced outside Kubernetes.", exitEvidence)
+
+	case container.ExitCode == 0 && status.RestartCount > 0:
+		add("Process exits successfully but is restarted by the restart policy", 70,
+			"Make sure the main process stays in the foreground, or run one-off work as a Job.", exitEvidence)
+	}
+
+	if container.ExitCode != 0 && container.Reason != "OOMKilled" && container.ExitCode != 126 && container.ExitCode != 127 {
+		causes = append(causes, diagnoseFromLogs(container, exitEvidence)...)
+	}
+
+	if startup.count > 0 && spec.StartupProbe != nil && !livenessFailing {
+		add("Startup probe failures restart the container", 75,
+			"Increase the startupProbe failureThreshold or periodSeconds to cover the application's startup time.",
+			exitEvidence, "Probe: "+container.StartupProbe)
+	}
+
+	if readiness.count > 0 && spec.ReadinessProbe != nil {
+		add("Readiness probe is failing", 55,
+			"Verify the probe endpoint and port; a dependency the probe checks may be unavailable.",
+			fmt.Sprintf("Readiness probe failed %d times: %s", readiness.count, readiness.message), "Probe: "+container.ReadinessProbe)
+	}
+
+	if spec.LivenessProbe != nil && spec.StartupProbe == nil && spec.LivenessProbe.InitialDelaySeconds == 0 && !livenessFailing && status.RestartCount > 0 {
+		add("Liveness probe has no initial delay or startup probe", 30,
+			"Add a startupProbe so the liveness probe does not run during application startup.", "Probe: "+container.LivenessProbe)
+	}
+
+	if container.CPULimit > 0 && container.CPUUsage >= container.CPULimit*9/10 {
+		add("CPU limit is saturated, slowing startup and probe responses", 45,
+			"Raise the CPU limit or remove it and rely on requests.",
+			fmt.Sprintf("CPU usage %s of limit %s", formatCPU(container.CPUUsage), formatCPU(container.CPULimit)))
+	}
+
+	if container.Reason != "OOMKilled" && container.MemoryLimit > 0 && container.MemoryUsage >= container.MemoryLimit*9/10 {
+		add("Memory usage is close to the limit", 60,
+			"Raise the memory limit before the container gets OOMKilled.",
+			fmt.Sprintf("Memory usage %s of limit %s", formatBytes(container.MemoryUsage), formatBytes(container.MemoryLimit)))
+	}
+
+	return causes
+}
+
+// diagnoseFromLogs looks for the most telling line in the log tail of a
+// crashed container.
+func diagnoseFromLogs(container ContainerDiagnosis, exitEvidence string) []DiagnosisCause {
+	for i := len(container.LogTail) - 1; i >= 0; i-- {
+		if configErrorPattern.MatchString(container.LogTail[i]) {
+			return []DiagnosisCause{{
+				Title:      "Application fails on missing configuration or files",
+				Container:  container.Name,
+				Confidence: 80,
+				Evidence:   []string{exitEvidence, "Log: " + container.LogTail[i]},
+				Fix:        "Check mounted ConfigMaps/Secrets, environment variables and file paths the application expects.",
+			}}
+		}
+	}
+
+	rules, err := compileLogRules(DefaultLogRules())
+	if err == nil {
+		summary, matches, _ := classifyLogLines(strings.NewReader(strings.Join(container.LogTail, "\n")), rules)
+		if len(matches) > 0 {
+			fixes := map[string]string{
+				"panic":              "Fix the crash shown in the logs; the stack trace points at the failing code path.",
+				"oom":                "The runtime reports memory exhaustion; raise the memory limit or tune the heap size.",
+				"stack-trace":        "Fix the unhandled exception shown in the logs.",
+				"connection-refused": "A dependency is unreachable at startup; check its Service, endpoints and DNS name, or add retries.",
+				"timeout":            "A dependency is too slow or unreachable; check network policies and the dependency's health.",
+				"error":              "Review the application errors logged before the crash.",
+			}
+
+			match := matches[len(matches)-1]
+			for _, m := range matches {
+				if m.rule.Severity == "Critical" {
+					match = m
+					break
+				}
+			}
+
+			confidence := 70
+			if match.rule.Severity == "Critical" {
+				confidence = 80
+			}
+
+			return []DiagnosisCause{{
+				Title:      fmt.Sprintf("Application crashes with %s errors", match.rule.Name),
+				Container:  container.Name,
+				Confidence: confidence,
+				Evidence:   []string{exitEvidence, fmt.Sprintf("%d of %d log lines classified", len(matches), summary.Lines), "Log: " + match.line},
+				Fix:        fixes[match.rule.Name],
+			}}
+		}
+	}
+
+	return []DiagnosisCause{{
+		Title:      fmt.Sprintf("Application exits with code %d", container.ExitCode),
+		Container:  container.Name,
+		Confidence: 40,
+		Evidence:   []string{exitEvidence},
+		Fix:        "Inspect the previous container logs (kubectl logs --previous) for the failure reason.",
+	}}
+}
+
+type probeFailure struct {
+	count   int32
+	message string
+}
+
+// countProbeFailures sums probe failures reported by "Unhealthy" events. The
+// events do not name the container, so failures are attributed to the pod.
+func countProbeFailures(events []ClusterEvent) (liveness, readiness, startup probeFailure) {
+	for _, event := range events {
+		if event.Reason != "Unhealthy" {
+			continue
+		}
+
+		var target *probeFailure
+		switch {
+		case strings.HasPrefix(event.Message, "Liveness probe failed"):
+			target = &liveness
+		case strings.HasPrefix(event.Message, "Readiness probe failed"):
+			target = &readiness
+		case strings.HasPrefix(event.Message, "Startup probe failed"):
+			target = &startup
+		default:
+			continue
+		}
+
+		target.count += event.Count
+		target.message = event.Message
+	}
+
+	return liveness, readiness, startup
+}
+
+func containerStateName(state corev1.ContainerState) string {
+	switch {
+	case state.Running != nil:
+		return "Running"
+	case state.Waiting != nil:
+		return "Waiting"
+	case state.Terminated != nil:
+		return "Terminated"
+	default:
+		return "Unknown"
+	}
+}
+
+func exitCodeSignal(exitCode, signal int32) string {
+	if signal == 0 && exitCode > 128 && exitCode < 160 {
+		signal = exitCode - 128
+	}
+
+	names := map[int32]string{6: "SIGABRT", 9: "SIGKILL", 11: "SIGSEGV", 15: "SIGTERM"}
+	if name, exists := names[signal]; exists {
+		return name
+	}
+	if signal > 0 {
+		return fmt.Sprintf("signal %d", signal)
+	}
+	return ""
+}
+
+func formatProbe(probe *corev1.Probe) string {
+	if probe == nil {
+		return "none"
+	}
+
+	var handler string
+	switch {
+	case probe.HTTPGet != nil:
+		handler = fmt.Sprintf("http-get %s:%s", probe.HTTPGet.Path, probe.HTTPGet.Port.String())
+	case probe.TCPSocket != nil:
+		handler = fmt.Sprintf("tcp :%s", probe.TCPSocket.Port.String())
+	case probe.Exec != nil:
+		handler = fmt.Sprintf("exec %s", strings.Join(probe.Exec.Command, " "))
+	case probe.GRPC != nil:
+		handler = fmt.Sprintf("grpc :%d", probe.GRPC.Port)
+	default:
+		handler = "unknown"
+	}
+
+	return fmt.Sprintf("%s delay=%ds timeout=%ds period=%ds failure=%d",
+		handler, probe.InitialDelaySeconds, probe.TimeoutSeconds, probe.PeriodSeconds, probe.FailureThreshold)
+}
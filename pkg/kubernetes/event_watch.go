@@ -0,0 +1,387 @@
+package kubernetes
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/watch"
+)
+
+// StreamEvent is a single entry of the live event stream. It is emitted
+// both for Kubernetes Events and for problematic Pod state changes.
+type StreamEvent struct {
+	Time       time.Time `json:"time"`
+	Source     string    `json:"source"`
+	Type       string    `json:"type"`
+	Severity   string    `json:"severity"`
+	Reason     string    `json:"reason"`
+	Message    string    `json:"message"`
+	Object     string    `json:"object"`
+	Namespace  string    `json:"namespace,omitempty"`
+	Count      int32     `json:"count"`
+	Component  string    `json:"component,omitempty"`
+	Suppressed int       `json:"suppressed,omitempty"`
+}
+
+type EventWatchOptions struct {
+	Namespace   string
+	MinSeverity string
+	DedupWindow time.Duration
+	RateLimit   int
+}
+
+// WatchClusterEvents streams new Events and Pod state changes to handler until
+// the client context is cancelled or handler returns an error.
+func (c *Client) WatchClusterEvents(options EventWatchOptions, handler func(StreamEvent) error) error {
+	throttler := newEventThrottler(options.DedupWindow, options.RateLimit, time.Minute)
+	minRank := eventSeverityRank(options.MinSeverity)
+
+	emit := func(event StreamEvent) error {
+		if eventSeverityRank(event.Severity) < minRank {
+			return nil
+		}
+		allowed, suppressed := throttler.allow(event.dedupKey(), time.Now())
+		if !allowed {
+			return nil
+		}
+		event.Suppressed = suppressed
+		return handler(event)
+	}
+
+	events, err := c.Clientset.CoreV1().Events(options.Namespace).List(c.Context, metav1.ListOptions{})
+	if err != nil {
+		return fmt.Errorf("failed to get events: %w", err)
+	}
+
+	pods, err := c.Clientset.CoreV1().Pods(options.Namespace).List(c.Context, metav1.ListOptions{})
+	if err != nil {
+		return fmt.Errorf("failed to get pods: %w", err)
+	}
+
+	// Seed the known pod states so only transitions after startup are reported
+	podStates := make(map[string]string)
+	for _, pod := range pods.Items {
+		for key, state := range podProblemStates(&pod) {
+			podStates[key] = state.Reason
+		}
+	}
+
+	eventVersion := events.ResourceVersion
+	podVersion := pods.ResourceVersion
+
+	for {
+		eventWatch, err := c.Clientset.CoreV1().Events(options.Namespace).Watch(c.Context, metav1.ListOptions{
+			ResourceVersion:     eventVersion,
+			AllowWatchBookmarks: true,
+		})
+		if err != nil {
+			return fmt.Errorf("failed to watch events: %w", err)
+		}
+
+		podWatch, err := c.Clientset.CoreV1().Pods(options.Namespace).Watch(c.Context, metav1.ListOptions{
+			ResourceVersion:     podVersion,
+			AllowWatchBookmarks: true,
+		})
+		if err != nil {
+			eventWatch.Stop()
+			return fmt.Errorf("failed to watch pods: %w", err)
+		}
+
+		expired, err := c.consumeWatches(eventWatch, podWatch, podStates, &eventVersion, &podVersion, emit)
+		eventWatch.Stop()
+		podWatch.Stop()
+
+		if err != nil {
+			return err
+		}
+
+		select {
+		case <-c.Context.Done():
+			return nil
+		default:
+		}
+
+		// The server closes watches periodically; resume from the last seen
+		// version, or from now if that version has been compacted away
+		if expired {
+			if eventVersion, podVersion, err = c.currentResourceVersions(options.Namespace); err != nil {
+				return err
+			}
+		}
+	}
+}
+
+func (c *Client) consumeWatches(eventWatch, podWatch watch.Interface, podStates map[string]string, eventVersion, podVersion *string, emit func(StreamEvent) error) (bool, error) {
+	for {
+		select {
+		case <-c.Context.Done():
+			return false, nil
+
+		case result, ok := <-eventWatch.ResultChan():
+			if !ok {
+				return false, nil
+			}
+			if result.Type == watch.Error {
+				return isWatchExpired(result), nil
+			}
+
+			event, ok := result.Object.(*corev1.Event)
+			if !ok {
+				continue
+			}
+			*eventVersion = event.ResourceVersion
+			if result.Type == watch.Added || result.Type == watch.Modified {
+				if err := emit(streamEventFromEvent(event)); err != nil {
+					return false, err
+				}
+			}
+
+		case result, ok := <-podWatch.ResultChan():
+			if !ok {
+				return false, nil
+			}
+			if result.Type == watch.Error {
+				return isWatchExpired(result), nil
+			}
+
+			pod, ok := result.Object.(*corev1.Pod)
+			if !ok {
+				continue
+			}
+			*podVersion = pod.ResourceVersion
+			if result.Type == watch.Bookmark {
+				continue
+			}
+
+			for _, event := range diffPodStates(pod, podStates, result.Type == watch.Deleted) {
+				if err := emit(event); err != nil {
+					return false, err
+				}
+			}
+		}
+	}
+}
+
+func (c *Client) currentResourceVersions(namespace string) (string, string, error) {
+	events, err := c.Clientset.CoreV1().Events(namespace).List(c.Context, metav1.ListOptions{Limit: 1})
+	if err != nil {
+		return "", "", fmt.Errorf("failed to get events: %w", err)
+	}
+
+	pods, err := c.Clientset.CoreV1().Pods(namespace).List(c.Context, metav1.ListOptions{Limit: 1})
+	if err != nil {
+		return "", "", fmt.Errorf("failed to get pods: %w", err)
+	}
+
+	return events.ResourceVersion, pods.ResourceVersion, nil
+}
+
+func isWatchExpired(result watch.Event) bool {
+	status, ok := result.Object.(*metav1.Status)
+	if !ok {
+		return false
+	}
+	return apierrors.IsResourceExpired(&apierrors.StatusError{ErrStatus: *status}) ||
+		apierrors.IsGone(&apierrors.StatusError{ErrStatus: *status})
+}
+
+func streamEventFromEvent(event *corev1.Event) StreamEvent {
+	timestamp := event.LastTimestamp.Time
+	if timestamp.IsZero() {
+		timestamp = event.EventTime.Time
+	}
+	if timestamp.IsZero() {
+		timestamp = time.Now()
+	}
+
+	return StreamEvent{
+		Time:      timestamp,
+		Source:    "event",
+		Type:      event.Type,
+		Severity:  categorizeSeverity(event),
+		Reason:    event.Reason,
+		Message:   event.Message,
+		Object:    fmt.Sprintf("%s/%s", event.InvolvedObject.Kind, event.InvolvedObject.Name),
+		Namespace: event.Namespace,
+		Count:     event.Count,
+		Component: extractComponent(event),
+	}
+}
+
+type podProblemState struct {
+	Container string
+	Reason    string
+	Message   string
+}
+
+// podProblemStates returns the problematic container states of a pod, keyed
+// by namespace/pod/container.
+func podProblemStates(pod *corev1.Pod) map[string]podProblemState {
+	states := make(map[string]podProblemState)
+	prefix := pod.Namespace + "/" + pod.Name + "/"
+
+	statuses := append([]corev1.ContainerStatus{}, pod.Status.InitContainerStatuses...)
+	statuses = append(statuses, pod.Status.ContainerStatuses...)
+
+	for _, status := range statuses {
+		switch {
+		case status.State.Waiting != nil && status.State.Waiting.Reason != "" && status.State.Waiting.Reason != "ContainerCreating" && status.State.Waiting.Reason != "PodInitializing":
+			states[prefix+status.Name] = podProblemState{status.Name, status.State.Waiting.Reason, status.State.Waiting.Message}
+		case status.State.Terminated != nil && status.State.Terminated.ExitCode != 0:
+			reason := status.State.Terminated.Reason
+			if reason == "" {
+				reason = "Error"
+			}
+			states[prefix+status.Name] = podProblemState{status.Name, reason, fmt.Sprintf("Container exited with code %d", status.State.Terminated.ExitCode)}
+		case status.LastTerminationState.Terminated != nil && status.LastTerminationState.Terminated.Reason == "OOMKilled" && !status.Ready:
+			states[prefix+status.Name] = podProblemState{status.Name, "OOMKilled", "Container was killed for exceeding its memory limit"}
+		}
+	}
+
+	if pod.Status.Phase == corev1.PodFailed {
+		states[prefix] = podProblemState{"", "PodFailed", pod.Status.Message}
+	}
+
+	return states
+}
+
+// diffPodStates reports container states that changed since the pod was last
+// seen and updates known accordingly.
+func diffPodStates(pod *corev1.Pod, known map[string]string, deleted bool) []StreamEvent {
+	prefix := pod.Namespace + "/" + pod.Name + "/"
+	current := podProblemStates(pod)
+	if deleted {
+		current = map[string]podProblemState{}
+	}
+
+	var events []StreamEvent
+	for key, state := range current {
+		if known[key] == state.Reason {
+			continue
+		}
+		known[key] = state.Reason
+
+		object := "Pod/" + pod.Name
+		if state.Container != "" {
+			object += "/" + state.Container
+		}
+
+		message := state.Message
+		if message == "" {
+			message = fmt.Sprintf("Container entered %s state", state.Reason)
+		}
+
+		event := &corev1.Event{Type: "Warning", Reason: state.Reason}
+		events = append(events, StreamEvent{
+			Time:      time.Now(),
+			Source:    "pod",
+			Type:      "Warning",
+			Severity:  categorizeSeverity(event),
+			Reason:    state.Reason,
+			Message:   message,
+			Object:    object,
+			Namespace: pod.Namespace,
+			Count:     1,
+		})
+	}
+
+	// Forget containers that recovered so a recurrence is reported again
+	for key := range known {
+		if strings.HasPrefix(key, prefix) {
+			if _, exists := current[key]; !exists {
+				delete(known, key)
+			}
+		}
+	}
+
+	return events
+}
+
+func (event StreamEvent) dedupKey() string {
+	return event.Namespace + "/" + event.Object + "/" + event.Reason + "/" + event.Message
+}
+
+func eventSeverityRank(severity string) int {
+	switch severity {
+	case "Critical":
+		return 2
+	case "Warning":
+		return 1
+	default:
+		return 0
+	}
+}
+
+// eventThrottler suppresses repeats of the same event within the dedup window
+// and caps the number of emitted events per interval.
+type eventThrottler struct {
+	window      time.Duration
+	limit       int
+	interval    time.Duration
+	lastSeen    map[string]time.Time
+	repeats     map[string]int
+	windowStart time.Time
+	lastPrune   time.Time
+	emitted     int
+	dropped     int
+}
+
+func newEventThrottler(window time.Duration, limit int, interval time.Duration) *eventThrottler {
+	return &eventThrottler{
+		window:   window,
+		limit:    limit,
+		interval: interval,
+		lastSeen: make(map[string]time.Time),
+		repeats:  make(map[string]int),
+	}
+}
+
+// allow reports whether an event should be emitted, along with how many
+// events were suppressed (as duplicates of it or by the rate limit) since.
+func (t *eventThrottler) allow(key string, now time.Time) (bool, int) {
+	// Prune after handling the key so its own suppressed count is reported
+	defer t.prune(now)
+
+	if last, exists := t.lastSeen[key]; exists && t.window > 0 && now.Sub(last) < t.window {
+		t.repeats[key]++
+		return false, 0
+	}
+
+	if now.Sub(t.windowStart) >= t.interval {
+		t.windowStart = now
+		t.emitted = 0
+	}
+	if t.limit > 0 && t.emitted >= t.limit {
+		t.dropped++
+		return false, 0
+	}
+
+	suppressed := t.repeats[key] + t.dropped
+	t.emitted++
+	t.dropped = 0
+	t.lastSeen[key] = now
+	delete(t.repeats, key)
+
+	return true, suppressed
+}
+
+// prune forgets events not seen within the dedup window, so a long-running
+// watch does not grow with every unique event. It runs at most once per
+// window.
+func (t *eventThrottler) prune(now time.Time) {
+	if now.Sub(t.lastPrune) < t.window {
+		return
+	}
+	t.lastPrune = now
+
+	for key, seen := range t.lastSeen {
+		if now.Sub(seen) >= t.window {
+			delete(t.lastSeen, key)
+			delete(t.repeats, key)
+		}
+	}
+}
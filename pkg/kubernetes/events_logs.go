@@ -7,6 +7,8 @@ import (
 	"time"
 
 	corev1 "k8s.io/api/core/v1"
+	eventsv1 "k8s.io/api/events/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
@@ -69,43 +71,150 @@ type PodLogSummary struct {
 	LastRestart    time.Time
 }
 
+// GetClusterEvents returns events observed within the last hours, aggregated
+// by involved object and reason. The events.k8s.io/v1 API is preferred so
+// that event series are counted correctly; core/v1 is used as a fallback.
 func (c *Client) GetClusterEvents(namespace string, hours int) ([]ClusterEvent, error) {
-	timeWindow := time.Now().Add(-time.Duration(hours) * time.Hour)
+	since := time.Now().Add(-time.Duration(hours) * time.Hour)
 
-	listOptions := metav1.ListOptions{
-		FieldSelector: fmt.Sprintf("firstTimestamp>%s", timeWindow.Format(time.RFC3339)),
-	}
+	var clusterEvents []ClusterEvent
 
-	events, err := c.Clientset.CoreV1().Events(namespace).List(c.Context, listOptions)
-	if err != nil {
+	events, err := c.Clientset.EventsV1().Events(namespace).List(c.Context, metav1.ListOptions{})
+	if err == nil {
+		for i := range events.Items {
+			clusterEvents = append(clusterEvents, clusterEventFromEventsV1(&events.Items[i]))
+		}
+	} else if apierrors.IsNotFound(err) || apierrors.IsForbidden(err) {
+		coreEvents, err := c.Clientset.CoreV1().Events(namespace).List(c.Context, metav1.ListOptions{})
+		if err != nil {
+			return nil, fmt.Errorf("failed to get events: %w", err)
+		}
+		for i := range coreEvents.Items {
+			clusterEvents = append(clusterEvents, clusterEventFromCoreV1(&coreEvents.Items[i]))
+		}
+	} else {
 		return nil, fmt.Errorf("failed to get events: %w", err)
 	}
 
-	var clusterEvents []ClusterEvent
-	for _, event := range events.Items {
-		severity := categorizeSeverity(&event)
-		component := extractComponent(&event)
-
-		clusterEvent := ClusterEvent{
-			Type:      event.Type,
-			Reason:    event.Reason,
-			Message:   event.Message,
-			Object:    fmt.Sprintf("%s/%s", event.InvolvedObject.Kind, event.InvolvedObject.Name),
-			Namespace: event.Namespace,
-			FirstTime: event.FirstTimestamp.Time,
-			LastTime:  event.LastTimestamp.Time,
-			Count:     event.Count,
-			Severity:  severity,
-			Component: component,
+	return aggregateClusterEvents(clusterEvents, since), nil
+}
+
+func clusterEventFromCoreV1(event *corev1.Event) ClusterEvent {
+	first := firstNonZeroTime(event.FirstTimestamp.Time, event.EventTime.Time, event.CreationTimestamp.Time)
+	last := firstNonZeroTime(event.LastTimestamp.Time, event.EventTime.Time, first)
+	count := event.Count
+
+	if event.Series != nil {
+		last = firstNonZeroTime(event.Series.LastObservedTime.Time, last)
+		if event.Series.Count > count {
+			count = event.Series.Count
+		}
+	}
+	if count == 0 {
+		count = 1
+	}
+
+	return ClusterEvent{
+		Type:      event.Type,
+		Reason:    event.Reason,
+		Message:   event.Message,
+		Object:    fmt.Sprintf("%s/%s", event.InvolvedObject.Kind, event.InvolvedObject.Name),
+		Namespace: event.Namespace,
+		FirstTime: first,
+		LastTime:  last,
+		Count:     count,
+		Severity:  categorizeSeverity(event),
+		Component: extractComponent(event),
+	}
+}
+
+func clusterEventFromEventsV1(event *eventsv1.Event) ClusterEvent {
+	first := firstNonZeroTime(event.EventTime.Time, event.DeprecatedFirstTimestamp.Time, event.CreationTimestamp.Time)
+	last := firstNonZeroTime(event.DeprecatedLastTimestamp.Time, first)
+	count := event.DeprecatedCount
+
+	if event.Series != nil {
+		last = firstNonZeroTime(event.Series.LastObservedTime.Time, last)
+		if event.Series.Count > count {
+			count = event.Series.Count
+		}
+	}
+	if count == 0 {
+		count = 1
+	}
+
+	// Map onto the core/v1 shape so severity and component detection are shared
+	coreEvent := &corev1.Event{
+		Type:                event.Type,
+		Reason:              event.Reason,
+		Source:              event.DeprecatedSource,
+		ReportingController: event.ReportingController,
+	}
+
+	return ClusterEvent{
+		Type:      event.Type,
+		Reason:    event.Reason,
+		Message:   event.Note,
+		Object:    fmt.Sprintf("%s/%s", event.Regarding.Kind, event.Regarding.Name),
+		Namespace: event.Namespace,
+		FirstTime: first,
+		LastTime:  last,
+		Count:     count,
+		Severity:  categorizeSeverity(coreEvent),
+		Component: extractComponent(coreEvent),
+	}
+}
+
+// aggregateClusterEvents drops events last observed before since and merges
+// events for the same object and reason (e.g. separate series of a recurring
+// event) into a single entry, newest first.
+func aggregateClusterEvents(events []ClusterEvent, since time.Time) []ClusterEvent {
+	aggregated := make(map[string]*ClusterEvent)
+	var order []string
+
+	for _, event := range events {
+		if event.LastTime.Before(since) {
+			continue
+		}
+
+		key := strings.Join([]string{event.Namespace, event.Object, event.Type, event.Reason}, "|")
+		existing, exists := aggregated[key]
+		if !exists {
+			event := event
+			aggregated[key] = &event
+			order = append(order, key)
+			continue
+		}
+
+		existing.Count += event.Count
+		if event.FirstTime.Before(existing.FirstTime) {
+			existing.FirstTime = event.FirstTime
+		}
+		if event.LastTime.After(existing.LastTime) {
+			existing.LastTime = event.LastTime
+			existing.Message = event.Message
 		}
-		clusterEvents = append(clusterEvents, clusterEvent)
 	}
 
-	sort.Slice(clusterEvents, func(i, j int) bool {
-		return clusterEvents[i].LastTime.After(clusterEvents[j].LastTime)
+	result := make([]ClusterEvent, 0, len(order))
+	for _, key := range order {
+		result = append(result, *aggregated[key])
+	}
+
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].LastTime.After(result[j].LastTime)
 	})
 
-	return clusterEvents, nil
+	return result
+}
+
+func firstNonZeroTime(times ...time.Time) time.Time {
+	for _, t := range times {
+		if !t.IsZero() {
+			return t
+		}
+	}
+	return time.Time{}
 }
 
 func (c *Client) GetLogAnalysis(namespace string, hours int) (*LogAnalysis, error) {
@@ -144,6 +253,8 @@ func (c *Client) GetLogAnalysis(namespace string, hours int) (*LogAnalysis, erro
 	return analysis, nil
 }
 
+// GetPodLogsAnalysis summarizes pod problems from events only; see
+// GetContainerLogAnalysis for analysis of the container logs themselves.
 func (c *Client) GetPodLogsAnalysis(namespace string) ([]PodLogSummary, error) {
 	pods, err := c.Clientset.CoreV1().Pods(namespace).List(c.Context, metav1.ListOptions{})
 	if err != nil {
@@ -389,7 +500,7 @@ func generatePatternDescription(reason string) string {
 
 func generatePatternRecommendation(reason string) string {
 	recommendations := map[string]string{
-		"FailedScheduling":       "Check node capacity, taints, and pod resource requests",
+		"FailedScheduling":       "Run 'k8s-cli pending' to see which node constraints the pod fails",
 		"FailedMount":            "Verify volume availability and mount permissions",
 		"ImagePullBackOff":       "Check image name, registry credentials, and network connectivity",
 		"SystemOOM":              "Increase memory limits or optimize application memory usage",
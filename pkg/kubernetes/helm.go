@@ -0,0 +1,242 @@
+package kubernetes
+
+import (
+	"bytes"
+	"compress/gzip"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+type HelmRelease struct {
+	Name          string
+	Namespace     string
+	Revision      int
+	Status        string
+	Chart         string
+	ChartVersion  string
+	AppVersion    string
+	FirstDeployed time.Time
+	LastDeployed  time.Time
+	Description   string
+	Notes         string
+	CustomValues  int
+	Images        []string
+	Flagged       bool
+	Issues        []string
+	Manifest      string `json:"-"`
+}
+
+// helmReleasePayload mirrors the subset of Helm's release object stored in
+// "sh.helm.release.v1" secrets that we need for analysis.
+type helmReleasePayload struct {
+	Name      string `json:"name"`
+	Namespace string `json:"namespace"`
+	Version   int    `json:"version"`
+	Manifest  string `json:"manifest"`
+	Info      struct {
+		FirstDeployed time.Time `json:"first_deployed"`
+		LastDeployed  time.Time `json:"last_deployed"`
+		Description   string    `json:"description"`
+		Status        string    `json:"status"`
+		Notes         string    `json:"notes"`
+	} `json:"info"`
+	Chart struct {
+		Metadata struct {
+			Name       string `json:"name"`
+			Version    string `json:"version"`
+			AppVersion string `json:"appVersion"`
+		} `json:"metadata"`
+	} `json:"chart"`
+	Config map[string]interface{} `json:"config"`
+}
+
+var gzipMagic = []byte{0x1f, 0x8b, 0x08}
+
+// decodeHelmRelease decodes the "release" key of a Helm secret, which is
+// base64 encoded and (since Helm 3) gzip compressed JSON.
+func decodeHelmRelease(data []byte) (*helmReleasePayload, error) {
+	decoded, err := base64.StdEncoding.DecodeString(string(data))
+	if err != nil {
+		return nil, fmt.Errorf("failed to base64 decode release: %w", err)
+	}
+
+	if bytes.HasPrefix(decoded, gzipMagic) {
+		reader, err := gzip.NewReader(bytes.NewReader(decoded))
+		if err != nil {
+			return nil, fmt.Errorf("failed to open gzip release: %w", err)
+		}
+		defer reader.Close()
+
+		decoded, err = io.ReadAll(reader)
+		if err != nil {
+			return nil, fmt.Errorf("failed to decompress release: %w", err)
+		}
+	}
+
+	var release helmReleasePayload
+	if err := json.Unmarshal(decoded, &release); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal release: %w", err)
+	}
+
+	return &release, nil
+}
+
+// GetHelmReleases returns the newest revision of every Helm release stored
+// as a secret in the given namespace.
+func (c *Client) GetHelmReleases(namespace string) ([]HelmRelease, error) {
+	revisions, err := c.listHelmRevisions(namespace, "")
+	if err != nil {
+		return nil, err
+	}
+
+	latest := make(map[string]HelmRelease)
+	for _, release := range revisions {
+		key := release.Namespace + "/" + release.Name
+		if existing, exists := latest[key]; !exists || release.Revision > existing.Revision {
+			latest[key] = release
+		}
+	}
+
+	releases := make([]HelmRelease, 0, len(latest))
+	for _, release := range latest {
+		releases = append(releases, release)
+	}
+
+	sort.Slice(releases, func(i, j int) bool {
+		return releases[i].Namespace+"/"+releases[i].Name < releases[j].Namespace+"/"+releases[j].Name
+	})
+
+	return releases, nil
+}
+
+// GetHelmReleaseHistory returns all stored revisions of a release, newest first.
+func (c *Client) GetHelmReleaseHistory(namespace, name string) ([]HelmRelease, error) {
+	history, err := c.listHelmRevisions(namespace, name)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(history) == 0 {
+		return nil, fmt.Errorf("helm release %q not found", name)
+	}
+
+	sort.Slice(history, func(i, j int) bool {
+		return history[i].Revision > history[j].Revision
+	})
+
+	return history, nil
+}
+
+func (c *Client) listHelmRevisions(namespace, name string) ([]HelmRelease, error) {
+	selector := "owner=helm"
+	if name != "" {
+		selector += ",name=" + name
+	}
+
+	secrets, err := c.Clientset.CoreV1().Secrets(namespace).List(c.Context, metav1.ListOptions{
+		LabelSelector: selector,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list helm secrets: %w", err)
+	}
+
+	var releases []HelmRelease
+	for _, secret := range secrets.Items {
+		if release := helmReleaseFromSecret(&secret); release != nil {
+			releases = append(releases, *release)
+		}
+	}
+
+	return releases, nil
+}
+
+func helmReleaseFromSecret(secret *corev1.Secret) *HelmRelease {
+	labels := secret.Labels
+	if labels["name"] == "" {
+		return nil
+	}
+
+	release := HelmRelease{
+		Name:      labels["name"],
+		Namespace: secret.Namespace,
+		Status:    labels["status"],
+	}
+	release.Revision, _ = strconv.Atoi(labels["version"])
+
+	// Fall back to label data if the payload cannot be decoded
+	if data, exists := secret.Data["release"]; exists {
+		if payload, err := decodeHelmRelease(data); err == nil {
+			release.Revision = payload.Version
+			release.Status = payload.Info.Status
+			release.Chart = payload.Chart.Metadata.Name
+			release.ChartVersion = payload.Chart.Metadata.Version
+			release.AppVersion = payload.Chart.Metadata.AppVersion
+			release.FirstDeployed = payload.Info.FirstDeployed
+			release.LastDeployed = payload.Info.LastDeployed
+			release.Description = payload.Info.Description
+			release.Notes = payload.Info.Notes
+			release.Manifest = payload.Manifest
+			release.CustomValues = len(payload.Config)
+			release.Images = extractImagesFromValues(payload.Config)
+		}
+	}
+
+	switch release.Status {
+	case "failed":
+		release.Flagged = true
+		release.Issues = append(release.Issues, "Last operation failed")
+	case "pending-install", "pending-upgrade", "pending-rollback":
+		release.Flagged = true
+		release.Issues = append(release.Issues, fmt.Sprintf("Release stuck in %s", release.Status))
+	}
+
+	return &release
+}
+
+// extractImagesFromValues walks user-supplied values looking for the common
+// image.repository/image.tag convention used by most charts.
+func extractImagesFromValues(values map[string]interface{}) []string {
+	var images []string
+
+	var walk func(node map[string]interface{})
+	walk = func(node map[string]interface{}) {
+		repository, hasRepository := node["repository"].(string)
+		if hasRepository && repository != "" {
+			image := repository
+			if tag, ok := node["tag"]; ok && fmt.Sprint(tag) != "" {
+				image += ":" + fmt.Sprint(tag)
+			}
+			images = append(images, image)
+		}
+
+		for _, value := range node {
+			if child, ok := value.(map[string]interface{}); ok {
+				walk(child)
+			}
+		}
+	}
+
+	if values != nil {
+		walk(values)
+	}
+
+	sort.Strings(images)
+	return images
+}
+
+func formatHelmStatus(status string) string {
+	if status == "" {
+		return "Unknown"
+	}
+	// Capitalize first letter (replacement for deprecated strings.Title)
+	return strings.ToUpper(status[:1]) + strings.ToLower(status[1:])
+}
@@ -0,0 +1,477 @@
+package kubernetes
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const defaultRegistry = "docker.io"
+
+// ImageReference is a parsed container image reference of the form
+// [registry[:port]/]repository[:tag][@digest].
+type ImageReference struct {
+	Registry   string
+	Repository string
+	Tag        string
+	Digest     string
+}
+
+type ImageInventory struct {
+	Images       []ImageInfo
+	TagConflicts []ImageTagConflict
+	PullFailures []ImagePullFailure
+	Summary      ImageSummary
+}
+
+type ImageInfo struct {
+	Image        string
+	Registry     string
+	Repository   string
+	Tag          string
+	Digest       string
+	PullPolicies []string
+	Workloads    []string
+	Namespaces   []string
+	Nodes        int
+	PullFailures int
+	Severity     string
+	Issues       []string
+}
+
+type ImageTagConflict struct {
+	Repository string
+	Tags       []string
+	Namespaces []string
+}
+
+type ImagePullFailure struct {
+	Image     string
+	Namespace string
+	Object    string
+	Reason    string
+	Count     int32
+	LastSeen  time.Time
+}
+
+type ImageSummary struct {
+	TotalImages        int
+	TotalRegistries    int
+	DigestPinned       int
+	LatestOrUntagged   int
+	DisallowedRegistry int
+	TagConflicts       int
+	PullFailures       int
+}
+
+// parseImageReference splits an image reference into its parts following the
+// Docker reference grammar: the first path component is only treated as a
+// registry if it contains a "." or ":" or is "localhost".
+func parseImageReference(image string) ImageReference {
+	ref := ImageReference{}
+	name := strings.TrimSpace(image)
+
+	if i := strings.Index(name, "@"); i >= 0 {
+		ref.Digest = name[i+1:]
+		name = name[:i]
+	}
+
+	if i := strings.LastIndex(name, ":"); i > strings.LastIndex(name, "/") {
+		ref.Tag = name[i+1:]
+		name = name[:i]
+	}
+
+	if i := strings.Index(name, "/"); i >= 0 {
+		first := name[:i]
+		if strings.ContainsAny(first, ".:") || first == "localhost" {
+			ref.Registry = first
+			name = name[i+1:]
+		}
+	}
+
+	if ref.Registry == "" || ref.Registry == "index.docker.io" || ref.Registry == "registry-1.docker.io" {
+		ref.Registry = defaultRegistry
+	}
+	if ref.Registry == defaultRegistry && !strings.Contains(name, "/") {
+		name = "library/" + name
+	}
+
+	ref.Repository = name
+	return ref
+}
+
+// Name returns the fully qualified repository, without tag or digest.
+func (ref ImageReference) Name() string {
+	return ref.Registry + "/" + ref.Repository
+}
+
+// Reference returns the tag and/or digest the image is pulled by.
+func (ref ImageReference) Reference() string {
+	switch {
+	case ref.Tag != "" && ref.Digest != "":
+		return ref.Tag + "@" + shortDigest(ref.Digest)
+	case ref.Digest != "":
+		return "@" + shortDigest(ref.Digest)
+	case ref.Tag != "":
+		return ref.Tag
+	default:
+		return "<none>"
+	}
+}
+
+// IsFloating reports whether the image resolves through "latest", either
+// explicitly or because no tag or digest was given.
+func (ref ImageReference) IsFloating() bool {
+	return ref.Digest == "" && (ref.Tag == "" || ref.Tag == "latest")
+}
+
+func shortDigest(digest string) string {
+	algorithm, hex, found := strings.Cut(digest, ":")
+	if !found || len(hex) <= 12 {
+		return digest
+	}
+	return algorithm + ":" + hex[:12]
+}
+
+// GetImageInventory lists every distinct image used by pods, flagging floating
+// tags, registries outside allowedRegistries (if any are given), tag drift of a
+// repository across namespaces and image pull failures.
+func (c *Client) GetImageInventory(namespace string, allowedRegistries []string) (*ImageInventory, error) {
+	pods, err := c.Clientset.CoreV1().Pods(namespace).List(c.Context, metav1.ListOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get pods: %w", err)
+	}
+
+	events, err := c.Clientset.CoreV1().Events(namespace).List(c.Context, metav1.ListOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get events: %w", err)
+	}
+
+	inventory := buildImageInventory(pods.Items, allowedRegistries)
+	inventory.PullFailures = findImagePullFailures(pods.Items, events.Items)
+
+	failuresByImage := make(map[string]int)
+	for _, failure := range inventory.PullFailures {
+		failuresByImage[failure.Image] += int(failure.Count)
+	}
+	for i := range inventory.Images {
+		info := &inventory.Images[i]
+		if count := failuresByImage[info.Image]; count > 0 {
+			info.PullFailures = count
+			info.Issues = append(info.Issues, fmt.Sprintf("%d image pull failures", count))
+			info.Severity = "High"
+		}
+	}
+
+	sortImageInventory(inventory.Images)
+	inventory.Summary = calculateImageSummary(inventory, allowedRegistries)
+
+	return inventory, nil
+}
+
+func buildImageInventory(pods []corev1.Pod, allowedRegistries []string) *ImageInventory {
+	type imageUsage struct {
+		info         ImageInfo
+		pullPolicies map[string]bool
+		workloads    map[string]bool
+		namespaces   map[string]bool
+		nodes        map[string]bool
+	}
+
+	usages := make(map[string]*imageUsage)
+	var order []string
+
+	for _, pod := range pods {
+		containers := append([]corev1.Container{}, pod.Spec.InitContainers...)
+		containers = append(containers, pod.Spec.Containers...)
+
+		for _, container := range containers {
+			if container.Image == "" {
+				continue
+			}
+
+			usage, exists := usages[container.Image]
+			if !exists {
+				ref := parseImageReference(container.Image)
+				usage = &imageUsage{
+					info: ImageInfo{
+						Image:      container.Image,
+						Registry:   ref.Registry,
+						Repository: ref.Repository,
+						Tag:        ref.Tag,
+						Digest:     ref.Digest,
+					},
+					pullPolicies: make(map[string]bool),
+					workloads:    make(map[string]bool),
+					namespaces:   make(map[string]bool),
+					nodes:        make(map[string]bool),
+				}
+				usages[container.Image] = usage
+				order = append(order, container.Image)
+			}
+
+			if container.ImagePullPolicy != "" {
+				usage.pullPolicies[string(container.ImagePullPolicy)] = true
+			}
+			usage.workloads[pod.Namespace+"/"+getPodWorkload(&pod)] = true
+			usage.namespaces[pod.Namespace] = true
+			if pod.Spec.NodeName != "" {
+				usage.nodes[pod.Spec.NodeName] = true
+			}
+		}
+	}
+
+	inventory := &ImageInventory{}
+	for _, image := range order {
+		usage := usages[image]
+		info := usage.info
+		info.PullPolicies = sortedKeys(usage.pullPolicies)
+		info.Workloads = sortedKeys(usage.workloads)
+		info.Namespaces = sortedKeys(usage.namespaces)
+		info.Nodes = len(usage.nodes)
+		info.Severity = "None"
+
+		ref := ImageReference{Registry: info.Registry, Repository: info.Repository, Tag: info.Tag, Digest: info.Digest}
+		if ref.IsFloating() {
+			if info.Tag == "" {
+				info.Issues = append(info.Issues, "No tag specified (defaults to latest)")
+			} else {
+				info.Issues = append(info.Issues, "Uses the latest tag")
+			}
+			info.Severity = "Medium"
+		}
+
+		if len(allowedRegistries) > 0 && !isRegistryAllowed(ref, allowedRegistries) {
+			info.Issues = append(info.Issues, fmt.Sprintf("Registry %s is not allowlisted", info.Registry))
+			info.Severity = "High"
+		}
+
+		inventory.Images = append(inventory.Images, info)
+	}
+
+	inventory.TagConflicts = findImageTagConflicts(inventory.Images)
+	conflicting := make(map[string]bool)
+	for _, conflict := range inventory.TagConflicts {
+		conflicting[conflict.Repository] = true
+	}
+	for i := range inventory.Images {
+		info := &inventory.Images[i]
+		if conflicting[info.Registry+"/"+info.Repository] && info.Tag != "" {
+			info.Issues = append(info.Issues, "Other namespaces run a different tag of this repository")
+			if info.Severity == "None" {
+				info.Severity = "Low"
+			}
+		}
+	}
+
+	return inventory
+}
+
+// isRegistryAllowed matches allowlist entries against the registry
+// ("ghcr.io") or a repository prefix ("ghcr.io/my-org").
+func isRegistryAllowed(ref ImageReference, allowedRegistries []string) bool {
+	name := ref.Name()
+	for _, allowed := range allowedRegistries {
+		allowed = strings.TrimSuffix(strings.TrimSpace(allowed), "/")
+		if allowed == "" {
+			continue
+		}
+		if ref.Registry == allowed || strings.HasPrefix(name, allowed+"/") {
+			return true
+		}
+	}
+	return false
+}
+
+func findImageTagConflicts(images []ImageInfo) []ImageTagConflict {
+	tagsByRepository := make(map[string]map[string]bool)
+	namespacesByRepository := make(map[string]map[string]bool)
+
+	for _, info := range images {
+		if info.Tag == "" {
+			continue
+		}
+		repository := info.Registry + "/" + info.Repository
+		if tagsByRepository[repository] == nil {
+			tagsByRepository[repository] = make(map[string]bool)
+			namespacesByRepository[repository] = make(map[string]bool)
+		}
+		tagsByRepository[repository][info.Tag] = true
+		for _, ns := range info.Namespaces {
+			namespacesByRepository[repository][ns] = true
+		}
+	}
+
+	var conflicts []ImageTagConflict
+	for repository, tags := range tagsByRepository {
+		if len(tags) < 2 || len(namespacesByRepository[repository]) < 2 {
+			continue
+		}
+		conflicts = append(conflicts, ImageTagConflict{
+			Repository: repository,
+			Tags:       sortedKeys(tags),
+			Namespaces: sortedKeys(namespacesByRepository[repository]),
+		})
+	}
+
+	sort.Slice(conflicts, func(i, j int) bool {
+		return conflicts[i].Repository < conflicts[j].Repository
+	})
+
+	return conflicts
+}
+
+// findImagePullFailures combines pods currently stuck pulling an image with
+// the pull failure history still retained in events.
+func findImagePullFailures(pods []corev1.Pod, events []corev1.Event) []ImagePullFailure {
+	var failures []ImagePullFailure
+	seen := make(map[string]bool)
+
+	for _, event := range events {
+		if event.InvolvedObject.Kind != "Pod" {
+			continue
+		}
+
+		image := extractImageFromPullMessage(event.Message)
+		if image == "" || (event.Reason != "Failed" && event.Reason != "BackOff") {
+			continue
+		}
+
+		count := event.Count
+		if count == 0 {
+			count = 1
+		}
+
+		lastSeen := event.LastTimestamp.Time
+		if lastSeen.IsZero() {
+			lastSeen = event.EventTime.Time
+		}
+
+		failures = append(failures, ImagePullFailure{
+			Image:     image,
+			Namespace: event.Namespace,
+			Object:    event.InvolvedObject.Name,
+			Reason:    event.Reason,
+			Count:     count,
+			LastSeen:  lastSeen,
+		})
+		seen[event.Namespace+"/"+event.InvolvedObject.Name+"/"+image] = true
+	}
+
+	for _, pod := range pods {
+		statuses := append([]corev1.ContainerStatus{}, pod.Status.InitContainerStatuses...)
+		statuses = append(statuses, pod.Status.ContainerStatuses...)
+
+		for _, status := range statuses {
+			if status.State.Waiting == nil {
+				continue
+			}
+			reason := status.State.Waiting.Reason
+			if reason != "ImagePullBackOff" && reason != "ErrImagePull" && reason != "InvalidImageName" {
+				continue
+			}
+			if seen[pod.Namespace+"/"+pod.Name+"/"+status.Image] {
+				continue
+			}
+
+			failures = append(failures, ImagePullFailure{
+				Image:     status.Image,
+				Namespace: pod.Namespace,
+				Object:    pod.Name,
+				Reason:    reason,
+				Count:     1,
+				LastSeen:  time.Now(),
+			})
+		}
+	}
+
+	sort.Slice(failures, func(i, j int) bool {
+		return failures[i].Count > failures[j].Count
+	})
+
+	return failures
+}
+
+// extractImageFromPullMessage extracts the quoted image from kubelet messages
+// such as `Failed to pull image "nginx:1.2": ...` or `Back-off pulling image "nginx:1.2"`.
+func extractImageFromPullMessage(message string) string {
+	for _, prefix := range []string{"Failed to pull image \"", "Back-off pulling image \""} {
+		start := strings.Index(message, prefix)
+		if start < 0 {
+			continue
+		}
+		rest := message[start+len(prefix):]
+		if end := strings.Index(rest, "\""); end > 0 {
+			return rest[:end]
+		}
+	}
+	return ""
+}
+
+// getPodWorkload resolves the top-level workload of a pod, mapping
+// ReplicaSets created by a Deployment back to the Deployment.
+func getPodWorkload(pod *corev1.Pod) string {
+	owner := getPodOwner(pod)
+	if hash := pod.Labels["pod-template-hash"]; hash != "" && strings.HasPrefix(owner, "ReplicaSet/") {
+		return "Deployment/" + strings.TrimSuffix(strings.TrimPrefix(owner, "ReplicaSet/"), "-"+hash)
+	}
+	if owner == "<none>" {
+		return "Pod/" + pod.Name
+	}
+	return owner
+}
+
+func sortImageInventory(images []ImageInfo) {
+	sort.SliceStable(images, func(i, j int) bool {
+		if severityRank(images[i].Severity) != severityRank(images[j].Severity) {
+			return severityRank(images[i].Severity) > severityRank(images[j].Severity)
+		}
+		return images[i].Image < images[j].Image
+	})
+}
+
+func calculateImageSummary(inventory *ImageInventory, allowedRegistries []string) ImageSummary {
+	summary := ImageSummary{
+		TotalImages:  len(inventory.Images),
+		TagConflicts: len(inventory.TagConflicts),
+	}
+
+	// A pod can have several failure entries (one per event or image)
+	failingPods := make(map[string]bool)
+	for _, failure := range inventory.PullFailures {
+		failingPods[failure.Namespace+"/"+failure.Object] = true
+	}
+	summary.PullFailures = len(failingPods)
+
+	registries := make(map[string]bool)
+	for _, info := range inventory.Images {
+		registries[info.Registry] = true
+		if info.Digest != "" {
+			summary.DigestPinned++
+		}
+
+		ref := ImageReference{Registry: info.Registry, Repository: info.Repository, Tag: info.Tag, Digest: info.Digest}
+		if ref.IsFloating() {
+			summary.LatestOrUntagged++
+		}
+		if len(allowedRegistries) > 0 && !isRegistryAllowed(ref, allowedRegistries) {
+			summary.DisallowedRegistry++
+		}
+	}
+	summary.TotalRegistries = len(registries)
+
+	return summary
+}
+
+func sortedKeys(set map[string]bool) []string {
+	keys := make([]string, 0, len(set))
+	for key := range set {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -1,7 +1,1804 @@
 package kubernetes
 
-import "testing"
+import (
+	"bytes"
+	"compress/gzip"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+	"unicode/utf8"
+
+	appsv1 "k8s.io/api/apps/v1"
+	autoscalingv2 "k8s.io/api/autoscaling/v2"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	discoveryv1 "k8s.io/api/discovery/v1"
+	eventsv1 "k8s.io/api/events/v1"
+	networkingv1 "k8s.io/api/networking/v1"
+	policyv1 "k8s.io/api/policy/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/util/intstr"
+)
 
 func TestKubernetesDummy(t *testing.T) {
 	t.Log("kubernetes test running")
 }
+
+func TestAnalyzeNetworkPolicyCoverage(t *testing.T) {
+	pods := []corev1.Pod{
+		{ObjectMeta: metav1.ObjectMeta{Name: "web", Namespace: "app", Labels: map[string]string{"app": "web"}}},
+		{ObjectMeta: metav1.ObjectMeta{Name: "db", Namespace: "app", Labels: map[string]string{"app": "db"}}},
+		{ObjectMeta: metav1.ObjectMeta{Name: "api", Namespace: "open"}},
+	}
+	policies := []networkingv1.NetworkPolicy{
+		{
+			ObjectMeta: metav1.ObjectMeta{Name: "web-only", Namespace: "app"},
+			Spec:       networkingv1.NetworkPolicySpec{PodSelector: metav1.LabelSelector{MatchLabels: map[string]string{"app": "web"}}},
+		},
+	}
+
+	coverage, uncovered := analyzeNetworkPolicyCoverage([]string{"app", "open"}, pods, policies)
+
+	if len(uncovered) != 2 {
+		t.Fatalf("expected 2 uncovered pods, got %d", len(uncovered))
+	}
+
+	statuses := map[string]string{}
+	for _, ns := range coverage {
+		statuses[ns.Namespace] = ns.Status
+	}
+	if statuses["app"] != "Partial" {
+		t.Errorf("expected namespace app to be Partial, got %s", statuses["app"])
+	}
+	if statuses["open"] != "Uncovered" {
+		t.Errorf("expected namespace open to be Uncovered, got %s", statuses["open"])
+	}
+
+	denyAll := networkingv1.NetworkPolicy{ObjectMeta: metav1.ObjectMeta{Name: "deny", Namespace: "app"}}
+	allowAll := networkingv1.NetworkPolicy{
+		ObjectMeta: metav1.ObjectMeta{Name: "allow", Namespace: "open"},
+		Spec:       networkingv1.NetworkPolicySpec{Ingress: []networkingv1.NetworkPolicyIngressRule{{}}},
+	}
+	denyEgress := networkingv1.NetworkPolicy{
+		ObjectMeta: metav1.ObjectMeta{Name: "deny-egress", Namespace: "open"},
+		Spec: networkingv1.NetworkPolicySpec{
+			PolicyTypes: []networkingv1.PolicyType{networkingv1.PolicyTypeIngress, networkingv1.PolicyTypeEgress},
+			Ingress:     []networkingv1.NetworkPolicyIngressRule{{}},
+		},
+	}
+	if !isDefaultDenyPolicy(&denyAll) || isDefaultDenyPolicy(&allowAll) || !isDefaultDenyPolicy(&denyEgress) {
+		t.Errorf("expected only policies that allow nothing for a policy type to be default-deny")
+	}
+	coverage, _ = analyzeNetworkPolicyCoverage([]string{"open"}, pods, []networkingv1.NetworkPolicy{allowAll})
+	if coverage[0].DefaultDeny {
+		t.Errorf("expected an allow-all policy not to count as default-deny")
+	}
+
+	notReady := false
+	slices := []discoveryv1.EndpointSlice{
+		{
+			ObjectMeta: metav1.ObjectMeta{Name: "web-abc", Namespace: "app", Labels: map[string]string{discoveryv1.LabelServiceName: "web"}},
+			Endpoints:  []discoveryv1.Endpoint{{Addresses: []string{"10.0.0.1"}}},
+		},
+		{
+			ObjectMeta: metav1.ObjectMeta{Name: "db-abc", Namespace: "app", Labels: map[string]string{discoveryv1.LabelServiceName: "db"}},
+			Endpoints:  []discoveryv1.Endpoint{{Addresses: []string{"10.0.0.2"}, Conditions: discoveryv1.EndpointConditions{Ready: &notReady}}},
+		},
+	}
+	services := []corev1.Service{
+		{ObjectMeta: metav1.ObjectMeta{Name: "web", Namespace: "app"}, Spec: corev1.ServiceSpec{Selector: map[string]string{"app": "web"}}},
+		{ObjectMeta: metav1.ObjectMeta{Name: "db", Namespace: "app"}, Spec: corev1.ServiceSpec{Selector: map[string]string{"app": "db"}}},
+		{ObjectMeta: metav1.ObjectMeta{Name: "legacy", Namespace: "app"}},
+	}
+	issues := make(map[string]string)
+	for _, issue := range findServicesWithoutEndpoints(services, pods, readyServiceEndpoints(slices)) {
+		issues[issue.Name] = issue.Issue
+	}
+	if len(issues) != 2 || issues["db"] != "1 matching pods but no ready endpoints" || issues["legacy"] != "No selector and no endpoints" {
+		t.Errorf("unexpected service endpoint issues %v", issues)
+	}
+}
+
+func TestFindDeprecatedAPIsInManifests(t *testing.T) {
+	manifest := `apiVersion: batch/v1beta1
+kind: CronJob
+metadata:
+  name: nightly
+  namespace: jobs
+---
+apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: web
+---
+apiVersion: flowcontrol.apiserver.k8s.io/v1beta3
+kind: FlowSchema
+metadata:
+  name: custom
+`
+
+	findings, err := FindDeprecatedAPIsInManifests(strings.NewReader(manifest), "test", "1.29")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(findings) != 2 {
+		t.Fatalf("expected 2 findings, got %d: %+v", len(findings), findings)
+	}
+	if findings[0].Kind != "CronJob" || findings[0].Severity != "High" || findings[0].Replacement != "batch/v1" {
+		t.Errorf("unexpected CronJob finding: %+v", findings[0])
+	}
+	if findings[1].Kind != "FlowSchema" || findings[1].Severity != "Low" {
+		t.Errorf("expected FlowSchema to be deprecated but not removed in 1.29: %+v", findings[1])
+	}
+}
+
+func TestParseMinorVersion(t *testing.T) {
+	cases := map[string]int{"1.32": 32, "v1.28.3": 28, "1.27+": 27}
+	for input, expected := range cases {
+		minor, err := parseMinorVersion(input)
+		if err != nil || minor != expected {
+			t.Errorf("parseMinorVersion(%q) = %d, %v; want %d", input, minor, err, expected)
+		}
+	}
+
+	if _, err := parseMinorVersion("2.0"); err == nil {
+		t.Error("expected error for unsupported major version")
+	}
+}
+
+func TestDecodeHelmRelease(t *testing.T) {
+	payload := `{"name":"web","namespace":"apps","version":3,"info":{"status":"pending-upgrade"},"chart":{"metadata":{"name":"nginx","version":"15.1.0","appVersion":"1.25.3"}},"config":{"image":{"repository":"nginx","tag":"1.25.3"}}}`
+
+	var compressed bytes.Buffer
+	writer := gzip.NewWriter(&compressed)
+	writer.Write([]byte(payload))
+	writer.Close()
+
+	secret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "sh.helm.release.v1.web.v3",
+			Namespace: "apps",
+			Labels:    map[string]string{"owner": "helm", "name": "web", "status": "pending-upgrade", "version": "3"},
+		},
+		Data: map[string][]byte{
+			"release": []byte(base64.StdEncoding.EncodeToString(compressed.Bytes())),
+		},
+	}
+
+	release := helmReleaseFromSecret(secret)
+	if release == nil {
+		t.Fatal("expected release to be decoded")
+	}
+	if release.Chart != "nginx" || release.ChartVersion != "15.1.0" || release.AppVersion != "1.25.3" {
+		t.Errorf("unexpected chart metadata: %+v", release)
+	}
+	if !release.Flagged {
+		t.Error("expected pending-upgrade release to be flagged")
+	}
+	if len(release.Images) != 1 || release.Images[0] != "nginx:1.25.3" {
+		t.Errorf("unexpected images from values: %v", release.Images)
+	}
+}
+
+func TestEvaluateComponentVersions(t *testing.T) {
+	catalog, err := LoadComponentCatalog(filepath.Join(t.TempDir(), "missing.json"))
+	if err == nil {
+		t.Fatal("expected error for explicit missing catalog file")
+	}
+
+	catalog = &ComponentCatalog{}
+	if err := json.Unmarshal(embeddedComponentCatalog, catalog); err != nil {
+		t.Fatalf("embedded catalog is invalid: %v", err)
+	}
+
+	components := []ComponentInfo{
+		{Name: "ingress-nginx-controller", Namespace: "ingress", Version: "v1.11.2", Image: "registry.k8s.io/ingress-nginx/controller:v1.11.2"},
+		{Name: "cert-manager", Namespace: "cert-manager", Version: "v1.16.1", Source: "Helm", Chart: "cert-manager", AppVersion: "v1.16.1"},
+		{Name: "ingress-nginx", Namespace: "edge", Version: "4.11.2", Source: "Helm", Chart: "ingress-nginx", ChartVersion: "4.11.2"},
+		{Name: "my-app", Namespace: "default", Version: "1.0.0", Image: "example.com/my-app:1.0.0"},
+	}
+
+	now := time.Date(2025, 8, 1, 0, 0, 0, 0, time.UTC)
+	statuses := EvaluateComponentVersions(components, catalog, "1.30", now)
+	for image, expected := range map[string]string{
+		"traefik:v3.1@sha256:abc":                    "traefik",
+		"localhost:5000/ingress-nginx/controller:v1": "ingress-nginx",
+	} {
+		if entry := catalog.lookup(&ComponentInfo{Image: image}); entry == nil || entry.Name != expected {
+			t.Errorf("expected %s to match catalog entry %s, got %+v", image, expected, entry)
+		}
+	}
+
+	// The chart-only ingress-nginx release is not compared against app versions
+	if len(statuses) != 2 {
+		t.Fatalf("expected 2 catalog matches, got %d: %+v", len(statuses), statuses)
+	}
+
+	if statuses[0].CatalogName != "ingress-nginx" || statuses[0].Status != "Vulnerable" {
+		t.Errorf("expected ingress-nginx v1.11.2 to be vulnerable, got %+v", statuses[0])
+	}
+	if statuses[1].CatalogName != "cert-manager" || statuses[1].Status != "EOL" {
+		t.Errorf("expected cert-manager v1.16.1 to be EOL, got %+v", statuses[1])
+	}
+}
+
+func TestParseImageReference(t *testing.T) {
+	tests := []struct {
+		image    string
+		expected ImageReference
+	}{
+		{"nginx", ImageReference{Registry: "docker.io", Repository: "library/nginx"}},
+		{"nginx:1.25", ImageReference{Registry: "docker.io", Repository: "library/nginx", Tag: "1.25"}},
+		{"bitnami/redis:7.2", ImageReference{Registry: "docker.io", Repository: "bitnami/redis", Tag: "7.2"}},
+		{"localhost:5000/app", ImageReference{Registry: "localhost:5000", Repository: "app"}},
+		{"registry.example.com:5000/team/app:v2", ImageReference{Registry: "registry.example.com:5000", Repository: "team/app", Tag: "v2"}},
+		{"ghcr.io/org/app@sha256:abcdef0123456789", ImageReference{Registry: "ghcr.io", Repository: "org/app", Digest: "sha256:abcdef0123456789"}},
+		{"quay.io/org/app:1.0@sha256:abcdef", ImageReference{Registry: "quay.io", Repository: "org/app", Tag: "1.0", Digest: "sha256:abcdef"}},
+	}
+
+	for _, tt := range tests {
+		if got := parseImageReference(tt.image); got != tt.expected {
+			t.Errorf("parseImageReference(%q) = %+v, expected %+v", tt.image, got, tt.expected)
+		}
+	}
+
+	versions := map[string]string{
+		"nginx":                                   "latest",
+		"localhost:5000/app":                      "latest",
+		"registry.example.com:5000/app:v1.2.3":    "v1.2.3",
+		"ghcr.io/org/app@sha256:abcdef0123456789": "@sha256:abcdef012345",
+	}
+	for image, expected := range versions {
+		if got := extractVersionFromImage(image); got != expected {
+			t.Errorf("extractVersionFromImage(%q) = %q, expected %q", image, got, expected)
+		}
+	}
+}
+
+func TestBuildImageInventory(t *testing.T) {
+	pod := func(name, namespace, node, image string) corev1.Pod {
+		return corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: namespace},
+			Spec: corev1.PodSpec{
+				NodeName:   node,
+				Containers: []corev1.Container{{Name: "app", Image: image, ImagePullPolicy: corev1.PullIfNotPresent}},
+			},
+		}
+	}
+
+	pods := []corev1.Pod{
+		pod("web-1", "prod", "node-a", "ghcr.io/acme/web:1.2.0"),
+		pod("web-2", "prod", "node-b", "ghcr.io/acme/web:1.2.0"),
+		pod("web-1", "staging", "node-a", "ghcr.io/acme/web:1.3.0"),
+		pod("debug", "default", "node-a", "busybox"),
+	}
+
+	inventory := buildImageInventory(pods, []string{"ghcr.io/acme"})
+	if len(inventory.Images) != 3 {
+		t.Fatalf("expected 3 distinct images, got %d", len(inventory.Images))
+	}
+
+	byImage := make(map[string]ImageInfo)
+	for _, info := range inventory.Images {
+		byImage[info.Image] = info
+	}
+
+	if info := byImage["ghcr.io/acme/web:1.2.0"]; info.Nodes != 2 || len(info.Workloads) != 2 || info.Severity != "Low" {
+		t.Errorf("unexpected inventory for web:1.2.0: %+v", info)
+	}
+	if info := byImage["busybox"]; info.Severity != "High" || len(info.Issues) != 2 {
+		t.Errorf("expected busybox to be untagged and from a disallowed registry, got %+v", info)
+	}
+	if len(inventory.TagConflicts) != 1 || inventory.TagConflicts[0].Repository != "ghcr.io/acme/web" {
+		t.Errorf("expected a tag conflict for ghcr.io/acme/web, got %+v", inventory.TagConflicts)
+	}
+
+	message := `Failed to pull image "ghcr.io/acme/web:9.9": rpc error: not found`
+	if image := extractImageFromPullMessage(message); image != "ghcr.io/acme/web:9.9" {
+		t.Errorf("unexpected image from pull message: %q", image)
+	}
+
+	stuck := pod("api-1", "prod", "node-a", "ghcr.io/acme/api:9.9")
+	stuck.Status.ContainerStatuses = []corev1.ContainerStatus{{Image: "ghcr.io/acme/api:9.9", State: corev1.ContainerState{Waiting: &corev1.ContainerStateWaiting{Reason: "ImagePullBackOff"}}}}
+	events := []corev1.Event{
+		{InvolvedObject: corev1.ObjectReference{Kind: "Pod", Name: "api-1"}, ObjectMeta: metav1.ObjectMeta{Namespace: "prod"}, Reason: "Failed", Message: `Failed to pull image "ghcr.io/acme/api:9.9": not found`},
+		{InvolvedObject: corev1.ObjectReference{Kind: "Pod", Name: "api-1"}, ObjectMeta: metav1.ObjectMeta{Namespace: "prod"}, Reason: "BackOff", Message: `Back-off pulling image "ghcr.io/acme/api:9.9"`},
+	}
+	inventory.PullFailures = findImagePullFailures([]corev1.Pod{stuck}, events)
+	if summary := calculateImageSummary(inventory, nil); len(inventory.PullFailures) != 2 || summary.PullFailures != 1 {
+		t.Errorf("expected 2 failure entries for 1 pod, got %d entries and %d pods", len(inventory.PullFailures), summary.PullFailures)
+	}
+}
+
+func TestEventThrottler(t *testing.T) {
+	start := time.Date(2025, 8, 1, 12, 0, 0, 0, time.UTC)
+	throttler := newEventThrottler(5*time.Minute, 2, time.Minute)
+
+	if allowed, _ := throttler.allow("a", start); !allowed {
+		t.Fatal("expected first event to be emitted")
+	}
+	if allowed, _ := throttler.allow("a", start.Add(time.Second)); allowed {
+		t.Error("expected duplicate within dedup window to be suppressed")
+	}
+	if allowed, _ := throttler.allow("b", start.Add(2*time.Second)); !allowed {
+		t.Error("expected second distinct event to be emitted")
+	}
+	if allowed, _ := throttler.allow("c", start.Add(3*time.Second)); allowed {
+		t.Error("expected third event in the same minute to be rate limited")
+	}
+
+	allowed, suppressed := throttler.allow("a", start.Add(6*time.Minute))
+	if !allowed || suppressed != 2 {
+		t.Errorf("expected event after the window with 2 suppressed, got allowed=%v suppressed=%d", allowed, suppressed)
+	}
+
+	// Events not seen within the window are forgotten
+	for i := 0; i < 50; i++ {
+		throttler.allow(fmt.Sprintf("unique-%d", i), start.Add(7*time.Minute+time.Duration(i)*time.Minute))
+		throttler.allow(fmt.Sprintf("unique-%d", i), start.Add(7*time.Minute+time.Duration(i)*time.Minute+time.Second))
+	}
+	if len(throttler.lastSeen) > 6 || len(throttler.repeats) > 6 {
+		t.Errorf("expected stale events to be pruned, got %d last seen and %d repeats", len(throttler.lastSeen), len(throttler.repeats))
+	}
+}
+
+func TestDiffPodStates(t *testing.T) {
+	pod := &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{Name: "api-1", Namespace: "prod"},
+		Status: corev1.PodStatus{
+			ContainerStatuses: []corev1.ContainerStatus{{
+				Name:  "api",
+				State: corev1.ContainerState{Waiting: &corev1.ContainerStateWaiting{Reason: "CrashLoopBackOff"}},
+			}},
+		},
+	}
+
+	known := make(map[string]string)
+	events := diffPodStates(pod, known, false)
+	if len(events) != 1 || events[0].Reason != "CrashLoopBackOff" || events[0].Severity != "Warning" || events[0].Object != "Pod/api-1/api" {
+		t.Fatalf("unexpected events for crashing pod: %+v", events)
+	}
+
+	if events := diffPodStates(pod, known, false); len(events) != 0 {
+		t.Errorf("expected no events for unchanged state, got %+v", events)
+	}
+
+	pod.Status.ContainerStatuses[0].State = corev1.ContainerState{Running: &corev1.ContainerStateRunning{}}
+	diffPodStates(pod, known, false)
+	if len(known) != 0 {
+		t.Errorf("expected recovered container to be forgotten, got %v", known)
+	}
+}
+
+func TestClassifyLogLines(t *testing.T) {
+	rules, err := compileLogRules(DefaultLogRules())
+	if err != nil {
+		t.Fatalf("default rules do not compile: %v", err)
+	}
+
+	logs := strings.Join([]string{
+		"2025-08-01T10:00:00Z INFO starting server on :8080",
+		"2025-08-01T10:00:01Z dial tcp 10.0.0.12:5432: connect: connection refused",
+		"2025-08-01T10:00:02Z dial tcp 10.0.0.13:5432: connect: connection refused",
+		"panic: runtime error: invalid memory address or nil pointer dereference",
+		"goroutine 1 [running]:",
+		"2025-08-01T10:00:03Z level=error msg=\"request failed\" id=42",
+	}, "\n")
+
+	summary, matches, err := classifyLogLines(strings.NewReader(logs), rules)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if summary.Lines != 6 || summary.CriticalCount != 2 || summary.WarningCount != 3 {
+		t.Errorf("unexpected summary: %+v", summary)
+	}
+	if summary.Rules["connection-refused"] != 2 || summary.Rules["panic"] != 1 || summary.Rules["stack-trace"] != 1 {
+		t.Errorf("unexpected rule counts: %v", summary.Rules)
+	}
+
+	aggregator := newLogPatternAggregator(3)
+	for _, match := range matches {
+		aggregator.add(match, ContainerLogSummary{Pod: "api-1", Namespace: "prod", Container: "api"})
+	}
+	patterns := aggregator.patterns()
+	if len(patterns) != 4 {
+		t.Fatalf("expected 4 patterns, got %d: %+v", len(patterns), patterns)
+	}
+
+	var refused *LogPattern
+	for i := range patterns {
+		if patterns[i].Rule == "connection-refused" {
+			refused = &patterns[i]
+		}
+	}
+	if refused == nil || refused.Count != 2 || len(refused.Samples) != 1 {
+		t.Errorf("expected connection refused lines to cluster with one sample per pod, got %+v", refused)
+	}
+	if refused != nil && refused.Signature != "dial tcp <ip>: connect: connection refused" {
+		t.Errorf("unexpected signature: %q", refused.Signature)
+	}
+
+	long := logSignature(strings.Repeat("ошибка ", 40))
+	if !utf8.ValidString(long) || utf8.RuneCountInString(long) != 160 {
+		t.Errorf("expected long signatures truncated to 160 runes, got %d runes (valid=%v)", utf8.RuneCountInString(long), utf8.ValidString(long))
+	}
+}
+
+func TestAggregateClusterEvents(t *testing.T) {
+	now := time.Date(2025, 8, 1, 12, 0, 0, 0, time.UTC)
+
+	seriesEvent := &eventsv1.Event{
+		ObjectMeta: metav1.ObjectMeta{Name: "api-1.abc", Namespace: "prod"},
+		EventTime:  metav1.NewMicroTime(now.Add(-50 * time.Minute)),
+		Series:     &eventsv1.EventSeries{Count: 12, LastObservedTime: metav1.NewMicroTime(now.Add(-5 * time.Minute))},
+		Reason:     "BackOff",
+		Note:       "Back-off restarting failed container",
+		Type:       "Warning",
+		Regarding:  corev1.ObjectReference{Kind: "Pod", Name: "api-1", Namespace: "prod"},
+	}
+
+	converted := clusterEventFromEventsV1(seriesEvent)
+	if converted.Count != 12 || !converted.LastTime.Equal(now.Add(-5*time.Minute)) || converted.Severity != "Warning" {
+		t.Fatalf("unexpected conversion of event series: %+v", converted)
+	}
+
+	// A second series for the same object and reason, started after the first ended
+	laterSeries := converted
+	laterSeries.Count = 3
+	laterSeries.FirstTime = now.Add(-2 * time.Minute)
+	laterSeries.LastTime = now.Add(-time.Minute)
+	laterSeries.Message = "Back-off restarting failed container api"
+
+	coreEvent := clusterEventFromCoreV1(&corev1.Event{
+		ObjectMeta:     metav1.ObjectMeta{Namespace: "prod"},
+		InvolvedObject: corev1.ObjectReference{Kind: "Node", Name: "node-1"},
+		Reason:         "NodeNotReady",
+		Type:           "Warning",
+		FirstTimestamp: metav1.NewTime(now.Add(-48 * time.Hour)),
+		LastTimestamp:  metav1.NewTime(now.Add(-47 * time.Hour)),
+	})
+
+	events := aggregateClusterEvents([]ClusterEvent{converted, coreEvent, laterSeries}, now.Add(-24*time.Hour))
+	if len(events) != 1 {
+		t.Fatalf("expected old event to be dropped and series merged, got %+v", events)
+	}
+	if events[0].Count != 15 || events[0].Message != laterSeries.Message || !events[0].FirstTime.Equal(now.Add(-50*time.Minute)) {
+		t.Errorf("unexpected aggregated event: %+v", events[0])
+	}
+}
+
+func TestBuildPodDiagnosis(t *testing.T) {
+	memoryLimit := resource.MustParse("256Mi")
+	pod := &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{Name: "api-1", Namespace: "prod"},
+		Spec: corev1.PodSpec{
+			NodeName: "node-a",
+			Containers: []corev1.Container{
+				{
+					Name:      "api",
+					Resources: corev1.ResourceRequirements{Limits: corev1.ResourceList{corev1.ResourceMemory: memoryLimit}},
+				},
+				{
+					Name: "worker",
+					LivenessProbe: &corev1.Probe{
+						ProbeHandler:     corev1.ProbeHandler{HTTPGet: &corev1.HTTPGetAction{Path: "/healthz", Port: intstr.FromInt32(8080)}},
+						PeriodSeconds:    10,
+						FailureThreshold: 3,
+					},
+				},
+				{Name: "loader"},
+			},
+		},
+		Status: corev1.PodStatus{
+			Phase: corev1.PodRunning,
+			ContainerStatuses: []corev1.ContainerStatus{
+				{
+					Name:                 "api",
+					RestartCount:         7,
+					State:                corev1.ContainerState{Waiting: &corev1.ContainerStateWaiting{Reason: "CrashLoopBackOff"}},
+					LastTerminationState: corev1.ContainerState{Terminated: &corev1.ContainerStateTerminated{ExitCode: 137, Reason: "OOMKilled"}},
+				},
+				{
+					Name:                 "worker",
+					RestartCount:         3,
+					LastTerminationState: corev1.ContainerState{Terminated: &corev1.ContainerStateTerminated{ExitCode: 137, Reason: "Error"}},
+				},
+				{
+					Name:                 "loader",
+					RestartCount:         2,
+					LastTerminationState: corev1.ContainerState{Terminated: &corev1.ContainerStateTerminated{ExitCode: 1, Reason: "Error"}},
+				},
+			},
+		},
+	}
+
+	events := []ClusterEvent{{Type: "Warning", Reason: "Unhealthy", Message: "Liveness probe failed: HTTP probe failed with statuscode: 500", Count: 9}}
+	logs := map[string][]string{"loader": {"starting loader", "open /etc/loader/config.yaml: no such file or directory"}}
+	nodeConditions := []corev1.NodeCondition{
+		{Type: corev1.NodeReady, Status: corev1.ConditionTrue},
+		{Type: corev1.NodeMemoryPressure, Status: corev1.ConditionTrue, Message: "kubelet has insufficient memory available"},
+	}
+
+	diagnosis := buildPodDiagnosis(pod, events, logs, nil, nodeConditions)
+
+	if len(diagnosis.Causes) == 0 || diagnosis.Causes[0].Container != "api" || diagnosis.Causes[0].Confidence != 95 {
+		t.Fatalf("expected OOMKilled to be the most likely cause, got %+v", diagnosis.Causes)
+	}
+
+	found := make(map[string]string)
+	for _, cause := range diagnosis.Causes {
+		found[cause.Container+"|"+cause.Title] = cause.Fix
+	}
+	for _, expected := range []string{
+		"worker|Liveness probe failures restart the container",
+		"loader|Application fails on missing configuration or files",
+		"|Node node-a is unhealthy or under resource pressure",
+	} {
+		if _, ok := found[expected]; !ok {
+			t.Errorf("expected cause %q, got %+v", expected, diagnosis.Causes)
+		}
+	}
+
+	if diagnosis.Containers[0].Signal != "SIGKILL" || diagnosis.Containers[0].State != "CrashLoopBackOff" {
+		t.Errorf("unexpected container diagnosis: %+v", diagnosis.Containers[0])
+	}
+}
+
+func TestExplainPendingPod(t *testing.T) {
+	allocatable := corev1.ResourceList{
+		corev1.ResourceCPU:    resource.MustParse("2"),
+		corev1.ResourceMemory: resource.MustParse("4Gi"),
+		corev1.ResourcePods:   resource.MustParse("110"),
+	}
+	node := func(name, zone string, taints []corev1.Taint) corev1.Node {
+		return corev1.Node{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Labels: map[string]string{"disk": "ssd", "topology.kubernetes.io/zone": zone}},
+			Spec:       corev1.NodeSpec{Taints: taints},
+			Status:     corev1.NodeStatus{Allocatable: allocatable},
+		}
+	}
+
+	nodes := []corev1.Node{
+		node("fits", "a", nil),
+		node("tainted", "a", []corev1.Taint{{Key: "dedicated", Value: "gpu", Effect: corev1.TaintEffectNoSchedule}}),
+		node("full", "a", nil),
+		node("hdd", "b", nil),
+		node("other-zone", "b", nil),
+	}
+	nodes[3].Labels["disk"] = "hdd"
+
+	request := func(cpu string) corev1.ResourceRequirements {
+		return corev1.ResourceRequirements{Requests: corev1.ResourceList{corev1.ResourceCPU: resource.MustParse(cpu)}}
+	}
+	running := []corev1.Pod{
+		{
+			ObjectMeta: metav1.ObjectMeta{Name: "hog", Namespace: "prod"},
+			Spec:       corev1.PodSpec{NodeName: "full", Containers: []corev1.Container{{Name: "hog", Resources: request("1800m")}}},
+			Status:     corev1.PodStatus{Phase: corev1.PodRunning},
+		},
+		{
+			ObjectMeta: metav1.ObjectMeta{Name: "done", Namespace: "prod"},
+			Spec:       corev1.PodSpec{NodeName: "fits", Containers: []corev1.Container{{Name: "done", Resources: request("2")}}},
+			Status:     corev1.PodStatus{Phase: corev1.PodSucceeded},
+		},
+	}
+
+	pending := &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{Name: "api", Namespace: "prod"},
+		Spec: corev1.PodSpec{
+			NodeSelector: map[string]string{"disk": "ssd"},
+			Containers:   []corev1.Container{{Name: "api", Resources: request("500m")}},
+		},
+		Status: corev1.PodStatus{
+			Phase: corev1.PodPending,
+			Conditions: []corev1.PodCondition{{
+				Type: corev1.PodScheduled, Status: corev1.ConditionFalse, Message: "0/5 nodes are available",
+			}},
+		},
+	}
+
+	volumes := []volumeConstraint{{
+		claim: "data",
+		nodeAffinity: &corev1.VolumeNodeAffinity{Required: &corev1.NodeSelector{NodeSelectorTerms: []corev1.NodeSelectorTerm{{
+			MatchExpressions: []corev1.NodeSelectorRequirement{{Key: "topology.kubernetes.io/zone", Operator: corev1.NodeSelectorOpIn, Values: []string{"a"}}},
+		}}}},
+	}}
+
+	explanation := explainPendingPod(pending, nodes, append(running, *pending), volumes)
+
+	if explanation.FittingNodes != 1 || explanation.SchedulerMessage != "0/5 nodes are available" {
+		t.Fatalf("unexpected explanation: %+v", explanation)
+	}
+
+	results := make(map[string]NodeFitResult)
+	for _, result := range explanation.Nodes {
+		results[result.Node] = result
+	}
+
+	expected := map[string]string{
+		"tainted":    "untolerated taint dedicated=gpu:NoSchedule",
+		"full":       "insufficient cpu (requests 500m, free 200m)",
+		"hdd":        "nodeSelector disk=ssd does not match",
+		"other-zone": "volume of PVC data cannot attach here (node in b)",
+	}
+	for name, reason := range expected {
+		result := results[name]
+		if result.Fits || len(result.Reasons) == 0 || result.Reasons[0] != reason {
+			t.Errorf("expected %s to fail with %q, got %+v", name, reason, result)
+		}
+	}
+	if !results["fits"].Fits || explanation.Nodes[0].Node != "fits" {
+		t.Errorf("expected fitting node first, got %+v", explanation.Nodes)
+	}
+
+	pending.Spec.Tolerations = []corev1.Toleration{{Key: "dedicated", Operator: corev1.TolerationOpEqual, Value: "gpu", Effect: corev1.TaintEffectNoSchedule}}
+	if reasons := checkTaints(pending, &nodes[1]); len(reasons) != 0 {
+		t.Errorf("expected toleration to match taint, got %v", reasons)
+	}
+}
+
+func TestBuildNodeDetail(t *testing.T) {
+	node := &corev1.Node{
+		ObjectMeta: metav1.ObjectMeta{Name: "worker-1"},
+		Spec: corev1.NodeSpec{
+			Unschedulable: true,
+			Taints:        []corev1.Taint{{Key: "node.kubernetes.io/unschedulable", Effect: corev1.TaintEffectNoSchedule}},
+		},
+		Status: corev1.NodeStatus{
+			Capacity: corev1.ResourceList{
+				corev1.ResourceCPU:    resource.MustParse("4"),
+				corev1.ResourceMemory: resource.MustParse("8Gi"),
+			},
+			Allocatable: corev1.ResourceList{
+				corev1.ResourceCPU:    resource.MustParse("3800m"),
+				corev1.ResourceMemory: resource.MustParse("7Gi"),
+				corev1.ResourcePods:   resource.MustParse("110"),
+			},
+			Conditions: []corev1.NodeCondition{
+				{Type: corev1.NodeReady, Status: corev1.ConditionTrue},
+				{Type: corev1.NodeMemoryPressure, Status: corev1.ConditionTrue, Message: "kubelet has insufficient memory available"},
+				{Type: corev1.NodeDiskPressure, Status: corev1.ConditionFalse},
+			},
+			NodeInfo: corev1.NodeSystemInfo{KubeletVersion: "v1.27.3", KernelVersion: "6.1.0", ContainerRuntimeVersion: "containerd://1.7.2"},
+		},
+	}
+
+	resources := func(cpuRequest, memLimit string) corev1.ResourceRequirements {
+		return corev1.ResourceRequirements{
+			Requests: corev1.ResourceList{corev1.ResourceCPU: resource.MustParse(cpuRequest)},
+			Limits:   corev1.ResourceList{corev1.ResourceMemory: resource.MustParse(memLimit)},
+		}
+	}
+	pods := []corev1.Pod{
+		{Spec: corev1.PodSpec{Containers: []corev1.Container{{Resources: resources("1", "6Gi")}}}, Status: corev1.PodStatus{Phase: corev1.PodRunning}},
+		{Spec: corev1.PodSpec{Containers: []corev1.Container{{Resources: resources("900m", "6Gi")}}}, Status: corev1.PodStatus{Phase: corev1.PodRunning}},
+		{Spec: corev1.PodSpec{Containers: []corev1.Container{{Resources: resources("2", "1Gi")}}}, Status: corev1.PodStatus{Phase: corev1.PodSucceeded}},
+	}
+	usage := corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("1500m"), corev1.ResourceMemory: resource.MustParse("2Gi")}
+
+	detail := buildNodeDetail(node, pods, usage, 32, nil)
+
+	if detail.Status != "Ready,SchedulingDisabled" {
+		t.Errorf("expected cordoned status, got %q", detail.Status)
+	}
+	if detail.CPUAllocatable != 3800 || detail.CPURequests != 1900 || detail.CPUUsage != 1500 || detail.Pods != 2 {
+		t.Errorf("unexpected allocation: %+v", detail)
+	}
+	if detail.MemoryOvercommit < 1.7 || detail.MemoryOvercommit > 1.72 {
+		t.Errorf("expected memory overcommit of 12Gi/7Gi, got %.2f", detail.MemoryOvercommit)
+	}
+	if detail.VersionSkew != 5 {
+		t.Errorf("expected version skew 5, got %d", detail.VersionSkew)
+	}
+
+	expected := []string{
+		"MemoryPressure reported",
+		"Node is cordoned",
+		"Memory limits overcommitted 1.7x",
+		"Kubelet is 5 minor versions behind the control plane",
+	}
+	if strings.Join(detail.Issues, "|") != strings.Join(expected, "|") {
+		t.Errorf("expected issues %v, got %v", expected, detail.Issues)
+	}
+}
+
+func TestCapacitySimulation(t *testing.T) {
+	node := func(name string) corev1.Node {
+		return corev1.Node{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Labels: map[string]string{"kubernetes.io/hostname": name}},
+			Status: corev1.NodeStatus{Allocatable: corev1.ResourceList{
+				corev1.ResourceCPU:    resource.MustParse("2"),
+				corev1.ResourceMemory: resource.MustParse("4Gi"),
+				corev1.ResourcePods:   resource.MustParse("110"),
+			}},
+		}
+	}
+	nodes := []corev1.Node{node("node-a"), node("node-b")}
+
+	workload := func(name, nodeName, cpu string) corev1.Pod {
+		return corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "prod", Labels: map[string]string{"app": name}},
+			Spec: corev1.PodSpec{
+				NodeName: nodeName,
+				Containers: []corev1.Container{{Name: "app", Resources: corev1.ResourceRequirements{
+					Requests: corev1.ResourceList{corev1.ResourceCPU: resource.MustParse(cpu), corev1.ResourceMemory: resource.MustParse("512Mi")},
+				}}},
+			},
+			Status: corev1.PodStatus{Phase: corev1.PodRunning},
+		}
+	}
+	pods := []corev1.Pod{workload("db", "node-a", "1500m"), workload("cache", "node-b", "500m")}
+
+	template := NewHypotheticalPod("prod", resource.MustParse("500m"), resource.MustParse("256Mi"))
+	headroom := simulateReplicaHeadroom(template, nodes, pods, nil)
+	if headroom.Replicas != 4 || headroom.PerNode[0].Node != "node-b" || headroom.PerNode[0].Replicas != 3 {
+		t.Errorf("expected 1 replica on node-a and 3 on node-b, got %+v", headroom)
+	}
+	if len(headroom.BlockingReasons) != 1 || headroom.BlockingReasons[0] != "2/2 nodes: insufficient cpu" {
+		t.Errorf("unexpected blocking reasons: %v", headroom.BlockingReasons)
+	}
+
+	template.Labels = map[string]string{"app": "web"}
+	template.Spec.Affinity = &corev1.Affinity{PodAntiAffinity: &corev1.PodAntiAffinity{
+		RequiredDuringSchedulingIgnoredDuringExecution: []corev1.PodAffinityTerm{{
+			LabelSelector: &metav1.LabelSelector{MatchLabels: map[string]string{"app": "web"}},
+			TopologyKey:   "kubernetes.io/hostname",
+		}},
+	}}
+	if headroom := simulateReplicaHeadroom(template, nodes, pods, nil); headroom.Replicas != 2 {
+		t.Errorf("expected anti-affinity to allow one replica per node, got %d", headroom.Replicas)
+	}
+
+	daemon := workload("agent", "node-b", "100m")
+	daemon.OwnerReferences = []metav1.OwnerReference{{Kind: "DaemonSet", Name: "agent"}}
+
+	drain := simulateDrain("node-b", nodes, append(pods, daemon), nil)
+	if !drain.Feasible || drain.SkippedPods != 1 || len(drain.Placements) != 1 || drain.Placements[0].TargetNode != "node-a" {
+		t.Errorf("expected cache to move to node-a, got %+v", drain)
+	}
+	drain = simulateDrain("node-a", nodes, append(pods, daemon), nil)
+	if drain.Feasible || drain.SkippedPods != 0 || drain.Placements[0].Reason != "1/1 nodes: insufficient cpu" {
+		t.Errorf("expected db not to fit on node-b, got %+v", drain)
+	}
+
+	plan := buildCapacityPlan(nodes, append(pods, daemon), 0.5)
+	if plan.CPURequests != 2000 || plan.DaemonSetCPU != 100 || plan.LargestPodCPU != 1500 {
+		t.Fatalf("unexpected plan totals: %+v", plan)
+	}
+	if len(plan.Options) == 0 {
+		t.Fatal("expected node pool options")
+	}
+	for i, option := range plan.Options {
+		if i > 0 && option.MonthlyCost < plan.Options[i-1].MonthlyCost {
+			t.Errorf("options not sorted by cost: %+v", plan.Options)
+		}
+		for _, instance := range option.Instances {
+			if nodeTypeShapes[instance.Type].cpu < 4000 {
+				t.Errorf("instance %s cannot hold the largest pod at 50%% utilization", instance.Type)
+			}
+		}
+	}
+}
+
+func TestPlanConsolidation(t *testing.T) {
+	node := func(name string) corev1.Node {
+		return corev1.Node{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Labels: map[string]string{"kubernetes.io/hostname": name}},
+			Status: corev1.NodeStatus{
+				Allocatable: corev1.ResourceList{
+					corev1.ResourceCPU:    resource.MustParse("4"),
+					corev1.ResourceMemory: resource.MustParse("8Gi"),
+					corev1.ResourcePods:   resource.MustParse("110"),
+				},
+				Conditions: []corev1.NodeCondition{{Type: corev1.NodeReady, Status: corev1.ConditionTrue}},
+			},
+		}
+	}
+	nodes := []corev1.Node{node("busy"), node("quiet"), node("guarded")}
+
+	pod := func(name, nodeName, cpu, owner string) corev1.Pod {
+		p := corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "prod", Labels: map[string]string{"app": name}},
+			Spec: corev1.PodSpec{
+				NodeName: nodeName,
+				Containers: []corev1.Container{{Name: "app", Resources: corev1.ResourceRequirements{
+					Requests: corev1.ResourceList{corev1.ResourceCPU: resource.MustParse(cpu)},
+				}}},
+			},
+			Status: corev1.PodStatus{Phase: corev1.PodRunning},
+		}
+		if owner != "" {
+			p.OwnerReferences = []metav1.OwnerReference{{Kind: owner, Name: name}}
+		}
+		return p
+	}
+
+	scratch := pod("builder", "busy", "1", "ReplicaSet")
+	scratch.Spec.Volumes = []corev1.Volume{{Name: "scratch", VolumeSource: corev1.VolumeSource{EmptyDir: &corev1.EmptyDirVolumeSource{}}}}
+
+	pods := []corev1.Pod{
+		pod("api", "busy", "2", "ReplicaSet"),
+		scratch,
+		pod("web", "quiet", "500m", "ReplicaSet"),
+		pod("agent", "quiet", "100m", "DaemonSet"),
+		pod("db", "guarded", "1500m", "StatefulSet"),
+	}
+	pdbs := []policyv1.PodDisruptionBudget{{
+		ObjectMeta: metav1.ObjectMeta{Name: "db", Namespace: "prod"},
+		Spec:       policyv1.PodDisruptionBudgetSpec{Selector: &metav1.LabelSelector{MatchLabels: map[string]string{"app": "db"}}},
+		Status:     policyv1.PodDisruptionBudgetStatus{DisruptionsAllowed: 0},
+	}}
+	costs := map[string]float64{"busy": 100, "quiet": 70, "guarded": 50}
+
+	plan := planConsolidation(nodes, pods, pdbs, nil, costs)
+
+	if len(plan.RemovableNodes) != 1 || plan.RemovableNodes[0].Node != "quiet" || plan.MonthlySavings != 70 {
+		t.Fatalf("expected only quiet to be removable, got %+v", plan)
+	}
+	if moves := plan.RemovableNodes[0].Moves; len(moves) != 1 || moves[0].Pod != "web" || moves[0].TargetNode != "guarded" {
+		t.Errorf("expected web to move to the least requested node, got %+v", moves)
+	}
+
+	blockers := make(map[string]string)
+	for _, node := range plan.BlockedNodes {
+		blockers[node.Node] = strings.Join(node.Blockers, "; ")
+	}
+	if !strings.Contains(blockers["guarded"], "PodDisruptionBudget db") {
+		t.Errorf("expected PDB to block guarded, got %q", blockers["guarded"])
+	}
+	if !strings.Contains(blockers["busy"], "uses local storage") {
+		t.Errorf("expected local storage to block busy, got %q", blockers["busy"])
+	}
+
+	web := pod("web", "quiet", "500m", "ReplicaSet")
+	namespaceWide := []policyv1.PodDisruptionBudget{
+		{ObjectMeta: metav1.ObjectMeta{Name: "everything", Namespace: "prod"}, Spec: policyv1.PodDisruptionBudgetSpec{Selector: &metav1.LabelSelector{}}},
+		{ObjectMeta: metav1.ObjectMeta{Name: "nothing", Namespace: "prod"}},
+	}
+	if got := evictionBlockers(&web, namespaceWide); len(got) != 1 || !strings.Contains(got[0], "PodDisruptionBudget everything") {
+		t.Errorf("expected only the empty selector budget to block web, got %v", got)
+	}
+}
+
+func TestAuditPDBs(t *testing.T) {
+	intOrString := func(value intstr.IntOrString) *intstr.IntOrString { return &value }
+	pdb := func(name, app string, minAvailable, maxUnavailable *intstr.IntOrString, allowed int32) policyv1.PodDisruptionBudget {
+		return policyv1.PodDisruptionBudget{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "prod"},
+			Spec: policyv1.PodDisruptionBudgetSpec{
+				Selector:       &metav1.LabelSelector{MatchLabels: map[string]string{"app": app}},
+				MinAvailable:   minAvailable,
+				MaxUnavailable: maxUnavailable,
+			},
+			Status: policyv1.PodDisruptionBudgetStatus{DisruptionsAllowed: allowed},
+		}
+	}
+	pdbs := []policyv1.PodDisruptionBudget{
+		pdb("api", "api", intOrString(intstr.FromInt32(1)), nil, 1),
+		pdb("db", "db", intOrString(intstr.FromString("100%")), nil, 0),
+		pdb("cache", "cache", nil, intOrString(intstr.FromInt32(1)), 0),
+		pdb("old", "removed", intOrString(intstr.FromInt32(1)), nil, 0),
+	}
+
+	var pods []corev1.Pod
+	for _, app := range []string{"api", "api", "db", "db", "cache", "cache"} {
+		pods = append(pods, corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{Name: app, Namespace: "prod", Labels: map[string]string{"app": app}},
+			Status:     corev1.PodStatus{Phase: corev1.PodRunning},
+		})
+	}
+
+	audits := auditPDBs(pdbs, pods)
+	statuses := make(map[string]string)
+	for _, audit := range audits {
+		statuses[audit.Name] = audit.Status + ": " + strings.Join(audit.Issues, "; ")
+	}
+
+	expected := map[string]string{
+		"api":   "Healthy: ",
+		"db":    "Critical: Blocks all voluntary evictions (node drains will hang)",
+		"cache": "Warning: No disruptions currently allowed (0/0 healthy)",
+		"old":   "Warning: Selects no pods",
+	}
+	for name, status := range expected {
+		if statuses[name] != status {
+			t.Errorf("expected %s to be %q, got %q", name, status, statuses[name])
+		}
+	}
+	if audits[0].Name != "db" {
+		t.Errorf("expected critical budgets first, got %s", audits[0].Name)
+	}
+
+	namespaceWide := pdb("everything", "", nil, intOrString(intstr.FromInt32(1)), 1)
+	namespaceWide.Spec.Selector = &metav1.LabelSelector{}
+	unselected := pdb("unselected", "", nil, intOrString(intstr.FromInt32(1)), 1)
+	unselected.Spec.Selector = nil
+	wide := auditPDBs([]policyv1.PodDisruptionBudget{namespaceWide, unselected}, pods)
+	if len(wide) != 2 || wide[0].Name != "unselected" || wide[0].MatchedPods != 0 || wide[1].MatchedPods != len(pods) {
+		t.Errorf("expected the empty selector to match every pod and the nil selector none, got %+v", wide)
+	}
+	if matched := matchingPDBs([]policyv1.PodDisruptionBudget{namespaceWide, unselected}, "prod", map[string]string{"app": "web"}); len(matched) != 1 || matched[0].Name != "everything" {
+		t.Errorf("expected only the empty selector budget to cover web, got %+v", matched)
+	}
+
+	replicas := int32(3)
+	deployment := func(app string) *appsv1.Deployment {
+		return &appsv1.Deployment{
+			ObjectMeta: metav1.ObjectMeta{Name: app, Namespace: "prod"},
+			Spec: appsv1.DeploymentSpec{
+				Replicas: &replicas,
+				Template: corev1.PodTemplateSpec{
+					ObjectMeta: metav1.ObjectMeta{Labels: map[string]string{"app": app}},
+					Spec:       corev1.PodSpec{Containers: []corev1.Container{{Name: app}}},
+				},
+			},
+			Status: appsv1.DeploymentStatus{ReadyReplicas: 3, AvailableReplicas: 3},
+		}
+	}
+
+	client := &Client{}
+	unprotected := client.analyzeDeploymentHealth(deployment("web"), pdbs, nil, nil)
+	protected := client.analyzeDeploymentHealth(deployment("api"), pdbs, nil, nil)
+	blocked := client.analyzeDeploymentHealth(deployment("db"), pdbs, nil, nil)
+	unknown := client.analyzeDeploymentHealth(deployment("web"), nil, nil, nil)
+
+	if protected.PodDisruptionBudget != "api" || protected.HealthScore != unknown.HealthScore {
+		t.Errorf("expected a healthy PDB not to change the score, got %+v", protected)
+	}
+	if unprotected.HealthScore != unknown.HealthScore-10 || !containsString(unprotected.Issues, "No PodDisruptionBudget") {
+		t.Errorf("expected a missing PDB to cost 10 points, got %+v", unprotected)
+	}
+	if blocked.HealthScore != unknown.HealthScore-15 || !containsString(blocked.Issues, "PodDisruptionBudget db blocks all evictions") {
+		t.Errorf("expected a blocking PDB to cost 15 points, got %+v", blocked)
+	}
+
+	template := deployment("db").Spec.Template
+	if name, issues, _, penalty := checkDisruptionReadiness("prod", 0, template, pdbs); name != "db" || len(issues) != 0 || penalty != 0 {
+		t.Errorf("expected no PDB issues at 0 replicas, got %s %v (%d)", name, issues, penalty)
+	}
+}
+
+func TestAutoscalerAwareness(t *testing.T) {
+	int32Ptr := func(value int32) *int32 { return &value }
+	utilization := int32(70)
+
+	hpa := func(name string, current []autoscalingv2.MetricStatus, replicas int32) *autoscalingv2.HorizontalPodAutoscaler {
+		return &autoscalingv2.HorizontalPodAutoscaler{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "prod"},
+			Spec: autoscalingv2.HorizontalPodAutoscalerSpec{
+				ScaleTargetRef: autoscalingv2.CrossVersionObjectReference{Kind: "Deployment", Name: name},
+				MinReplicas:    int32Ptr(2),
+				MaxReplicas:    5,
+				Metrics: []autoscalingv2.MetricSpec{{
+					Type: autoscalingv2.ResourceMetricSourceType,
+					Resource: &autoscalingv2.ResourceMetricSource{
+						Name:   corev1.ResourceCPU,
+						Target: autoscalingv2.MetricTarget{Type: autoscalingv2.UtilizationMetricType, AverageUtilization: &utilization},
+					},
+				}},
+			},
+			Status: autoscalingv2.HorizontalPodAutoscalerStatus{CurrentReplicas: replicas, DesiredReplicas: replicas, CurrentMetrics: current},
+		}
+	}
+	cpuAt := func(percent int32) []autoscalingv2.MetricStatus {
+		return []autoscalingv2.MetricStatus{{
+			Type: autoscalingv2.ResourceMetricSourceType,
+			Resource: &autoscalingv2.ResourceMetricStatus{
+				Name:    corev1.ResourceCPU,
+				Current: autoscalingv2.MetricValueStatus{AverageUtilization: &percent},
+			},
+		}}
+	}
+
+	healthy := auditHPA(hpa("api", cpuAt(45), 3))
+	if healthy.Status != "Healthy" || healthy.Metrics[0] != "cpu: 45%/70%" || !healthy.ResourceMetrics {
+		t.Errorf("expected a healthy HPA, got %+v", healthy)
+	}
+
+	pinned := auditHPA(hpa("web", cpuAt(95), 5))
+	if pinned.Status != "Warning" || !containsString(pinned.Issues, "Pinned at max replicas (5)") {
+		t.Errorf("expected HPA pinned at max, got %+v", pinned)
+	}
+
+	blind := auditHPA(hpa("worker", nil, 2))
+	if blind.Status != "Critical" || blind.Metrics[0] != "cpu: <unknown>/70%" || !containsString(blind.Issues, "Missing metrics: cpu") {
+		t.Errorf("expected HPA with missing metrics, got %+v", blind)
+	}
+
+	// Only memory has a reading; it must not be shown as cpu
+	partial := hpa("cache", []autoscalingv2.MetricStatus{{
+		Type: autoscalingv2.ResourceMetricSourceType,
+		Resource: &autoscalingv2.ResourceMetricStatus{
+			Name:    corev1.ResourceMemory,
+			Current: autoscalingv2.MetricValueStatus{AverageUtilization: int32Ptr(60)},
+		},
+	}}, 2)
+	partial.Spec.Metrics = append(partial.Spec.Metrics, autoscalingv2.MetricSpec{
+		Type: autoscalingv2.ResourceMetricSourceType,
+		Resource: &autoscalingv2.ResourceMetricSource{
+			Name:   corev1.ResourceMemory,
+			Target: autoscalingv2.MetricTarget{Type: autoscalingv2.UtilizationMetricType, AverageUtilization: &utilization},
+		},
+	})
+	if audit := auditHPA(partial); strings.Join(audit.Metrics, ", ") != "cpu: <unknown>/70%, memory: 60%/70%" {
+		t.Errorf("expected metrics matched by resource name, got %v", audit.Metrics)
+	}
+
+	vpa := parseVPA(&unstructured.Unstructured{Object: map[string]interface{}{
+		"metadata": map[string]interface{}{"name": "web", "namespace": "prod"},
+		"spec": map[string]interface{}{
+			"targetRef":    map[string]interface{}{"kind": "Deployment", "name": "web"},
+			"updatePolicy": map[string]interface{}{"updateMode": "Off"},
+		},
+		"status": map[string]interface{}{
+			"recommendation": map[string]interface{}{
+				"containerRecommendations": []interface{}{
+					map[string]interface{}{"containerName": "web", "target": map[string]interface{}{"cpu": "250m", "memory": "256Mi"}},
+					map[string]interface{}{"containerName": "sidecar", "target": map[string]interface{}{"cpu": "50m", "memory": "64Mi"}},
+				},
+			},
+		},
+	}})
+	if vpa.Target != "Deployment/web" || vpa.UpdateMode != "Off" || len(vpa.Recommendations) != 2 {
+		t.Fatalf("unexpected VPA %+v", vpa)
+	}
+
+	pod := &corev1.Pod{Spec: corev1.PodSpec{Containers: []corev1.Container{{Name: "web"}}}}
+	cpu, memory, found := vpaTarget(&vpa, pod)
+	if !found || cpu != 250 || memory != 256*1024*1024 {
+		t.Errorf("expected the web container target only, got %d/%d", cpu, memory)
+	}
+	if status, recommendation := vpaRecommendation(1000, 256*1024*1024, cpu, memory); status != UtilizationUnderutilized {
+		t.Errorf("expected requests above the VPA target to be underutilized, got %s: %q", status, recommendation)
+	}
+
+	autoscalers := newWorkloadAutoscalers([]HPAStatus{pinned}, []VPAStatus{vpa})
+	foundHPA, foundVPA := autoscalers.lookup("prod", "Deployment/web")
+	if foundHPA == nil || foundVPA == nil {
+		t.Fatalf("expected both autoscalers for Deployment/web")
+	}
+
+	deployment := &appsv1.Deployment{
+		ObjectMeta: metav1.ObjectMeta{Name: "web", Namespace: "prod"},
+		Status:     appsv1.DeploymentStatus{ReadyReplicas: 1, AvailableReplicas: 1},
+	}
+	client := &Client{}
+	manual := client.analyzeDeploymentHealth(deployment, nil, nil, nil)
+	if manual.Replicas != 1 || !containsString(manual.Issues, "Single replica - no high availability") {
+		t.Errorf("expected nil replicas to default to a single replica, got %+v", manual)
+	}
+
+	autoscaled := client.analyzeDeploymentHealth(deployment, nil, foundHPA, foundVPA)
+	if containsString(autoscaled.Issues, "Single replica - no high availability") {
+		t.Errorf("expected no replica advice for an autoscaled deployment, got %+v", autoscaled.Issues)
+	}
+	if autoscaled.Autoscaler != "HPA 2-5, VPA Off" || !containsString(autoscaled.Issues, "HPA web: Pinned at max replicas (5)") {
+		t.Errorf("expected HPA issues on the deployment, got %+v", autoscaled)
+	}
+}
+
+func TestRightsizing(t *testing.T) {
+	if got := usagePercentile([]int64{50, 10, 40, 20, 30}, 95); got != 50 {
+		t.Errorf("expected p95 of 5 samples to be the max, got %d", got)
+	}
+	if got := usagePercentile([]int64{50, 10, 40, 20, 30}, 50); got != 30 {
+		t.Errorf("expected median 30, got %d", got)
+	}
+
+	const mi = 1024 * 1024
+	container := corev1.Container{
+		Name: "api",
+		Resources: corev1.ResourceRequirements{
+			Requests: corev1.ResourceList{
+				corev1.ResourceCPU:    resource.MustParse("500m"),
+				corev1.ResourceMemory: resource.MustParse("512Mi"),
+			},
+			Limits: corev1.ResourceList{
+				corev1.ResourceMemory: resource.MustParse("1Gi"),
+			},
+		},
+	}
+	options := RightsizingOptions{Percentile: 95, Headroom: 0.2}
+
+	recommendation := recommendContainerResources(container, []int64{80, 100, 90, 70, 60}, []int64{150 * mi, 160 * mi, 300 * mi, 140 * mi, 130 * mi}, nil, options)
+	if recommendation.RecommendedCPURequest != 120 || recommendation.RecommendedCPULimit != 0 {
+		t.Errorf("expected cpu 120m without a limit, got %+v", recommendation)
+	}
+	if recommendation.RecommendedMemoryRequest != 360*mi || recommendation.RecommendedMemoryLimit != 720*mi || !recommendation.Changed {
+		t.Errorf("expected memory 360Mi with the 2x limit kept, got %+v", recommendation)
+	}
+	if got := FormatResourceChange(recommendation.CPURequest, recommendation.RecommendedCPURequest, false); got != "500m → 120m" {
+		t.Errorf("unexpected cpu change %q", got)
+	}
+
+	vpa := &ContainerRecommendation{Container: "api", CPUTarget: 200, MemoryTarget: 450 * mi}
+	fromVPA := recommendContainerResources(container, []int64{80}, []int64{150 * mi}, vpa, options)
+	if fromVPA.Source != "VPA target" || fromVPA.RecommendedCPURequest != 240 || !fromVPA.Changed {
+		t.Errorf("expected the VPA target to drive the recommendation, got %+v", fromVPA)
+	}
+
+	// A single sample is not enough to trust a percentile
+	single := recommendContainerResources(container, []int64{80}, []int64{150 * mi}, nil, options)
+	if recommendation.LowConfidence || !single.LowConfidence || single.Samples != 1 || fromVPA.LowConfidence {
+		t.Errorf("expected only the single sample usage recommendation to be low confidence, got %+v", single)
+	}
+
+	report := &RightsizingReport{Workloads: []WorkloadRightsizing{
+		{Kind: "Deployment", Name: "api", Namespace: "prod", Containers: []ContainerRightsizing{recommendation}},
+		{Kind: "Pod", Name: "debug", Namespace: "prod", Containers: []ContainerRightsizing{recommendation}},
+		{Kind: "Deployment", Name: "web", Namespace: "prod", Containers: []ContainerRightsizing{single}},
+	}}
+	patches, err := BuildRightsizingPatches(report, "../base")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(patches) != 2 || patches[0].Filename != "prod-deployment-api.yaml" || patches[1].Filename != "kustomization.yaml" {
+		t.Fatalf("unexpected patches %+v", patches)
+	}
+	for _, expected := range []string{"kind: Deployment", "name: api", "cpu: 120m", "memory: 360Mi", "memory: 720Mi"} {
+		if !strings.Contains(string(patches[0].Content), expected) {
+			t.Errorf("expected patch to contain %q:\n%s", expected, patches[0].Content)
+		}
+	}
+	if !strings.Contains(string(patches[1].Content), "- ../base") || !strings.Contains(string(patches[1].Content), "path: prod-deployment-api.yaml") {
+		t.Errorf("unexpected kustomization:\n%s", patches[1].Content)
+	}
+
+	client := &Client{}
+	if got := client.generateRightsizingRecommendation(500, 512*mi, 20, 50); got != "Set requests cpu: 500m → 120m, memory: 512Mi → 308Mi" {
+		t.Errorf("unexpected rightsizing advice %q", got)
+	}
+}
+
+func TestFixPlanners(t *testing.T) {
+	now := time.Now()
+	ago := func(d time.Duration) *metav1.Time { value := metav1.NewTime(now.Add(-d)); return &value }
+	controller := true
+
+	pods := []corev1.Pod{
+		{ObjectMeta: metav1.ObjectMeta{Name: "evicted", Namespace: "prod"}, Status: corev1.PodStatus{Phase: corev1.PodFailed, Reason: "Evicted", Message: "node was low on memory"}},
+		{ObjectMeta: metav1.ObjectMeta{Name: "running", Namespace: "prod"}, Status: corev1.PodStatus{Phase: corev1.PodRunning}},
+		{ObjectMeta: metav1.ObjectMeta{Name: "stuck", Namespace: "prod", DeletionTimestamp: ago(time.Hour), Finalizers: []string{"example.com/cleanup"}}, Status: corev1.PodStatus{Phase: corev1.PodRunning}},
+		{ObjectMeta: metav1.ObjectMeta{Name: "stopping", Namespace: "prod", DeletionTimestamp: ago(time.Minute)}, Status: corev1.PodStatus{Phase: corev1.PodRunning}},
+	}
+
+	failed := planFailedPodCleanup(pods)
+	if len(failed) != 1 || failed[0].Name != "evicted" || failed[0].Reason != "Evicted: node was low on memory" {
+		t.Errorf("expected only the evicted pod, got %+v", failed)
+	}
+
+	stuck := planStuckTerminating(pods, now, 10*time.Minute)
+	if len(stuck) != 1 || stuck[0].Name != "stuck" || !stuck[0].force || len(stuck[0].Diff) != 2 {
+		t.Errorf("expected a forced delete of the stuck pod with a finalizer note, got %+v", stuck)
+	}
+
+	ttl := int32(3600)
+	finished := func(name string, finishedAgo time.Duration, ttl *int32, owners ...metav1.OwnerReference) batchv1.Job {
+		return batchv1.Job{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "batch", OwnerReferences: owners},
+			Spec:       batchv1.JobSpec{TTLSecondsAfterFinished: ttl},
+			Status: batchv1.JobStatus{
+				CompletionTime: ago(finishedAgo),
+				Conditions:     []batchv1.JobCondition{{Type: batchv1.JobComplete, Status: corev1.ConditionTrue}},
+			},
+		}
+	}
+	jobs := []batchv1.Job{
+		finished("past-ttl", 2*time.Hour, &ttl),
+		finished("within-ttl", 30*time.Minute, &ttl),
+		finished("old-no-ttl", 48*time.Hour, nil),
+		finished("recent-no-ttl", time.Hour, nil),
+		finished("cron-run", 48*time.Hour, nil, metav1.OwnerReference{Kind: "CronJob", Name: "nightly", Controller: &controller}),
+		{ObjectMeta: metav1.ObjectMeta{Name: "running", Namespace: "batch"}},
+	}
+	var names []string
+	for _, action := range planCompletedJobs(jobs, now, 24*time.Hour) {
+		names = append(names, action.Name)
+	}
+	if strings.Join(names, ",") != "old-no-ttl,past-ttl" {
+		t.Errorf("expected old-no-ttl and past-ttl, got %v", names)
+	}
+
+	templates := []workloadTemplate{{
+		kind:      "Deployment",
+		namespace: "prod",
+		name:      "api",
+		template: corev1.PodTemplateSpec{Spec: corev1.PodSpec{Containers: []corev1.Container{
+			{Name: "api", Resources: corev1.ResourceRequirements{Requests: corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("250m")}}},
+			{Name: "sidecar"},
+			{Name: "proxy", Resources: corev1.ResourceRequirements{Limits: corev1.ResourceList{corev1.ResourceMemory: resource.MustParse("128Mi")}}},
+		}}},
+	}, {
+		kind:      "Deployment",
+		namespace: "prod",
+		name:      "guaranteed",
+		template: corev1.PodTemplateSpec{Spec: corev1.PodSpec{Containers: []corev1.Container{
+			{Name: "app", Resources: corev1.ResourceRequirements{Limits: corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("500m"), corev1.ResourceMemory: resource.MustParse("512Mi")}}},
+		}}},
+	}}
+	report := &RightsizingReport{Workloads: []WorkloadRightsizing{{
+		Kind: "Deployment", Name: "api", Namespace: "prod",
+		Containers: []ContainerRightsizing{
+			{Container: "api", RecommendedCPURequest: 120, RecommendedMemoryRequest: 256 * 1024 * 1024},
+			{Container: "sidecar", RecommendedCPURequest: 10, RecommendedMemoryRequest: 32 * 1024 * 1024},
+			{Container: "proxy", RecommendedCPURequest: 20, RecommendedMemoryRequest: 16 * 1024 * 1024},
+		},
+	}, {
+		Kind: "Deployment", Name: "guaranteed", Namespace: "prod",
+		Containers: []ContainerRightsizing{
+			{Container: "app", RecommendedCPURequest: 50, RecommendedMemoryRequest: 64 * 1024 * 1024},
+		},
+	}}}
+
+	// Limit-only resources already request the limit, so they are left alone
+	// and the fully limited workload keeps its Guaranteed QoS
+	actions, err := planMissingRequests(templates, report)
+	if err != nil || len(actions) != 1 || actions[0].Name != "api" {
+		t.Fatalf("expected one patch for api, got %+v (%v)", actions, err)
+	}
+	expectedDiff := []string{
+		"+ containers[api].resources.requests.memory: 256Mi",
+		"+ containers[sidecar].resources.requests.cpu: 10m",
+		"+ containers[sidecar].resources.requests.memory: 32Mi",
+		"+ containers[proxy].resources.requests.cpu: 20m",
+	}
+	if strings.Join(actions[0].Diff, "\n") != strings.Join(expectedDiff, "\n") {
+		t.Errorf("unexpected diff %v", actions[0].Diff)
+	}
+	if strings.Contains(string(actions[0].patch), "250m") || strings.Contains(string(actions[0].patch), `"cpu":"120m"`) {
+		t.Errorf("expected existing requests to be left alone, got %s", actions[0].patch)
+	}
+}
+
+func TestBuildCleanupReport(t *testing.T) {
+	controller := true
+	meta := func(namespace, name string) metav1.ObjectMeta {
+		return metav1.ObjectMeta{Namespace: namespace, Name: name, CreationTimestamp: metav1.NewTime(time.Now().Add(-72 * time.Hour))}
+	}
+	owned := func(namespace, name, kind, owner string, annotations map[string]string) metav1.ObjectMeta {
+		objectMeta := meta(namespace, name)
+		objectMeta.Annotations = annotations
+		objectMeta.OwnerReferences = []metav1.OwnerReference{{Kind: kind, Name: owner, Controller: &controller}}
+		return objectMeta
+	}
+	zero := int32(0)
+	historyLimit := int32(1)
+	ready := true
+
+	web := corev1.Pod{
+		ObjectMeta: meta("prod", "web-1"),
+		Spec: corev1.PodSpec{
+			ServiceAccountName: "web",
+			Volumes: []corev1.Volume{
+				{Name: "config", VolumeSource: corev1.VolumeSource{ConfigMap: &corev1.ConfigMapVolumeSource{LocalObjectReference: corev1.LocalObjectReference{Name: "web-config"}}}},
+				{Name: "data", VolumeSource: corev1.VolumeSource{PersistentVolumeClaim: &corev1.PersistentVolumeClaimVolumeSource{ClaimName: "web-data"}}},
+			},
+			Containers: []corev1.Container{{
+				Name: "web",
+				Env:  []corev1.EnvVar{{Name: "TOKEN", ValueFrom: &corev1.EnvVarSource{SecretKeyRef: &corev1.SecretKeySelector{LocalObjectReference: corev1.LocalObjectReference{Name: "web-token"}}}}},
+			}},
+		},
+		Status: corev1.PodStatus{Phase: corev1.PodRunning},
+	}
+	evicted := corev1.Pod{ObjectMeta: meta("prod", "web-0"), Status: corev1.PodStatus{Phase: corev1.PodFailed, Reason: "Evicted", Message: "low on memory"}}
+
+	inventory := &cleanupInventory{
+		namespaces: []corev1.Namespace{{ObjectMeta: meta("", "prod")}, {ObjectMeta: meta("", "abandoned")}, {ObjectMeta: meta("", "kube-system")}},
+		pods:       []corev1.Pod{web, evicted},
+		configMaps: []corev1.ConfigMap{
+			{ObjectMeta: meta("prod", "web-config")},
+			{ObjectMeta: meta("prod", "old-config")},
+			{ObjectMeta: meta("prod", "kube-root-ca.crt")},
+			{ObjectMeta: meta("prod", "report-config")},
+		},
+		secrets: []corev1.Secret{
+			{ObjectMeta: meta("prod", "web-token")},
+			{ObjectMeta: meta("prod", "tls-cert")},
+			{ObjectMeta: meta("prod", "leaked")},
+			{ObjectMeta: meta("prod", "sh.helm.release.v1.web.v1"), Type: "helm.sh/release.v1"},
+		},
+		pvcs: []corev1.PersistentVolumeClaim{
+			{ObjectMeta: meta("prod", "web-data")},
+			{
+				ObjectMeta: owned("prod", "db-data-0", "StatefulSet", "db", nil),
+				Status:     corev1.PersistentVolumeClaimStatus{Phase: corev1.ClaimBound, Capacity: corev1.ResourceList{corev1.ResourceStorage: resource.MustParse("100Gi")}},
+			},
+		},
+		services: []corev1.Service{
+			{ObjectMeta: meta("prod", "web"), Spec: corev1.ServiceSpec{Type: corev1.ServiceTypeClusterIP}},
+			{ObjectMeta: meta("prod", "legacy-lb"), Spec: corev1.ServiceSpec{Type: corev1.ServiceTypeLoadBalancer}},
+			{ObjectMeta: meta("default", "kubernetes"), Spec: corev1.ServiceSpec{Type: corev1.ServiceTypeClusterIP}},
+		},
+		endpointSlices: []discoveryv1.EndpointSlice{{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "prod", Name: "web-abc", Labels: map[string]string{discoveryv1.LabelServiceName: "web"}},
+			Endpoints:  []discoveryv1.Endpoint{{Conditions: discoveryv1.EndpointConditions{Ready: &ready}}},
+		}},
+		serviceAccounts: []corev1.ServiceAccount{{ObjectMeta: meta("prod", "default")}, {ObjectMeta: meta("prod", "web")}, {ObjectMeta: meta("prod", "ci-bot")}},
+		ingresses: []networkingv1.Ingress{{
+			ObjectMeta: meta("prod", "web"),
+			Spec:       networkingv1.IngressSpec{TLS: []networkingv1.IngressTLS{{SecretName: "tls-cert"}}},
+		}},
+		deployments: []appsv1.Deployment{{ObjectMeta: meta("prod", "web"), Spec: appsv1.DeploymentSpec{RevisionHistoryLimit: &historyLimit}}},
+		replicaSets: []appsv1.ReplicaSet{
+			{ObjectMeta: owned("prod", "web-1", "Deployment", "web", map[string]string{deploymentRevisionAnnotation: "1"}), Spec: appsv1.ReplicaSetSpec{Replicas: &zero}},
+			{ObjectMeta: owned("prod", "web-2", "Deployment", "web", map[string]string{deploymentRevisionAnnotation: "2"}), Spec: appsv1.ReplicaSetSpec{Replicas: &zero}},
+			{ObjectMeta: owned("prod", "web-3", "Deployment", "web", map[string]string{deploymentRevisionAnnotation: "3"})},
+		},
+		jobs: []batchv1.Job{{
+			ObjectMeta: meta("prod", "migrate"),
+			Status: batchv1.JobStatus{
+				Succeeded:      1,
+				CompletionTime: &metav1.Time{Time: time.Now().Add(-2 * time.Hour)},
+				Conditions:     []batchv1.JobCondition{{Type: batchv1.JobComplete, Status: corev1.ConditionTrue}},
+			},
+		}},
+		cronJobs: []batchv1.CronJob{{
+			ObjectMeta: meta("prod", "report"),
+			Spec: batchv1.CronJobSpec{JobTemplate: batchv1.JobTemplateSpec{Spec: batchv1.JobSpec{Template: corev1.PodTemplateSpec{Spec: corev1.PodSpec{
+				Containers: []corev1.Container{{Name: "report", EnvFrom: []corev1.EnvFromSource{{ConfigMapRef: &corev1.ConfigMapEnvSource{LocalObjectReference: corev1.LocalObjectReference{Name: "report-config"}}}}}},
+			}}}}},
+		}},
+	}
+
+	report := buildCleanupReport(inventory)
+
+	found := make(map[string]string)
+	for _, finding := range report.Findings {
+		found[finding.Category+" "+finding.Namespace+"/"+finding.Name] = finding.Owner
+	}
+	expected := map[string]string{
+		"Unused ConfigMap prod/old-config":         "<none>",
+		"Unused Secret prod/leaked":                "<none>",
+		"Unmounted PVC prod/db-data-0":             "StatefulSet/db",
+		"Service Without Endpoints prod/legacy-lb": "<none>",
+		"Stale ReplicaSet prod/web-1":              "Deployment/web",
+		"Finished Job prod/migrate":                "<none>",
+		"Evicted Pod prod/web-0":                   "<none>",
+		"Empty Namespace /abandoned":               "<none>",
+		"Unused ServiceAccount prod/ci-bot":        "<none>",
+	}
+	for key, owner := range expected {
+		if got, exists := found[key]; !exists || got != owner {
+			t.Errorf("expected finding %q owned by %s, got %q (exists %v)", key, owner, got, exists)
+		}
+	}
+	if len(report.Findings) != len(expected) {
+		t.Errorf("expected %d findings, got %v", len(expected), found)
+	}
+
+	// 100Gi at $0.10/GB plus one load balancer
+	if want := 100*storageCostPerGBMonth + loadBalancerMonthlyCost; report.MonthlyCost < want-0.01 || report.MonthlyCost > want+0.01 {
+		t.Errorf("expected monthly cost %.2f, got %.2f", want, report.MonthlyCost)
+	}
+}
+
+func TestBatchHealth(t *testing.T) {
+	now := time.Date(2025, 6, 11, 12, 30, 0, 0, time.UTC) // a Wednesday
+
+	scheduleTests := []struct {
+		spec  string
+		after time.Time
+		next  time.Time
+	}{
+		{"*/15 * * * *", now, time.Date(2025, 6, 11, 12, 45, 0, 0, time.UTC)},
+		{"0 2 * * *", now, time.Date(2025, 6, 12, 2, 0, 0, 0, time.UTC)},
+		{"30 9 * * mon-fri", time.Date(2025, 6, 13, 10, 0, 0, 0, time.UTC), time.Date(2025, 6, 16, 9, 30, 0, 0, time.UTC)},
+		{"0 0 1,15 * 7", now, time.Date(2025, 6, 15, 0, 0, 0, 0, time.UTC)},
+		{"@monthly", now, time.Date(2025, 7, 1, 0, 0, 0, 0, time.UTC)},
+	}
+	for _, test := range scheduleTests {
+		schedule, err := parseCronSchedule(test.spec)
+		if err != nil {
+			t.Fatalf("%s: unexpected error %v", test.spec, err)
+		}
+		if got := schedule.next(test.after); !got.Equal(test.next) {
+			t.Errorf("%s: expected next run %s, got %s", test.spec, test.next, got)
+		}
+	}
+	for _, invalid := range []string{"* * * *", "61 * * * *", "*/0 * * * *", "5-1 * * * *"} {
+		if _, err := parseCronSchedule(invalid); err == nil {
+			t.Errorf("expected %q to be rejected", invalid)
+		}
+	}
+
+	controller := true
+	finishedRun := func(name string, start time.Time, duration time.Duration, failed bool) batchv1.Job {
+		condition := batchv1.JobComplete
+		reason := ""
+		if failed {
+			condition = batchv1.JobFailed
+			reason = "BackoffLimitExceeded"
+		}
+		return batchv1.Job{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace:       "prod",
+				Name:            name,
+				OwnerReferences: []metav1.OwnerReference{{Kind: "CronJob", Name: "backup", Controller: &controller}},
+			},
+			Status: batchv1.JobStatus{
+				StartTime:      &metav1.Time{Time: start},
+				CompletionTime: &metav1.Time{Time: start.Add(duration)},
+				Conditions:     []batchv1.JobCondition{{Type: condition, Status: corev1.ConditionTrue, Reason: reason}},
+			},
+		}
+	}
+	hourly := func(hoursAgo int) time.Time {
+		return time.Date(2025, 6, 11, 12-hoursAgo, 0, 0, 0, time.UTC)
+	}
+
+	lastSchedule := metav1.NewTime(hourly(5))
+	backup := batchv1.CronJob{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "prod", Name: "backup", CreationTimestamp: metav1.NewTime(now.AddDate(0, -1, 0))},
+		Spec:       batchv1.CronJobSpec{Schedule: "0 * * * *", ConcurrencyPolicy: batchv1.ForbidConcurrent},
+		Status:     batchv1.CronJobStatus{LastScheduleTime: &lastSchedule},
+	}
+	suspended := true
+	paused := batchv1.CronJob{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "prod", Name: "paused", CreationTimestamp: metav1.NewTime(now.AddDate(0, -1, 0))},
+		Spec:       batchv1.CronJobSpec{Schedule: "@daily", Suspend: &suspended},
+	}
+
+	backoffLimit := int32(2)
+	deadline := int64(600)
+	jobs := []batchv1.Job{
+		finishedRun("backup-1", hourly(11), 10*time.Minute, false),
+		finishedRun("backup-2", hourly(10), 10*time.Minute, false),
+		finishedRun("backup-3", hourly(9), 20*time.Minute, false),
+		finishedRun("backup-4", hourly(8), 20*time.Minute, false),
+		finishedRun("backup-5", hourly(7), 5*time.Minute, true),
+		finishedRun("backup-6", hourly(6), 5*time.Minute, true),
+		finishedRun("backup-7", hourly(5), 5*time.Minute, true),
+		{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "prod", Name: "migrate"},
+			Spec:       batchv1.JobSpec{BackoffLimit: &backoffLimit},
+			Status: batchv1.JobStatus{
+				Failed:     3,
+				StartTime:  &metav1.Time{Time: hourly(2)},
+				Conditions: []batchv1.JobCondition{{Type: batchv1.JobFailed, Status: corev1.ConditionTrue, Reason: "BackoffLimitExceeded", LastTransitionTime: metav1.NewTime(hourly(1))}},
+			},
+		},
+		{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "prod", Name: "reindex"},
+			Spec:       batchv1.JobSpec{ActiveDeadlineSeconds: &deadline},
+			Status: batchv1.JobStatus{
+				StartTime:  &metav1.Time{Time: hourly(2)},
+				Conditions: []batchv1.JobCondition{{Type: batchv1.JobFailed, Status: corev1.ConditionTrue, Reason: "DeadlineExceeded", LastTransitionTime: metav1.NewTime(hourly(1))}},
+			},
+		},
+	}
+
+	jobHealth, cronJobHealth := analyzeBatchHealth(jobs, []batchv1.CronJob{backup, paused}, now)
+
+	if len(jobHealth) != 2 {
+		t.Fatalf("expected only the 2 standalone jobs, got %d", len(jobHealth))
+	}
+	for _, job := range jobHealth {
+		if job.Status != "Critical" || job.Phase != "Failed" || len(job.Issues) != 1 {
+			t.Errorf("%s: expected a critical failure, got %s/%s %v", job.Name, job.Status, job.Phase, job.Issues)
+			continue
+		}
+		if job.Name == "migrate" && !strings.Contains(job.Issues[0], "Backoff limit reached (3 failed attempts, limit 2)") {
+			t.Errorf("migrate: unexpected issue %q", job.Issues[0])
+		}
+		if job.Name == "reindex" && !strings.Contains(job.Issues[0], "Active deadline exceeded (10m0s)") {
+			t.Errorf("reindex: unexpected issue %q", job.Issues[0])
+		}
+	}
+
+	var health CronJobHealth
+	for _, cronJob := range cronJobHealth {
+		if cronJob.Name == "backup" {
+			health = cronJob
+		} else if cronJob.Status != "Warning" || len(cronJob.Issues) != 1 || cronJob.MissedRuns != 0 {
+			t.Errorf("paused: expected only the suspension warning, got %s %v", cronJob.Status, cronJob.Issues)
+		}
+	}
+
+	// Runs at 08:00 through 12:00 never started
+	if health.MissedRuns != 5 {
+		t.Errorf("expected 5 missed runs, got %d", health.MissedRuns)
+	}
+	if health.ConsecutiveFailures != 3 {
+		t.Errorf("expected 3 consecutive failures, got %d", health.ConsecutiveFailures)
+	}
+	if health.AverageDuration != "15m0s" || health.DurationTrend != "+50%" {
+		t.Errorf("expected 15m0s average trending +50%%, got %s %s", health.AverageDuration, health.DurationTrend)
+	}
+	// The default history of 3 successful runs is enough for a trend
+	if trend, ok := durationTrend([]time.Duration{10 * time.Minute, 10 * time.Minute, 20 * time.Minute}); !ok || trend != 1 {
+		t.Errorf("expected the latest of 3 runs to trend +100%%, got %v %v", trend, ok)
+	}
+	if _, ok := durationTrend([]time.Duration{10 * time.Minute}); ok {
+		t.Errorf("expected no trend from a single run")
+	}
+	if health.Status != "Critical" || len(health.Issues) != 3 {
+		t.Errorf("expected critical with missed runs, failures and trend, got %s %v", health.Status, health.Issues)
+	}
+}
+
+func TestRolloutStatus(t *testing.T) {
+	controller := true
+	replicas := int32(2)
+	rolledOut := time.Now().Add(-30 * time.Minute)
+
+	deployment := &appsv1.Deployment{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "prod", Name: "web", UID: "web-uid", Generation: 3},
+		Spec:       appsv1.DeploymentSpec{Replicas: &replicas},
+		Status: appsv1.DeploymentStatus{
+			ObservedGeneration: 3,
+			Replicas:           3,
+			UpdatedReplicas:    1,
+			AvailableReplicas:  2,
+			Conditions: []appsv1.DeploymentCondition{{
+				Type:    appsv1.DeploymentProgressing,
+				Status:  corev1.ConditionFalse,
+				Reason:  "ProgressDeadlineExceeded",
+				Message: `ReplicaSet "web-3" has timed out progressing.`,
+			}},
+		},
+	}
+
+	replicaSet := func(name, revision, cause string, created time.Time, images ...string) appsv1.ReplicaSet {
+		rs := appsv1.ReplicaSet{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace:         "prod",
+				Name:              name,
+				CreationTimestamp: metav1.NewTime(created),
+				Annotations:       map[string]string{deploymentRevisionAnnotation: revision},
+				OwnerReferences:   []metav1.OwnerReference{{Kind: "Deployment", Name: "web", UID: "web-uid", Controller: &controller}},
+			},
+		}
+		if cause != "" {
+			rs.Annotations[changeCauseAnnotation] = cause
+		}
+		for i := 0; i+1 < len(images); i += 2 {
+			rs.Spec.Template.Spec.Containers = append(rs.Spec.Template.Spec.Containers, corev1.Container{Name: images[i], Image: images[i+1]})
+		}
+		return rs
+	}
+
+	replicaSets := []appsv1.ReplicaSet{
+		replicaSet("web-3", "3", "bump to 1.26", rolledOut, "web", "nginx:1.26", "sidecar", "envoy:1.30"),
+		replicaSet("web-1", "1", "", rolledOut.Add(-48*time.Hour), "web", "nginx:1.24", "metrics", "exporter:1"),
+		replicaSet("web-2", "2", "", rolledOut.Add(-24*time.Hour), "web", "nginx:1.25", "metrics", "exporter:1"),
+		{ObjectMeta: metav1.ObjectMeta{Namespace: "prod", Name: "other-1", OwnerReferences: []metav1.OwnerReference{{Kind: "Deployment", Name: "other", UID: "other-uid"}}}},
+	}
+
+	pod := func(name, owner string, created time.Time, restarts int32) corev1.Pod {
+		return corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "prod", Name: name, CreationTimestamp: metav1.NewTime(created), OwnerReferences: []metav1.OwnerReference{{Kind: "ReplicaSet", Name: owner, Controller: &controller}}},
+			Status:     corev1.PodStatus{ContainerStatuses: []corev1.ContainerStatus{{Name: "web", RestartCount: restarts}}},
+		}
+	}
+	pods := []corev1.Pod{pod("web-3-a", "web-3", rolledOut.Add(time.Minute), 4), pod("web-2-a", "web-2", rolledOut.Add(-24*time.Hour), 7), pod("web-2-b", "web-2", rolledOut.Add(-24*time.Hour), 0)}
+
+	events := []ClusterEvent{
+		{Type: "Warning", Reason: "BackOff", Object: "Pod/web-3-a", Namespace: "prod", FirstTime: rolledOut.Add(5 * time.Minute), LastTime: rolledOut.Add(20 * time.Minute), Count: 12},
+		{Type: "Warning", Reason: "Unhealthy", Object: "Pod/web-2-a", Namespace: "prod", FirstTime: rolledOut.Add(time.Minute), LastTime: rolledOut.Add(2 * time.Minute), Count: 3},
+		{Type: "Warning", Reason: "BackOff", Object: "Pod/web-3-a", Namespace: "prod", FirstTime: rolledOut.Add(-time.Hour), LastTime: rolledOut.Add(-time.Hour), Count: 9},
+		{Type: "Normal", Reason: "Pulled", Object: "Pod/web-3-a", Namespace: "prod", FirstTime: rolledOut, LastTime: rolledOut, Count: 1},
+	}
+
+	status := buildRolloutStatus(deployment, replicaSets, pods, events)
+
+	if status.State != "Stalled" || status.Revision != 3 || !status.RolloutTime.Equal(rolledOut) {
+		t.Errorf("expected revision 3 stalled since %s, got revision %d %s since %s", rolledOut, status.Revision, status.State, status.RolloutTime)
+	}
+	if len(status.History) != 3 || !status.History[2].Current || status.History[0].ChangeCause != "<none>" || status.History[2].ChangeCause != "bump to 1.26" {
+		t.Fatalf("unexpected history %+v", status.History)
+	}
+	if got := strings.Join(status.History[1].ImageChanges, "; "); got != "web: nginx:1.24 → nginx:1.25" {
+		t.Errorf("unexpected revision 2 image changes %q", got)
+	}
+	if got := strings.Join(status.History[2].ImageChanges, "; "); got != "+sidecar: envoy:1.30; web: nginx:1.25 → nginx:1.26; -metrics: exporter:1" {
+		t.Errorf("unexpected revision 3 image changes %q", got)
+	}
+
+	// Only the new revision's pod restarts and events after the rollout count
+	if status.Restarts != 4 || len(status.Events) != 1 || status.Events[0].Count != 12 {
+		t.Errorf("expected 4 restarts and one BackOff event, got %d restarts and %+v", status.Restarts, status.Events)
+	}
+	if !status.Suspicious || len(status.Findings) != 4 || !strings.Contains(status.Findings[3], "--to-revision=2") {
+		t.Errorf("expected a suspicious rollout with a rollback hint, got %v", status.Findings)
+	}
+
+	// A rollback re-adopts web-2 as revision 4; its old pods' earlier restarts
+	// and its creation time must not count against the rollback
+	rolledBack := rolledOut.Add(20 * time.Minute)
+	deployment.Status.Conditions = []appsv1.DeploymentCondition{{
+		Type:           appsv1.DeploymentProgressing,
+		Status:         corev1.ConditionTrue,
+		Reason:         "NewReplicaSetAvailable",
+		LastUpdateTime: metav1.NewTime(rolledBack),
+	}}
+	replicaSets[2].Annotations[deploymentRevisionAnnotation] = "4"
+	replicaSets[2].Annotations[revisionHistoryAnnotation] = "2"
+	crashed := pod("web-2-b", "web-2", rolledOut.Add(-24*time.Hour), 2)
+	crashed.Status.ContainerStatuses[0].LastTerminationState.Terminated = &corev1.ContainerStateTerminated{FinishedAt: metav1.NewTime(rolledBack.Add(time.Minute))}
+	pods = []corev1.Pod{pod("web-2-a", "web-2", rolledOut.Add(-24*time.Hour), 7), crashed}
+
+	status = buildRolloutStatus(deployment, replicaSets, pods, nil)
+	if status.Revision != 4 || !status.RolloutTime.Equal(rolledBack) {
+		t.Errorf("expected revision 4 rolled out at %s, got revision %d at %s", rolledBack, status.Revision, status.RolloutTime)
+	}
+	if status.Restarts != 1 {
+		t.Errorf("expected only the restart after the rollback to count, got %d", status.Restarts)
+	}
+
+	deployment.Status = appsv1.DeploymentStatus{ObservedGeneration: 3, Replicas: 2, UpdatedReplicas: 2, AvailableReplicas: 2}
+	if state, _ := rolloutState(deployment); state != "Complete" {
+		t.Errorf("expected Complete, got %s", state)
+	}
+	deployment.Status.Replicas = 3
+	if state, message := rolloutState(deployment); state != "Progressing" || message != "1 old replicas pending termination" {
+		t.Errorf("expected Progressing with old replicas, got %s (%s)", state, message)
+	}
+}
+
+func TestLintManifests(t *testing.T) {
+	manifest := `---
+# Source: web/templates/deployment.yaml
+apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: web
+  namespace: prod
+spec:
+  replicas: 1
+  template:
+    spec:
+      securityContext:
+        runAsNonRoot: true
+      containers:
+      - name: web
+        image: web:1.0
+        resources:
+          requests: {cpu: 100m, memory: 128Mi}
+          limits: {cpu: 500m, memory: 256Mi}
+        livenessProbe: {httpGet: {path: /healthz, port: 8080}}
+        readinessProbe: {httpGet: {path: /ready, port: 8080}}
+        securityContext:
+          allowPrivilegeEscalation: false
+---
+# Source: web/templates/hpa.yaml
+apiVersion: autoscaling/v2
+kind: HorizontalPodAutoscaler
+metadata:
+  name: web
+  namespace: prod
+spec:
+  scaleTargetRef: {apiVersion: apps/v1, kind: Deployment, name: web}
+  maxReplicas: 5
+---
+apiVersion: extensions/v1beta1
+kind: DaemonSet
+metadata:
+  name: agent
+spec:
+  template:
+    spec:
+      hostNetwork: true
+      containers:
+      - name: agent
+        image: agent:1.0
+        securityContext:
+          privileged: true
+---
+apiVersion: batch/v1
+kind: CronJob
+metadata:
+  name: backup
+spec:
+  schedule: "0 3 * * *"
+  jobTemplate:
+    spec:
+      template:
+        spec:
+          initContainers:
+          - name: wait
+            image: busybox:1.36
+            securityContext: {privileged: true}
+          containers:
+          - name: backup
+            image: backup:1.0
+          - name: upload
+            image: upload:1.0
+---
+apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: settings
+`
+
+	manifests, err := ParseManifests(strings.NewReader(manifest), "chart.yaml")
+	if err != nil {
+		t.Fatalf("failed to parse manifests: %v", err)
+	}
+	if len(manifests) != 5 {
+		t.Fatalf("expected 5 objects, got %d", len(manifests))
+	}
+	if manifests[0].Source != "chart.yaml:web/templates/deployment.yaml" || manifests[2].Source != "chart.yaml" {
+		t.Errorf("unexpected sources %q and %q", manifests[0].Source, manifests[2].Source)
+	}
+
+	if _, err := LintManifests(manifests, "2.0"); err == nil {
+		t.Errorf("expected an invalid target version to fail")
+	}
+
+	findings, err := LintManifests(manifests, "1.29")
+	if err != nil {
+		t.Fatalf("failed to lint manifests: %v", err)
+	}
+
+	rules := make(map[string][]string)
+	for _, finding := range findings {
+		rules[finding.Name] = append(rules[finding.Name], finding.Rule+"/"+finding.Severity)
+	}
+
+	// Fully configured and autoscaled, so no single replica finding
+	if len(rules["web"]) != 0 {
+		t.Errorf("expected no findings for web, got %v", rules["web"])
+	}
+
+	expectedAgent := []string{"deprecated-api/High", "privileged/High", "host-namespaces/High", "resource-requests/Medium", "run-as-non-root/Medium", "privilege-escalation/Medium", "resource-limits/Low", "liveness-probe/Low", "readiness-probe/Medium"}
+	for _, rule := range expectedAgent {
+		found := false
+		for _, actual := range rules["agent"] {
+			found = found || actual == rule
+		}
+		if !found {
+			t.Errorf("expected %s for agent, got %v", rule, rules["agent"])
+		}
+	}
+	if len(rules["agent"]) != len(expectedAgent) {
+		t.Fatalf("expected %d findings for agent, got %v", len(expectedAgent), rules["agent"])
+	}
+	if rules["agent"][0] != "deprecated-api/High" || !strings.HasSuffix(rules["agent"][len(rules["agent"])-1], "/Low") {
+		t.Errorf("expected agent findings sorted by severity, got %v", rules["agent"])
+	}
+
+	// Jobs get resource and security checks but no probe checks, per container
+	backup := 0
+	for _, finding := range findings {
+		if finding.Name != "backup" {
+			continue
+		}
+		backup++
+		if strings.Contains(finding.Rule, "probe") {
+			t.Errorf("expected no probe checks for CronJobs, got %s", finding.Rule)
+		}
+		if !strings.Contains(finding.Issue, "container ") {
+			t.Errorf("expected multi-container issues to name the container, got %q", finding.Issue)
+		}
+	}
+	if backup != 13 {
+		t.Errorf("expected 13 findings for backup, got %d", backup)
+	}
+	if !strings.Contains(strings.Join(rules["backup"], ","), "privileged/High") {
+		t.Errorf("expected the privileged init container to be flagged, got %v", rules["backup"])
+	}
+
+	singleReplica, _ := ParseManifests(strings.NewReader(strings.Split(manifest, "---\n# Source: web/templates/hpa.yaml")[0]), "web.yaml")
+	findings, _ = LintManifests(singleReplica, "")
+	if len(findings) != 1 || findings[0].Rule != "single-replica" {
+		t.Errorf("expected only a single replica finding without the HPA, got %+v", findings)
+	}
+
+	// The live analysis shares the replica checks; security checks stay lint-only
+	spec, _, err := manifestPodSpec(manifests[2])
+	if err != nil {
+		t.Fatal(err)
+	}
+	agent := &appsv1.Deployment{Spec: appsv1.DeploymentSpec{Template: corev1.PodTemplateSpec{Spec: *spec}}}
+	health := (&Client{}).analyzeDeploymentHealth(agent, nil, nil, nil)
+	issues := strings.Join(health.Issues, "; ")
+	if !strings.Contains(issues, "Single replica") || strings.Contains(issues, "Runs as privileged") || strings.Contains(issues, "Shares host") {
+		t.Errorf("expected only the shared checks in the live analysis, got %s", issues)
+	}
+}
@@ -0,0 +1,263 @@
+package kubernetes
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+
+	appsv1 "k8s.io/api/apps/v1"
+	autoscalingv2 "k8s.io/api/autoscaling/v2"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/util/yaml"
+)
+
+// DefaultLintTargetVersion is used for deprecated API checks when no target
+// version is given; it matches the client libraries the CLI is built with.
+const DefaultLintTargetVersion = "1.33"
+
+// Manifest is one object from a YAML stream such as raw manifests,
+// `kustomize build` or `helm template` output.
+type Manifest struct {
+	Source     string
+	APIVersion string
+	Kind       string
+	Name       string
+	Namespace  string
+	Raw        []byte
+}
+
+type LintFinding struct {
+	Source         string
+	Kind           string
+	Name           string
+	Namespace      string
+	Rule           string
+	Severity       string
+	Issue          string
+	Recommendation string
+}
+
+// ParseManifests splits a YAML stream into objects. Documents rendered by
+// `helm template` are attributed to their "# Source:" template.
+func ParseManifests(r io.Reader, source string) ([]Manifest, error) {
+	var manifests []Manifest
+
+	reader := yaml.NewYAMLReader(bufio.NewReader(r))
+	for {
+		doc, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return manifests, fmt.Errorf("failed to read %s: %w", source, err)
+		}
+
+		var obj manifestObject
+		if err := yaml.Unmarshal(doc, &obj); err != nil {
+			return manifests, fmt.Errorf("failed to parse %s: %w", source, err)
+		}
+		if obj.Kind == "" {
+			continue
+		}
+
+		manifests = append(manifests, Manifest{
+			Source:     manifestSource(doc, source),
+			APIVersion: obj.APIVersion,
+			Kind:       obj.Kind,
+			Name:       obj.Metadata.Name,
+			Namespace:  obj.Metadata.Namespace,
+			Raw:        doc,
+		})
+	}
+
+	return manifests, nil
+}
+
+func manifestSource(doc []byte, source string) string {
+	for _, line := range bytes.Split(doc, []byte("\n")) {
+		line = bytes.TrimSpace(line)
+		if template, found := bytes.CutPrefix(line, []byte("# Source:")); found {
+			return source + ":" + strings.TrimSpace(string(template))
+		}
+		if len(line) > 0 && line[0] != '#' && !bytes.Equal(line, []byte("---")) {
+			break
+		}
+	}
+	return source
+}
+
+// LintManifests runs the live workload checks (resources, probes, replicas)
+// plus security context and deprecated API checks against parsed manifests.
+// HPAs in the same set exempt their targets from the single replica check.
+func LintManifests(manifests []Manifest, targetVersion string) ([]LintFinding, error) {
+	if targetVersion == "" {
+		targetVersion = DefaultLintTargetVersion
+	}
+	if _, err := parseMinorVersion(targetVersion); err != nil {
+		return nil, err
+	}
+
+	autoscaled := make(map[string]bool)
+	for _, manifest := range manifests {
+		if manifest.Kind != "HorizontalPodAutoscaler" {
+			continue
+		}
+		var hpa autoscalingv2.HorizontalPodAutoscaler
+		if err := yaml.Unmarshal(manifest.Raw, &hpa); err != nil {
+			continue
+		}
+		autoscaled[hpa.Spec.ScaleTargetRef.Kind+"/"+manifest.Namespace+"/"+hpa.Spec.ScaleTargetRef.Name] = true
+	}
+
+	var findings []LintFinding
+	for _, manifest := range manifests {
+		add := func(checks []workloadCheck) {
+			for _, check := range checks {
+				findings = append(findings, LintFinding{
+					Source:         manifest.Source,
+					Kind:           manifest.Kind,
+					Name:           manifest.Name,
+					Namespace:      manifest.Namespace,
+					Rule:           check.Rule,
+					Severity:       check.Severity,
+					Issue:          check.Issue,
+					Recommendation: check.Recommendation,
+				})
+			}
+		}
+
+		if deprecated := lookupDeprecatedAPI(manifest.APIVersion, manifest.Kind); deprecated != nil {
+			if finding := newDeprecatedAPIFinding(deprecated, manifest.Kind, manifest.Name, manifest.Namespace, manifest.Source, targetVersion); finding != nil {
+				add([]workloadCheck{{
+					Rule:           "deprecated-api",
+					Severity:       finding.Severity,
+					Issue:          fmt.Sprintf("%s: %s", finding.APIVersion, finding.Status),
+					Recommendation: fmt.Sprintf("Migrate to %s", finding.Replacement),
+				}})
+			}
+		}
+
+		spec, longRunning, err := manifestPodSpec(manifest)
+		if err != nil {
+			return findings, fmt.Errorf("failed to decode %s %s in %s: %w", manifest.Kind, manifest.Name, manifest.Source, err)
+		}
+		if spec == nil {
+			continue
+		}
+
+		if manifest.Kind == "Deployment" {
+			var deploy appsv1.Deployment
+			if err := yaml.Unmarshal(manifest.Raw, &deploy); err == nil {
+				add(replicaChecks(replicasOrDefault(deploy.Spec.Replicas), autoscaled["Deployment/"+manifest.Namespace+"/"+manifest.Name]))
+			}
+		}
+
+		// Init containers run to completion, so they get no probe checks
+		containerCount := len(spec.InitContainers) + len(spec.Containers)
+		for i, container := range append(append([]corev1.Container{}, spec.InitContainers...), spec.Containers...) {
+			init := i < len(spec.InitContainers)
+			checks := append(containerChecks(container, longRunning && !init), securityContextChecks(spec, container)...)
+			if containerCount > 1 {
+				label := "container"
+				if init {
+					label = "init container"
+				}
+				for j := range checks {
+					checks[j].Issue = fmt.Sprintf("%s (%s %s)", checks[j].Issue, label, container.Name)
+				}
+			}
+			add(checks)
+		}
+		add(hostNamespaceChecks(spec))
+	}
+
+	sort.SliceStable(findings, func(i, j int) bool {
+		if findings[i].Source != findings[j].Source {
+			return findings[i].Source < findings[j].Source
+		}
+		return severityRank(findings[i].Severity) > severityRank(findings[j].Severity)
+	})
+
+	return findings, nil
+}
+
+// manifestPodSpec returns the pod spec of workload kinds and whether the
+// workload is long-running (and so should have probes).
+func manifestPodSpec(manifest Manifest) (*corev1.PodSpec, bool, error) {
+	switch manifest.Kind {
+	case "Deployment":
+		var deploy appsv1.Deployment
+		err := yaml.Unmarshal(manifest.Raw, &deploy)
+		return &deploy.Spec.Template.Spec, true, err
+	case "StatefulSet":
+		var ss appsv1.StatefulSet
+		err := yaml.Unmarshal(manifest.Raw, &ss)
+		return &ss.Spec.Template.Spec, true, err
+	case "DaemonSet":
+		var ds appsv1.DaemonSet
+		err := yaml.Unmarshal(manifest.Raw, &ds)
+		return &ds.Spec.Template.Spec, true, err
+	case "ReplicaSet":
+		var rs appsv1.ReplicaSet
+		err := yaml.Unmarshal(manifest.Raw, &rs)
+		return &rs.Spec.Template.Spec, true, err
+	case "Job":
+		var job batchv1.Job
+		err := yaml.Unmarshal(manifest.Raw, &job)
+		return &job.Spec.Template.Spec, false, err
+	case "CronJob":
+		var cronJob batchv1.CronJob
+		err := yaml.Unmarshal(manifest.Raw, &cronJob)
+		return &cronJob.Spec.JobTemplate.Spec.Template.Spec, false, err
+	case "Pod":
+		var pod corev1.Pod
+		err := yaml.Unmarshal(manifest.Raw, &pod)
+		return &pod.Spec, false, err
+	}
+	return nil, false, nil
+}
+
+func securityContextChecks(spec *corev1.PodSpec, container corev1.Container) []workloadCheck {
+	var checks []workloadCheck
+	securityContext := container.SecurityContext
+
+	if securityContext != nil && securityContext.Privileged != nil && *securityContext.Privileged {
+		checks = append(checks, workloadCheck{"privileged", "High", "Runs as privileged", "Remove privileged: true and grant only the capabilities needed", 0})
+	}
+
+	runAsNonRoot := spec.SecurityContext != nil && spec.SecurityContext.RunAsNonRoot != nil && *spec.SecurityContext.RunAsNonRoot
+	if securityContext != nil && securityContext.RunAsNonRoot != nil {
+		runAsNonRoot = *securityContext.RunAsNonRoot
+	}
+	if !runAsNonRoot {
+		checks = append(checks, workloadCheck{"run-as-non-root", "Medium", "May run as root (runAsNonRoot not set)", "Set securityContext.runAsNonRoot: true", 0})
+	}
+
+	if securityContext == nil || securityContext.AllowPrivilegeEscalation == nil || *securityContext.AllowPrivilegeEscalation {
+		checks = append(checks, workloadCheck{"privilege-escalation", "Medium", "Privilege escalation not disabled", "Set securityContext.allowPrivilegeEscalation: false", 0})
+	}
+
+	return checks
+}
+
+func hostNamespaceChecks(spec *corev1.PodSpec) []workloadCheck {
+	var shared []string
+	if spec.HostNetwork {
+		shared = append(shared, "network")
+	}
+	if spec.HostPID {
+		shared = append(shared, "PID")
+	}
+	if spec.HostIPC {
+		shared = append(shared, "IPC")
+	}
+	if len(shared) == 0 {
+		return nil
+	}
+
+	return []workloadCheck{{"host-namespaces", "High", fmt.Sprintf("Shares host %s namespace", strings.Join(shared, "/")), "Remove hostNetwork/hostPID/hostIPC unless the workload is a node agent", 0}}
+}
@@ -15,6 +15,8 @@ type NodeMetrics struct {
 	MemoryUsagePercent float64
 	CPUCapacity        string
 	MemoryCapacity     string
+	CPUAllocatable     string
+	MemoryAllocatable  string
 	Status             string
 }
 
@@ -49,9 +51,19 @@ type ResourceUtilization struct {
 	Namespace      string
 	CPUUtilization float64
 	MemUtilization float64
+	VPACPUTarget   int64
+	VPAMemTarget   int64
+	Status         string
 	Recommendation string
 }
 
+// Utilization statuses of a pod's usage against its requests (or VPA target)
+const (
+	UtilizationOptimal       = "Optimal"
+	UtilizationUnderutilized = "Underutilized"
+	UtilizationOverutilized  = "Overutilized"
+)
+
 func (c *Client) GetRealTimeNodeMetrics() ([]NodeMetrics, error) {
 	nodeMetrics, err := c.MetricsClient.MetricsV1beta1().NodeMetricses().List(c.Context, metav1.ListOptions{})
 	if err != nil {
@@ -80,17 +92,12 @@ func (c *Client) GetRealTimeNodeMetrics() ([]NodeMetrics, error) {
 
 		cpuCapacity := node.Status.Capacity[corev1.ResourceCPU]
 		memCapacity := node.Status.Capacity[corev1.ResourceMemory]
+		cpuAllocatable := node.Status.Allocatable[corev1.ResourceCPU]
+		memAllocatable := node.Status.Allocatable[corev1.ResourceMemory]
 
-		cpuUsagePercent := float64(cpuUsage.MilliValue()) / float64(cpuCapacity.MilliValue()) * 100
-		memUsagePercent := float64(memUsage.Value()) / float64(memCapacity.Value()) * 100
-
-		status := "Ready"
-		for _, condition := range node.Status.Conditions {
-			if condition.Type == corev1.NodeReady && condition.Status != corev1.ConditionTrue {
-				status = "NotReady"
-				break
-			}
-		}
+		// Usage is relative to allocatable: reserved system resources are not available to pods
+		cpuUsagePercent := float64(cpuUsage.MilliValue()) / float64(cpuAllocatable.MilliValue()) * 100
+		memUsagePercent := float64(memUsage.Value()) / float64(memAllocatable.Value()) * 100
 
 		metrics = append(metrics, NodeMetrics{
 			Name:               metric.Name,
@@ -100,7 +107,9 @@ func (c *Client) GetRealTimeNodeMetrics() ([]NodeMetrics, error) {
 			MemoryUsagePercent: memUsagePercent,
 			CPUCapacity:        formatCPU(cpuCapacity.MilliValue()),
 			MemoryCapacity:     formatBytes(memCapacity.Value()),
-			Status:             status,
+			CPUAllocatable:     formatCPU(cpuAllocatable.MilliValue()),
+			MemoryAllocatable:  formatBytes(memAllocatable.Value()),
+			Status:             getNodeStatus(&node),
 		})
 	}
 
@@ -224,6 +233,13 @@ func (c *Client) GetResourceUtilization() ([]ResourceUtilization, error) {
 		return nil, fmt.Errorf("failed to get pods: %w", err)
 	}
 
+	// VPA recommendations replace the utilization heuristic when present
+	var vpas []VPAStatus
+	if items, err := c.listVPAs(""); err == nil {
+		vpas = items
+	}
+	autoscalers := newWorkloadAutoscalers(nil, vpas)
+
 	podInfo := make(map[string]corev1.Pod)
 	for _, pod := range pods.Items {
 		key := fmt.Sprintf("%s/%s", pod.Namespace, pod.Name)
@@ -249,7 +265,7 @@ func (c *Client) GetResourceUtilization() ([]ResourceUtilization, error) {
 		cpuRequests, memRequests := getPodResourceRequests(&pod)
 
 		var cpuUtilization, memUtilization float64
-		var recommendation string
+		var status, recommendation string
 
 		if cpuRequests > 0 {
 			cpuUtilization = float64(totalCPUUsage) / float64(cpuRequests) * 100
@@ -259,12 +275,20 @@ func (c *Client) GetResourceUtilization() ([]ResourceUtilization, error) {
 			memUtilization = float64(totalMemUsage) / float64(memRequests) * 100
 		}
 
-		if cpuUtilization < 20 && memUtilization < 20 {
-			recommendation = "Consider reducing resource requests - underutilized"
+		_, vpa := autoscalers.lookup(pod.Namespace, getPodWorkload(&pod))
+		vpaCPU, vpaMem, hasVPA := int64(0), int64(0), false
+		if vpa != nil {
+			vpaCPU, vpaMem, hasVPA = vpaTarget(vpa, &pod)
+		}
+
+		if hasVPA {
+			status, recommendation = vpaRecommendation(cpuRequests, memRequests, vpaCPU, vpaMem)
+		} else if cpuUtilization < 20 && memUtilization < 20 {
+			status, recommendation = UtilizationUnderutilized, "Consider reducing resource requests - underutilized"
 		} else if cpuUtilization > 90 || memUtilization > 90 {
-			recommendation = "Consider increasing resource requests - overutilized"
+			status, recommendation = UtilizationOverutilized, "Consider increasing resource requests - overutilized"
 		} else {
-			recommendation = "Resource allocation looks good"
+			status, recommendation = UtilizationOptimal, "Resource allocation looks good"
 		}
 
 		utilizations = append(utilizations, ResourceUtilization{
@@ -273,6 +297,9 @@ func (c *Client) GetResourceUtilization() ([]ResourceUtilization, error) {
 			Namespace:      metric.Namespace,
 			CPUUtilization: cpuUtilization,
 			MemUtilization: memUtilization,
+			VPACPUTarget:   vpaCPU,
+			VPAMemTarget:   vpaMem,
+			Status:         status,
 			Recommendation: recommendation,
 		})
 	}
@@ -280,6 +307,20 @@ func (c *Client) GetResourceUtilization() ([]ResourceUtilization, error) {
 	return utilizations, nil
 }
 
+// vpaRecommendation compares requests against the VPA target, allowing 30%
+// headroom either way before suggesting a change. It returns the utilization
+// status and the recommendation text.
+func vpaRecommendation(cpuRequests, memRequests, vpaCPU, vpaMem int64) (string, string) {
+	target := fmt.Sprintf("VPA target cpu %s, memory %s", formatCPU(vpaCPU), formatBytes(vpaMem))
+	switch {
+	case float64(cpuRequests) < float64(vpaCPU)/1.3 || float64(memRequests) < float64(vpaMem)/1.3:
+		return UtilizationOverutilized, target + " - increase resource requests - overutilized"
+	case float64(cpuRequests) > float64(vpaCPU)*1.3 || float64(memRequests) > float64(vpaMem)*1.3:
+		return UtilizationUnderutilized, target + " - reduce resource requests - underutilized"
+	}
+	return UtilizationOptimal, target + " - resource allocation looks good"
+}
+
 func getPodResourceRequests(pod *corev1.Pod) (int64, int64) {
 	var cpuRequests, memRequests int64
 	for _, container := range pod.Spec.Containers {
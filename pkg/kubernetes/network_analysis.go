@@ -0,0 +1,513 @@
+package kubernetes
+
+import (
+	"fmt"
+	"sort"
+
+	corev1 "k8s.io/api/core/v1"
+	discoveryv1 "k8s.io/api/discovery/v1"
+	networkingv1 "k8s.io/api/networking/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+)
+
+type NetworkAnalysis struct {
+	NamespaceCoverage []NamespaceNetworkCoverage
+	UncoveredPods     []UncoveredPod
+	ExposedServices   []ExposedService
+	InsecureIngresses []IngressTLSIssue
+	ServiceIssues     []ServiceEndpointIssue
+	Recommendations   []string
+	NetworkSummary    NetworkSummary
+}
+
+type NamespaceNetworkCoverage struct {
+	Namespace       string
+	NetworkPolicies int
+	TotalPods       int
+	CoveredPods     int
+	CoveragePercent float64
+	DefaultDeny     bool
+	Status          string
+}
+
+type UncoveredPod struct {
+	Name      string
+	Namespace string
+	Node      string
+	Owner     string
+}
+
+type ExposedService struct {
+	Name        string
+	Namespace   string
+	Type        string
+	ExternalIPs []string
+	Ports       []string
+	RiskLevel   string
+}
+
+type IngressTLSIssue struct {
+	Name      string
+	Namespace string
+	Hosts     []string
+	Issue     string
+}
+
+type ServiceEndpointIssue struct {
+	Name      string
+	Namespace string
+	Selector  string
+	Issue     string
+}
+
+type NetworkSummary struct {
+	TotalNamespaces          int
+	UncoveredNamespaces      int
+	TotalPods                int
+	UncoveredPods            int
+	ExposedServices          int
+	InsecureIngresses        int
+	ServicesWithoutEndpoints int
+	CoveragePercent          float64
+}
+
+func (c *Client) GetNetworkAnalysis(namespace string) (*NetworkAnalysis, error) {
+	pods, err := c.Clientset.CoreV1().Pods(namespace).List(c.Context, metav1.ListOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get pods: %w", err)
+	}
+
+	policies, err := c.Clientset.NetworkingV1().NetworkPolicies(namespace).List(c.Context, metav1.ListOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get network policies: %w", err)
+	}
+
+	services, err := c.Clientset.CoreV1().Services(namespace).List(c.Context, metav1.ListOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get services: %w", err)
+	}
+
+	ingresses, err := c.Clientset.NetworkingV1().Ingresses(namespace).List(c.Context, metav1.ListOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get ingresses: %w", err)
+	}
+
+	readyEndpoints, err := c.getReadyServiceEndpoints(namespace)
+	if err != nil {
+		return nil, err
+	}
+
+	var namespaces []string
+	if namespace != "" {
+		namespaces = []string{namespace}
+	} else {
+		nsList, err := c.Clientset.CoreV1().Namespaces().List(c.Context, metav1.ListOptions{})
+		if err != nil {
+			return nil, fmt.Errorf("failed to get namespaces: %w", err)
+		}
+		for _, ns := range nsList.Items {
+			namespaces = append(namespaces, ns.Name)
+		}
+	}
+
+	analysis := &NetworkAnalysis{}
+	analysis.NamespaceCoverage, analysis.UncoveredPods = analyzeNetworkPolicyCoverage(namespaces, pods.Items, policies.Items)
+	analysis.ExposedServices = findExposedServices(services.Items)
+	analysis.InsecureIngresses = findIngressesWithoutTLS(ingresses.Items)
+	analysis.ServiceIssues = findServicesWithoutEndpoints(services.Items, pods.Items, readyEndpoints)
+	analysis.NetworkSummary = calculateNetworkSummary(analysis)
+	analysis.Recommendations = generateNetworkRecommendations(analysis)
+
+	return analysis, nil
+}
+
+func analyzeNetworkPolicyCoverage(namespaces []string, pods []corev1.Pod, policies []networkingv1.NetworkPolicy) ([]NamespaceNetworkCoverage, []UncoveredPod) {
+	policiesByNamespace := make(map[string][]networkingv1.NetworkPolicy)
+	for _, policy := range policies {
+		policiesByNamespace[policy.Namespace] = append(policiesByNamespace[policy.Namespace], policy)
+	}
+
+	podsByNamespace := make(map[string][]corev1.Pod)
+	for _, pod := range pods {
+		if pod.Status.Phase == corev1.PodSucceeded || pod.Status.Phase == corev1.PodFailed {
+			continue
+		}
+		if pod.Spec.HostNetwork {
+			continue
+		}
+		podsByNamespace[pod.Namespace] = append(podsByNamespace[pod.Namespace], pod)
+	}
+
+	var coverage []NamespaceNetworkCoverage
+	var uncovered []UncoveredPod
+
+	for _, ns := range namespaces {
+		nsPolicies := policiesByNamespace[ns]
+		nsPods := podsByNamespace[ns]
+
+		entry := NamespaceNetworkCoverage{
+			Namespace:       ns,
+			NetworkPolicies: len(nsPolicies),
+			TotalPods:       len(nsPods),
+		}
+
+		for _, policy := range nsPolicies {
+			if isDefaultDenyPolicy(&policy) {
+				entry.DefaultDeny = true
+			}
+		}
+
+		for _, pod := range nsPods {
+			if isPodSelectedByAnyPolicy(&pod, nsPolicies) {
+				entry.CoveredPods++
+				continue
+			}
+			uncovered = append(uncovered, UncoveredPod{
+				Name:      pod.Name,
+				Namespace: pod.Namespace,
+				Node:      pod.Spec.NodeName,
+				Owner:     getPodOwner(&pod),
+			})
+		}
+
+		if entry.TotalPods > 0 {
+			entry.CoveragePercent = float64(entry.CoveredPods) / float64(entry.TotalPods) * 100
+		} else {
+			entry.CoveragePercent = 100
+		}
+
+		switch {
+		case entry.TotalPods == 0:
+			entry.Status = "Empty"
+		case entry.CoveredPods == entry.TotalPods:
+			entry.Status = "Covered"
+		case entry.CoveredPods > 0:
+			entry.Status = "Partial"
+		default:
+			entry.Status = "Uncovered"
+		}
+
+		coverage = append(coverage, entry)
+	}
+
+	sort.Slice(coverage, func(i, j int) bool {
+		return coverage[i].CoveragePercent < coverage[j].CoveragePercent
+	})
+
+	return coverage, uncovered
+}
+
+// isDefaultDenyPolicy reports whether a policy selects every pod and allows
+// no traffic for at least one of its policy types. Ingress is implied when
+// policyTypes is empty.
+func isDefaultDenyPolicy(policy *networkingv1.NetworkPolicy) bool {
+	if len(policy.Spec.PodSelector.MatchLabels) != 0 || len(policy.Spec.PodSelector.MatchExpressions) != 0 {
+		return false
+	}
+
+	ingress, egress := len(policy.Spec.PolicyTypes) == 0, false
+	for _, policyType := range policy.Spec.PolicyTypes {
+		switch policyType {
+		case networkingv1.PolicyTypeIngress:
+			ingress = true
+		case networkingv1.PolicyTypeEgress:
+			egress = true
+		}
+	}
+	return (ingress && len(policy.Spec.Ingress) == 0) || (egress && len(policy.Spec.Egress) == 0)
+}
+
+func isPodSelectedByAnyPolicy(pod *corev1.Pod, policies []networkingv1.NetworkPolicy) bool {
+	for _, policy := range policies {
+		selector, err := metav1.LabelSelectorAsSelector(&policy.Spec.PodSelector)
+		if err != nil {
+			continue
+		}
+		if selector.Matches(labels.Set(pod.Labels)) {
+			return true
+		}
+	}
+	return false
+}
+
+func findExposedServices(services []corev1.Service) []ExposedService {
+	var exposed []ExposedService
+
+	for _, svc := range services {
+		isExternal := svc.Spec.Type == corev1.ServiceTypeLoadBalancer || svc.Spec.Type == corev1.ServiceTypeNodePort
+		if !isExternal && len(svc.Spec.ExternalIPs) == 0 {
+			continue
+		}
+
+		externalIPs := append([]string{}, svc.Spec.ExternalIPs...)
+		for _, ingress := range svc.Status.LoadBalancer.Ingress {
+			if ingress.IP != "" {
+				externalIPs = append(externalIPs, ingress.IP)
+			} else if ingress.Hostname != "" {
+				externalIPs = append(externalIPs, ingress.Hostname)
+			}
+		}
+
+		var ports []string
+		for _, port := range svc.Spec.Ports {
+			if port.NodePort != 0 {
+				ports = append(ports, fmt.Sprintf("%d:%d/%s", port.Port, port.NodePort, port.Protocol))
+			} else {
+				ports = append(ports, fmt.Sprintf("%d/%s", port.Port, port.Protocol))
+			}
+		}
+
+		riskLevel := "Medium"
+		if svc.Spec.Type == corev1.ServiceTypeLoadBalancer && len(svc.Spec.LoadBalancerSourceRanges) == 0 {
+			riskLevel = "High"
+		} else if len(svc.Spec.ExternalIPs) > 0 {
+			riskLevel = "High"
+		}
+
+		serviceType := string(svc.Spec.Type)
+		if len(svc.Spec.ExternalIPs) > 0 && !isExternal {
+			serviceType += " (externalIPs)"
+		}
+
+		exposed = append(exposed, ExposedService{
+			Name:        svc.Name,
+			Namespace:   svc.Namespace,
+			Type:        serviceType,
+			ExternalIPs: externalIPs,
+			Ports:       ports,
+			RiskLevel:   riskLevel,
+		})
+	}
+
+	sort.Slice(exposed, func(i, j int) bool {
+		if exposed[i].RiskLevel != exposed[j].RiskLevel {
+			return exposed[i].RiskLevel == "High"
+		}
+		return exposed[i].Namespace+"/"+exposed[i].Name < exposed[j].Namespace+"/"+exposed[j].Name
+	})
+
+	return exposed
+}
+
+func findIngressesWithoutTLS(ingresses []networkingv1.Ingress) []IngressTLSIssue {
+	var issues []IngressTLSIssue
+
+	for _, ing := range ingresses {
+		var hosts []string
+		for _, rule := range ing.Spec.Rules {
+			if rule.Host != "" {
+				hosts = append(hosts, rule.Host)
+			}
+		}
+
+		if len(ing.Spec.TLS) == 0 {
+			issues = append(issues, IngressTLSIssue{
+				Name:      ing.Name,
+				Namespace: ing.Namespace,
+				Hosts:     hosts,
+				Issue:     "No TLS configured",
+			})
+			continue
+		}
+
+		tlsHosts := make(map[string]bool)
+		for _, tls := range ing.Spec.TLS {
+			for _, host := range tls.Hosts {
+				tlsHosts[host] = true
+			}
+		}
+
+		var plainHosts []string
+		for _, host := range hosts {
+			if !tlsHosts[host] {
+				plainHosts = append(plainHosts, host)
+			}
+		}
+
+		if len(plainHosts) > 0 {
+			issues = append(issues, IngressTLSIssue{
+				Name:      ing.Name,
+				Namespace: ing.Namespace,
+				Hosts:     plainHosts,
+				Issue:     "Some hosts are not covered by TLS",
+			})
+		}
+	}
+
+	return issues
+}
+
+// findServicesWithoutEndpoints reports services whose selector matches no
+// pods or that have no ready endpoints, keyed "namespace/name" in
+// readyEndpoints.
+func findServicesWithoutEndpoints(services []corev1.Service, pods []corev1.Pod, readyEndpoints map[string]bool) []ServiceEndpointIssue {
+	var issues []ServiceEndpointIssue
+
+	for _, svc := range services {
+		if svc.Spec.Type == corev1.ServiceTypeExternalName {
+			continue
+		}
+
+		if len(svc.Spec.Selector) == 0 {
+			// Selector-less services are backed by manually managed endpoints
+			if !readyEndpoints[svc.Namespace+"/"+svc.Name] {
+				issues = append(issues, ServiceEndpointIssue{
+					Name:      svc.Name,
+					Namespace: svc.Namespace,
+					Selector:  "<none>",
+					Issue:     "No selector and no endpoints",
+				})
+			}
+			continue
+		}
+
+		selector := labels.SelectorFromSet(svc.Spec.Selector)
+		matchingPods := 0
+		for _, pod := range pods {
+			if pod.Namespace == svc.Namespace && selector.Matches(labels.Set(pod.Labels)) {
+				matchingPods++
+			}
+		}
+
+		if matchingPods == 0 {
+			issues = append(issues, ServiceEndpointIssue{
+				Name:      svc.Name,
+				Namespace: svc.Namespace,
+				Selector:  selector.String(),
+				Issue:     "Selector matches no pods",
+			})
+			continue
+		}
+
+		if !readyEndpoints[svc.Namespace+"/"+svc.Name] {
+			issues = append(issues, ServiceEndpointIssue{
+				Name:      svc.Name,
+				Namespace: svc.Namespace,
+				Selector:  selector.String(),
+				Issue:     fmt.Sprintf("%d matching pods but no ready endpoints", matchingPods),
+			})
+		}
+	}
+
+	return issues
+}
+
+// getReadyServiceEndpoints lists EndpointSlices once and returns the
+// services ("namespace/name") with at least one ready endpoint. Clusters that
+// do not serve EndpointSlices fall back to the Endpoints API.
+func (c *Client) getReadyServiceEndpoints(namespace string) (map[string]bool, error) {
+	slices, err := c.Clientset.DiscoveryV1().EndpointSlices(namespace).List(c.Context, metav1.ListOptions{})
+	if err == nil {
+		return readyServiceEndpoints(slices.Items), nil
+	}
+
+	endpoints, err := c.Clientset.CoreV1().Endpoints(namespace).List(c.Context, metav1.ListOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get endpoints: %w", err)
+	}
+	ready := make(map[string]bool)
+	for _, endpoint := range endpoints.Items {
+		for _, subset := range endpoint.Subsets {
+			if len(subset.Addresses) > 0 {
+				ready[endpoint.Namespace+"/"+endpoint.Name] = true
+			}
+		}
+	}
+	return ready, nil
+}
+
+// readyServiceEndpoints indexes EndpointSlices by their owning service
+// (the kubernetes.io/service-name label), keeping services with at least one
+// ready endpoint.
+func readyServiceEndpoints(slices []discoveryv1.EndpointSlice) map[string]bool {
+	ready := make(map[string]bool)
+	for _, slice := range slices {
+		service, exists := slice.Labels[discoveryv1.LabelServiceName]
+		if !exists {
+			continue
+		}
+		for _, endpoint := range slice.Endpoints {
+			if endpoint.Conditions.Ready == nil || *endpoint.Conditions.Ready {
+				ready[slice.Namespace+"/"+service] = true
+			}
+		}
+	}
+	return ready
+}
+
+func calculateNetworkSummary(analysis *NetworkAnalysis) NetworkSummary {
+	summary := NetworkSummary{
+		TotalNamespaces:          len(analysis.NamespaceCoverage),
+		UncoveredPods:            len(analysis.UncoveredPods),
+		ExposedServices:          len(analysis.ExposedServices),
+		InsecureIngresses:        len(analysis.InsecureIngresses),
+		ServicesWithoutEndpoints: len(analysis.ServiceIssues),
+	}
+
+	coveredPods := 0
+	for _, ns := range analysis.NamespaceCoverage {
+		summary.TotalPods += ns.TotalPods
+		coveredPods += ns.CoveredPods
+		if ns.Status == "Uncovered" {
+			summary.UncoveredNamespaces++
+		}
+	}
+
+	if summary.TotalPods > 0 {
+		summary.CoveragePercent = float64(coveredPods) / float64(summary.TotalPods) * 100
+	} else {
+		summary.CoveragePercent = 100
+	}
+
+	return summary
+}
+
+func generateNetworkRecommendations(analysis *NetworkAnalysis) []string {
+	var recommendations []string
+	summary := analysis.NetworkSummary
+
+	if summary.UncoveredNamespaces > 0 {
+		recommendations = append(recommendations, fmt.Sprintf("Add a default-deny NetworkPolicy to %d namespaces without any policy coverage", summary.UncoveredNamespaces))
+	}
+
+	if summary.UncoveredPods > 0 && summary.UncoveredNamespaces == 0 {
+		recommendations = append(recommendations, fmt.Sprintf("Extend NetworkPolicy selectors to cover %d unselected pods", summary.UncoveredPods))
+	}
+
+	highRisk := 0
+	for _, svc := range analysis.ExposedServices {
+		if svc.RiskLevel == "High" {
+			highRisk++
+		}
+	}
+	if highRisk > 0 {
+		recommendations = append(recommendations, fmt.Sprintf("Restrict %d externally exposed services with loadBalancerSourceRanges or move them behind an Ingress", highRisk))
+	}
+
+	if summary.InsecureIngresses > 0 {
+		recommendations = append(recommendations, fmt.Sprintf("Configure TLS for %d ingresses serving plain HTTP (e.g. with cert-manager)", summary.InsecureIngresses))
+	}
+
+	if summary.ServicesWithoutEndpoints > 0 {
+		recommendations = append(recommendations, fmt.Sprintf("Fix selectors or remove %d services that have no backing endpoints", summary.ServicesWithoutEndpoints))
+	}
+
+	return recommendations
+}
+
+func getPodOwner(pod *corev1.Pod) string {
+	return getObjectOwner(pod.OwnerReferences)
+}
+
+func getObjectOwner(ownerReferences []metav1.OwnerReference) string {
+	for _, ownerRef := range ownerReferences {
+		if ownerRef.Controller != nil && *ownerRef.Controller {
+			return fmt.Sprintf("%s/%s", ownerRef.Kind, ownerRef.Name)
+		}
+	}
+	if len(ownerReferences) > 0 {
+		return fmt.Sprintf("%s/%s", ownerReferences[0].Kind, ownerReferences[0].Name)
+	}
+	return "<none>"
+}
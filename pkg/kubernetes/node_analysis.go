@@ -0,0 +1,241 @@
+package kubernetes
+
+import (
+	"fmt"
+	"sort"
+
+	corev1 "k8s.io/api/core/v1"
+	policyv1 "k8s.io/api/policy/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+type NodeAnalysis struct {
+	ControlPlaneVersion string
+	Nodes               []NodeDetail
+	Inventory           NodeInventory
+	MetricsAvailable    bool
+}
+
+type NodeDetail struct {
+	Name          string
+	Status        string
+	Roles         string
+	Age           string
+	Unschedulable bool
+	Taints        []string
+	Conditions    []string
+
+	CPUCapacity       int64
+	CPUAllocatable    int64
+	CPURequests       int64
+	CPULimits         int64
+	CPUUsage          int64
+	MemoryCapacity    int64
+	MemoryAllocatable int64
+	MemoryRequests    int64
+	MemoryLimits      int64
+	MemoryUsage       int64
+	Pods              int64
+	PodCapacity       int64
+
+	CPUOvercommit    float64
+	MemoryOvercommit float64
+
+	KubeletVersion   string
+	VersionSkew      int
+	KernelVersion    string
+	OSImage          string
+	ContainerRuntime string
+	Architecture     string
+
+	Issues        []string
+	DrainBlockers []string
+}
+
+type NodeInventory struct {
+	KubeletVersions   map[string]int
+	KernelVersions    map[string]int
+	OSImages          map[string]int
+	ContainerRuntimes map[string]int
+}
+
+// GetNodeAnalysis reports allocatable, requested, limited and used resources
+// per node together with conditions, taints and version inventory.
+func (c *Client) GetNodeAnalysis() (*NodeAnalysis, error) {
+	nodes, err := c.Clientset.CoreV1().Nodes().List(c.Context, metav1.ListOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get nodes: %w", err)
+	}
+
+	pods, err := c.Clientset.CoreV1().Pods("").List(c.Context, metav1.ListOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get pods: %w", err)
+	}
+
+	analysis := &NodeAnalysis{
+		Inventory: NodeInventory{
+			KubeletVersions:   make(map[string]int),
+			KernelVersions:    make(map[string]int),
+			OSImages:          make(map[string]int),
+			ContainerRuntimes: make(map[string]int),
+		},
+	}
+
+	controlPlaneMinor := -1
+	if version, err := c.Clientset.Discovery().ServerVersion(); err == nil {
+		analysis.ControlPlaneVersion = version.GitVersion
+		if minor, err := parseMinorVersion(version.GitVersion); err == nil {
+			controlPlaneMinor = minor
+		}
+	}
+
+	usage := make(map[string]corev1.ResourceList)
+	if nodeMetrics, err := c.MetricsClient.MetricsV1beta1().NodeMetricses().List(c.Context, metav1.ListOptions{}); err == nil {
+		analysis.MetricsAvailable = true
+		for _, metric := range nodeMetrics.Items {
+			usage[metric.Name] = metric.Usage
+		}
+	}
+
+	// Drain readiness is best effort when budgets cannot be listed
+	pdbs, _ := c.listPodDisruptionBudgets("")
+
+	podsByNode := make(map[string][]corev1.Pod)
+	for _, pod := range pods.Items {
+		if pod.Spec.NodeName != "" {
+			podsByNode[pod.Spec.NodeName] = append(podsByNode[pod.Spec.NodeName], pod)
+		}
+	}
+
+	for i := range nodes.Items {
+		node := &nodes.Items[i]
+		detail := buildNodeDetail(node, podsByNode[node.Name], usage[node.Name], controlPlaneMinor, pdbs)
+		analysis.Nodes = append(analysis.Nodes, detail)
+
+		analysis.Inventory.KubeletVersions[detail.KubeletVersion]++
+		analysis.Inventory.KernelVersions[detail.KernelVersion]++
+		analysis.Inventory.OSImages[detail.OSImage]++
+		analysis.Inventory.ContainerRuntimes[detail.ContainerRuntime]++
+	}
+
+	sort.SliceStable(analysis.Nodes, func(i, j int) bool {
+		if len(analysis.Nodes[i].Issues) != len(analysis.Nodes[j].Issues) {
+			return len(analysis.Nodes[i].Issues) > len(analysis.Nodes[j].Issues)
+		}
+		return analysis.Nodes[i].Name < analysis.Nodes[j].Name
+	})
+
+	return analysis, nil
+}
+
+func buildNodeDetail(node *corev1.Node, pods []corev1.Pod, usage corev1.ResourceList, controlPlaneMinor int, pdbs []policyv1.PodDisruptionBudget) NodeDetail {
+	info := node.Status.NodeInfo
+	detail := NodeDetail{
+		Name:              node.Name,
+		Status:            getNodeStatus(node),
+		Roles:             getSimpleRoles(node),
+		Age:               getSimpleAge(node.CreationTimestamp.Time),
+		Unschedulable:     node.Spec.Unschedulable,
+		CPUCapacity:       node.Status.Capacity.Cpu().MilliValue(),
+		CPUAllocatable:    node.Status.Allocatable.Cpu().MilliValue(),
+		MemoryCapacity:    node.Status.Capacity.Memory().Value(),
+		MemoryAllocatable: node.Status.Allocatable.Memory().Value(),
+		PodCapacity:       node.Status.Allocatable.Pods().Value(),
+		KubeletVersion:    info.KubeletVersion,
+		KernelVersion:     info.KernelVersion,
+		OSImage:           info.OSImage,
+		ContainerRuntime:  info.ContainerRuntimeVersion,
+		Architecture:      info.Architecture,
+	}
+
+	for i := range pods {
+		if !isPodActive(&pods[i]) {
+			continue
+		}
+		cpuRequests, memRequests := getPodResourceRequests(&pods[i])
+		cpuLimits, memLimits := getPodResourceLimits(&pods[i])
+		detail.CPURequests += cpuRequests
+		detail.MemoryRequests += memRequests
+		detail.CPULimits += cpuLimits
+		detail.MemoryLimits += memLimits
+		detail.Pods++
+
+		if !isDaemonSetPod(&pods[i]) && !isMirrorPod(&pods[i]) {
+			detail.DrainBlockers = append(detail.DrainBlockers, evictionBlockers(&pods[i], pdbs)...)
+		}
+	}
+
+	if usage != nil {
+		detail.CPUUsage = usage.Cpu().MilliValue()
+		detail.MemoryUsage = usage.Memory().Value()
+	}
+
+	if detail.CPUAllocatable > 0 {
+		detail.CPUOvercommit = float64(detail.CPULimits) / float64(detail.CPUAllocatable)
+	}
+	if detail.MemoryAllocatable > 0 {
+		detail.MemoryOvercommit = float64(detail.MemoryLimits) / float64(detail.MemoryAllocatable)
+	}
+
+	for _, condition := range node.Status.Conditions {
+		switch {
+		case condition.Type == corev1.NodeReady && condition.Status != corev1.ConditionTrue:
+			detail.Conditions = append(detail.Conditions, fmt.Sprintf("NotReady: %s", condition.Message))
+			detail.Issues = append(detail.Issues, "Node is not Ready")
+		case condition.Type != corev1.NodeReady && condition.Status == corev1.ConditionTrue:
+			detail.Conditions = append(detail.Conditions, fmt.Sprintf("%s: %s", condition.Type, condition.Message))
+			detail.Issues = append(detail.Issues, fmt.Sprintf("%s reported", condition.Type))
+		}
+	}
+
+	for _, taint := range node.Spec.Taints {
+		detail.Taints = append(detail.Taints, taint.ToString())
+	}
+
+	if node.Spec.Unschedulable {
+		detail.Issues = append(detail.Issues, "Node is cordoned")
+	}
+	if detail.CPUAllocatable > 0 && detail.CPURequests > detail.CPUAllocatable {
+		detail.Issues = append(detail.Issues, "CPU requests exceed allocatable")
+	}
+	if detail.MemoryOvercommit > 1.5 {
+		detail.Issues = append(detail.Issues, fmt.Sprintf("Memory limits overcommitted %.1fx", detail.MemoryOvercommit))
+	}
+	if detail.MemoryAllocatable > 0 && float64(detail.MemoryUsage)/float64(detail.MemoryAllocatable) > 0.9 {
+		detail.Issues = append(detail.Issues, "Memory usage above 90% of allocatable")
+	}
+	if detail.PodCapacity > 0 && float64(detail.Pods)/float64(detail.PodCapacity) > 0.9 {
+		detail.Issues = append(detail.Issues, fmt.Sprintf("Pod count near limit (%d/%d)", detail.Pods, detail.PodCapacity))
+	}
+
+	if kubeletMinor, err := parseMinorVersion(detail.KubeletVersion); err == nil && controlPlaneMinor >= 0 {
+		detail.VersionSkew = controlPlaneMinor - kubeletMinor
+		// Kubelets may be up to three minor versions older than the API server, never newer
+		if detail.VersionSkew < 0 {
+			detail.Issues = append(detail.Issues, "Kubelet is newer than the control plane")
+		} else if detail.VersionSkew > 3 {
+			detail.Issues = append(detail.Issues, fmt.Sprintf("Kubelet is %d minor versions behind the control plane", detail.VersionSkew))
+		}
+	}
+
+	return detail
+}
+
+// getNodeStatus mirrors the STATUS column of kubectl get nodes.
+func getNodeStatus(node *corev1.Node) string {
+	status := "Unknown"
+	for _, condition := range node.Status.Conditions {
+		if condition.Type == corev1.NodeReady {
+			if condition.Status == corev1.ConditionTrue {
+				status = "Ready"
+			} else {
+				status = "NotReady"
+			}
+			break
+		}
+	}
+	if node.Spec.Unschedulable {
+		status += ",SchedulingDisabled"
+	}
+	return status
+}
@@ -0,0 +1,188 @@
+package kubernetes
+
+import (
+	"fmt"
+	"sort"
+
+	corev1 "k8s.io/api/core/v1"
+	policyv1 "k8s.io/api/policy/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/util/intstr"
+)
+
+type PDBAudit struct {
+	Name               string
+	Namespace          string
+	MinAvailable       string
+	MaxUnavailable     string
+	MatchedPods        int
+	ExpectedPods       int32
+	CurrentHealthy     int32
+	DesiredHealthy     int32
+	DisruptionsAllowed int32
+	Workloads          []string
+	Status             string
+	Issues             []string
+}
+
+func (c *Client) listPodDisruptionBudgets(namespace string) ([]policyv1.PodDisruptionBudget, error) {
+	pdbs, err := c.Clientset.PolicyV1().PodDisruptionBudgets(namespace).List(c.Context, metav1.ListOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get pod disruption budgets: %w", err)
+	}
+	return pdbs.Items, nil
+}
+
+// GetPDBAudit checks every PodDisruptionBudget for empty selectors, budgets
+// that block all evictions and currently disallowed disruptions.
+func (c *Client) GetPDBAudit(namespace string) ([]PDBAudit, error) {
+	pdbs, err := c.listPodDisruptionBudgets(namespace)
+	if err != nil {
+		return nil, err
+	}
+
+	pods, err := c.Clientset.CoreV1().Pods(namespace).List(c.Context, metav1.ListOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get pods: %w", err)
+	}
+
+	return auditPDBs(pdbs, pods.Items), nil
+}
+
+func auditPDBs(pdbs []policyv1.PodDisruptionBudget, pods []corev1.Pod) []PDBAudit {
+	var audits []PDBAudit
+
+	for _, pdb := range pdbs {
+		audit := PDBAudit{
+			Name:               pdb.Name,
+			Namespace:          pdb.Namespace,
+			MinAvailable:       "-",
+			MaxUnavailable:     "-",
+			ExpectedPods:       pdb.Status.ExpectedPods,
+			CurrentHealthy:     pdb.Status.CurrentHealthy,
+			DesiredHealthy:     pdb.Status.DesiredHealthy,
+			DisruptionsAllowed: pdb.Status.DisruptionsAllowed,
+		}
+		if pdb.Spec.MinAvailable != nil {
+			audit.MinAvailable = pdb.Spec.MinAvailable.String()
+		}
+		if pdb.Spec.MaxUnavailable != nil {
+			audit.MaxUnavailable = pdb.Spec.MaxUnavailable.String()
+		}
+
+		workloads := make(map[string]bool)
+		for i := range pods {
+			pod := &pods[i]
+			if isPodActive(pod) && pdbSelects(&pdb, pod.Namespace, pod.Labels) {
+				audit.MatchedPods++
+				workloads[getPodWorkload(pod)] = true
+			}
+		}
+		audit.Workloads = sortedKeys(workloads)
+
+		if audit.MatchedPods == 0 {
+			audit.Issues = append(audit.Issues, "Selects no pods")
+		} else if pdbBlocksAllEvictions(&pdb, int32(audit.MatchedPods)) {
+			audit.Issues = append(audit.Issues, "Blocks all voluntary evictions (node drains will hang)")
+		} else if pdb.Status.DisruptionsAllowed == 0 {
+			audit.Issues = append(audit.Issues, fmt.Sprintf("No disruptions currently allowed (%d/%d healthy)", pdb.Status.CurrentHealthy, pdb.Status.DesiredHealthy))
+		}
+
+		switch {
+		case audit.MatchedPods > 0 && pdbBlocksAllEvictions(&pdb, int32(audit.MatchedPods)):
+			audit.Status = "Critical"
+		case len(audit.Issues) > 0:
+			audit.Status = "Warning"
+		default:
+			audit.Status = "Healthy"
+		}
+
+		audits = append(audits, audit)
+	}
+
+	sort.SliceStable(audits, func(i, j int) bool {
+		if eventSeverityRank(audits[i].Status) != eventSeverityRank(audits[j].Status) {
+			return eventSeverityRank(audits[i].Status) > eventSeverityRank(audits[j].Status)
+		}
+		return audits[i].Namespace+"/"+audits[i].Name < audits[j].Namespace+"/"+audits[j].Name
+	})
+
+	return audits
+}
+
+// pdbBlocksAllEvictions reports whether the budget can never allow a
+// voluntary disruption for the given number of pods, regardless of health.
+func pdbBlocksAllEvictions(pdb *policyv1.PodDisruptionBudget, replicas int32) bool {
+	if pdb.Spec.MaxUnavailable != nil {
+		maxUnavailable, err := intstr.GetScaledValueFromIntOrPercent(pdb.Spec.MaxUnavailable, int(replicas), true)
+		return err == nil && maxUnavailable == 0
+	}
+	if pdb.Spec.MinAvailable != nil {
+		minAvailable, err := intstr.GetScaledValueFromIntOrPercent(pdb.Spec.MinAvailable, int(replicas), true)
+		return err == nil && int32(minAvailable) >= replicas
+	}
+	return false
+}
+
+// matchingPDBs returns the budgets in namespace whose selector matches the
+// given pod template labels.
+func matchingPDBs(pdbs []policyv1.PodDisruptionBudget, namespace string, podLabels map[string]string) []policyv1.PodDisruptionBudget {
+	var matched []policyv1.PodDisruptionBudget
+	for _, pdb := range pdbs {
+		if pdbSelects(&pdb, namespace, podLabels) {
+			matched = append(matched, pdb)
+		}
+	}
+	return matched
+}
+
+// pdbSelects reports whether the budget covers a pod with the given labels.
+// An empty selector matches every pod in the namespace; a nil one converts
+// to a selector that matches nothing.
+func pdbSelects(pdb *policyv1.PodDisruptionBudget, namespace string, podLabels map[string]string) bool {
+	if pdb.Namespace != namespace {
+		return false
+	}
+	selector, err := metav1.LabelSelectorAsSelector(pdb.Spec.Selector)
+	return err == nil && selector.Matches(labels.Set(podLabels))
+}
+
+// checkDisruptionReadiness returns the budget covering a workload, its PDB
+// issues and recommendations and the health score penalty they carry. A
+// workload scaled to zero has nothing to evict, so it is not checked.
+func checkDisruptionReadiness(namespace string, replicas int32, template corev1.PodTemplateSpec, pdbs []policyv1.PodDisruptionBudget) (pdbName string, issues, recommendations []string, penalty int) {
+	matched := matchingPDBs(pdbs, namespace, template.Labels)
+
+	if replicas == 0 {
+		if len(matched) > 0 {
+			pdbName = matched[0].Name
+		}
+		return pdbName, nil, nil, 0
+	}
+
+	if len(matched) == 0 {
+		if replicas > 1 {
+			issues = append(issues, "No PodDisruptionBudget")
+			recommendations = append(recommendations, "Add a PodDisruptionBudget so node drains keep enough replicas available")
+			penalty += 10
+		}
+		return "", issues, recommendations, penalty
+	}
+
+	pdb := matched[0]
+	if pdbBlocksAllEvictions(&pdb, replicas) {
+		issues = append(issues, fmt.Sprintf("PodDisruptionBudget %s blocks all evictions", pdb.Name))
+		recommendations = append(recommendations, "Allow at least one disruption (maxUnavailable >= 1 or minAvailable < replicas)")
+		penalty += 15
+	} else if pdb.Status.DisruptionsAllowed == 0 {
+		issues = append(issues, fmt.Sprintf("PodDisruptionBudget %s currently allows no disruptions", pdb.Name))
+		penalty += 5
+	}
+	if len(matched) > 1 {
+		issues = append(issues, fmt.Sprintf("Selected by %d PodDisruptionBudgets (evictions will fail)", len(matched)))
+		penalty += 10
+	}
+
+	return pdb.Name, issues, recommendations, penalty
+}
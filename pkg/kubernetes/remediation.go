@@ -0,0 +1,413 @@
+package kubernetes
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+type FixRule struct {
+	ID               string
+	Description      string
+	DefaultOlderThan time.Duration
+}
+
+type FixOptions struct {
+	Namespace  string
+	OlderThan  time.Duration
+	Percentile float64
+	Headroom   float64
+	Samples    int
+	Interval   time.Duration
+}
+
+type FixAction struct {
+	Rule      string
+	Operation string
+	Kind      string
+	Namespace string
+	Name      string
+	Reason    string
+	Diff      []string
+	patch     []byte
+	force     bool
+}
+
+type FixResult struct {
+	Action FixAction
+	DryRun bool
+	Error  string
+}
+
+var fixRules = []FixRule{
+	{ID: "missing-requests", Description: "Add missing CPU/memory requests to Deployments, StatefulSets and DaemonSets from rightsizing recommendations"},
+	{ID: "stuck-terminating", Description: "Force delete pods stuck in Terminating", DefaultOlderThan: 10 * time.Minute},
+	{ID: "failed-pods", Description: "Delete Evicted and Failed pods"},
+	{ID: "completed-jobs", Description: "Delete finished Jobs past their TTL (or older than --older-than without one)", DefaultOlderThan: 24 * time.Hour},
+}
+
+func FixRules() []FixRule {
+	return fixRules
+}
+
+func findFixRule(ruleID string) (FixRule, bool) {
+	for _, rule := range fixRules {
+		if rule.ID == ruleID {
+			return rule, true
+		}
+	}
+	return FixRule{}, false
+}
+
+// PlanFix computes the changes a rule would make without touching the cluster.
+func (c *Client) PlanFix(ruleID string, options FixOptions) ([]FixAction, error) {
+	rule, found := findFixRule(ruleID)
+	if !found {
+		return nil, fmt.Errorf("unknown rule %q", ruleID)
+	}
+	if options.OlderThan == 0 {
+		options.OlderThan = rule.DefaultOlderThan
+	}
+
+	switch rule.ID {
+	case "missing-requests":
+		return c.planMissingRequests(options)
+	case "stuck-terminating", "failed-pods":
+		pods, err := c.Clientset.CoreV1().Pods(options.Namespace).List(c.Context, metav1.ListOptions{})
+		if err != nil {
+			return nil, fmt.Errorf("failed to get pods: %w", err)
+		}
+		if rule.ID == "failed-pods" {
+			return planFailedPodCleanup(pods.Items), nil
+		}
+		return planStuckTerminating(pods.Items, time.Now(), options.OlderThan), nil
+	case "completed-jobs":
+		jobs, err := c.Clientset.BatchV1().Jobs(options.Namespace).List(c.Context, metav1.ListOptions{})
+		if err != nil {
+			return nil, fmt.Errorf("failed to get jobs: %w", err)
+		}
+		return planCompletedJobs(jobs.Items, time.Now(), options.OlderThan), nil
+	}
+
+	return nil, fmt.Errorf("rule %q has no planner", ruleID)
+}
+
+// ApplyFix executes the planned actions. With dryRun set every request is
+// sent with server-side dry-run, so admission and validation still run but
+// nothing is persisted.
+func (c *Client) ApplyFix(actions []FixAction, dryRun bool) []FixResult {
+	var dryRunOption []string
+	if dryRun {
+		dryRunOption = []string{metav1.DryRunAll}
+	}
+
+	var results []FixResult
+	for _, action := range actions {
+		var err error
+		switch {
+		case action.Operation == "delete" && action.Kind == "Pod":
+			options := metav1.DeleteOptions{DryRun: dryRunOption}
+			if action.force {
+				gracePeriod := int64(0)
+				options.GracePeriodSeconds = &gracePeriod
+			}
+			err = c.Clientset.CoreV1().Pods(action.Namespace).Delete(c.Context, action.Name, options)
+		case action.Operation == "delete" && action.Kind == "Job":
+			propagation := metav1.DeletePropagationBackground
+			err = c.Clientset.BatchV1().Jobs(action.Namespace).Delete(c.Context, action.Name, metav1.DeleteOptions{DryRun: dryRunOption, PropagationPolicy: &propagation})
+		case action.Operation == "patch" && action.Kind == "Deployment":
+			_, err = c.Clientset.AppsV1().Deployments(action.Namespace).Patch(c.Context, action.Name, types.StrategicMergePatchType, action.patch, metav1.PatchOptions{DryRun: dryRunOption})
+		case action.Operation == "patch" && action.Kind == "StatefulSet":
+			_, err = c.Clientset.AppsV1().StatefulSets(action.Namespace).Patch(c.Context, action.Name, types.StrategicMergePatchType, action.patch, metav1.PatchOptions{DryRun: dryRunOption})
+		case action.Operation == "patch" && action.Kind == "DaemonSet":
+			_, err = c.Clientset.AppsV1().DaemonSets(action.Namespace).Patch(c.Context, action.Name, types.StrategicMergePatchType, action.patch, metav1.PatchOptions{DryRun: dryRunOption})
+		default:
+			err = fmt.Errorf("unsupported action %s %s", action.Operation, action.Kind)
+		}
+
+		result := FixResult{Action: action, DryRun: dryRun}
+		if err != nil {
+			result.Error = err.Error()
+		}
+		results = append(results, result)
+	}
+
+	return results
+}
+
+func planFailedPodCleanup(pods []corev1.Pod) []FixAction {
+	var actions []FixAction
+	for _, pod := range pods {
+		if pod.Status.Phase != corev1.PodFailed || pod.DeletionTimestamp != nil {
+			continue
+		}
+
+		reason := "Failed"
+		if pod.Status.Reason != "" {
+			reason = pod.Status.Reason
+		}
+		if pod.Status.Message != "" {
+			reason += ": " + pod.Status.Message
+		}
+
+		actions = append(actions, FixAction{
+			Rule:      "failed-pods",
+			Operation: "delete",
+			Kind:      "Pod",
+			Namespace: pod.Namespace,
+			Name:      pod.Name,
+			Reason:    reason,
+			Diff:      []string{fmt.Sprintf("- Pod %s/%s (phase %s, owner %s)", pod.Namespace, pod.Name, pod.Status.Phase, getPodOwner(&pod))},
+		})
+	}
+	sortFixActions(actions)
+	return actions
+}
+
+func planStuckTerminating(pods []corev1.Pod, now time.Time, olderThan time.Duration) []FixAction {
+	var actions []FixAction
+	for _, pod := range pods {
+		if pod.DeletionTimestamp == nil {
+			continue
+		}
+		// The deletion timestamp already includes the grace period
+		stuckFor := now.Sub(pod.DeletionTimestamp.Time)
+		if stuckFor < olderThan {
+			continue
+		}
+
+		diff := []string{fmt.Sprintf("- Pod %s/%s (node %s, grace period 0)", pod.Namespace, pod.Name, valueOrNone(pod.Spec.NodeName))}
+		if len(pod.Finalizers) > 0 {
+			diff = append(diff, fmt.Sprintf("  finalizers %s are not removed and may keep the pod around", strings.Join(pod.Finalizers, ", ")))
+		}
+
+		actions = append(actions, FixAction{
+			Rule:      "stuck-terminating",
+			Operation: "delete",
+			Kind:      "Pod",
+			Namespace: pod.Namespace,
+			Name:      pod.Name,
+			Reason:    fmt.Sprintf("Terminating for %s", stuckFor.Truncate(time.Second)),
+			Diff:      diff,
+			force:     true,
+		})
+	}
+	sortFixActions(actions)
+	return actions
+}
+
+// planCompletedJobs skips Jobs owned by a CronJob; their history limits
+// already clean them up.
+func planCompletedJobs(jobs []batchv1.Job, now time.Time, olderThan time.Duration) []FixAction {
+	var actions []FixAction
+	for _, job := range jobs {
+		if job.DeletionTimestamp != nil || hasCronJobOwner(&job) {
+			continue
+		}
+
+		finished, finishedAt := jobFinishedAt(&job)
+		if !finished {
+			continue
+		}
+
+		age := now.Sub(finishedAt)
+		var reason string
+		if job.Spec.TTLSecondsAfterFinished != nil {
+			ttl := time.Duration(*job.Spec.TTLSecondsAfterFinished) * time.Second
+			if age < ttl {
+				continue
+			}
+			reason = fmt.Sprintf("Finished %s ago, past its %s TTL", age.Truncate(time.Second), ttl)
+		} else {
+			if age < olderThan {
+				continue
+			}
+			reason = fmt.Sprintf("Finished %s ago without a TTL", age.Truncate(time.Second))
+		}
+
+		actions = append(actions, FixAction{
+			Rule:      "completed-jobs",
+			Operation: "delete",
+			Kind:      "Job",
+			Namespace: job.Namespace,
+			Name:      job.Name,
+			Reason:    reason,
+			Diff:      []string{fmt.Sprintf("- Job %s/%s and its pods (succeeded %d, failed %d)", job.Namespace, job.Name, job.Status.Succeeded, job.Status.Failed)},
+		})
+	}
+	sortFixActions(actions)
+	return actions
+}
+
+func jobFinishedAt(job *batchv1.Job) (bool, time.Time) {
+	for _, condition := range job.Status.Conditions {
+		if (condition.Type == batchv1.JobComplete || condition.Type == batchv1.JobFailed) && condition.Status == corev1.ConditionTrue {
+			if job.Status.CompletionTime != nil {
+				return true, job.Status.CompletionTime.Time
+			}
+			return true, condition.LastTransitionTime.Time
+		}
+	}
+	return false, time.Time{}
+}
+
+func hasCronJobOwner(job *batchv1.Job) bool {
+	for _, owner := range job.OwnerReferences {
+		if owner.Kind == "CronJob" {
+			return true
+		}
+	}
+	return false
+}
+
+func (c *Client) planMissingRequests(options FixOptions) ([]FixAction, error) {
+	report, err := c.GetRightsizingReport(RightsizingOptions{
+		Namespace:  options.Namespace,
+		Percentile: options.Percentile,
+		Headroom:   options.Headroom,
+		Samples:    options.Samples,
+		Interval:   options.Interval,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	deployments, err := c.Clientset.AppsV1().Deployments(options.Namespace).List(c.Context, metav1.ListOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get deployments: %w", err)
+	}
+	statefulSets, err := c.Clientset.AppsV1().StatefulSets(options.Namespace).List(c.Context, metav1.ListOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get statefulsets: %w", err)
+	}
+	daemonSets, err := c.Clientset.AppsV1().DaemonSets(options.Namespace).List(c.Context, metav1.ListOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get daemonsets: %w", err)
+	}
+
+	var templates []workloadTemplate
+	for _, deploy := range deployments.Items {
+		templates = append(templates, workloadTemplate{kind: "Deployment", namespace: deploy.Namespace, name: deploy.Name, template: deploy.Spec.Template})
+	}
+	for _, ss := range statefulSets.Items {
+		templates = append(templates, workloadTemplate{kind: "StatefulSet", namespace: ss.Namespace, name: ss.Name, template: ss.Spec.Template})
+	}
+	for _, ds := range daemonSets.Items {
+		templates = append(templates, workloadTemplate{kind: "DaemonSet", namespace: ds.Namespace, name: ds.Name, template: ds.Spec.Template})
+	}
+
+	return planMissingRequests(templates, report)
+}
+
+type workloadTemplate struct {
+	kind      string
+	namespace string
+	name      string
+	template  corev1.PodTemplateSpec
+}
+
+// planMissingRequests only fills in requests that are not set at all and
+// have no limit; it never changes an existing request, leaving that to
+// 'k8s-cli rightsize'. Low confidence recommendations are not used.
+func planMissingRequests(templates []workloadTemplate, report *RightsizingReport) ([]FixAction, error) {
+	recommendations := make(map[string]ContainerRightsizing)
+	for _, workload := range report.Workloads {
+		for _, container := range workload.Containers {
+			recommendations[workload.Namespace+"/"+workload.Kind+"/"+workload.Name+"/"+container.Container] = container
+		}
+	}
+
+	var actions []FixAction
+	for _, workload := range templates {
+		var containers []interface{}
+		var diff []string
+
+		for _, container := range workload.template.Spec.Containers {
+			recommendation, found := recommendations[workload.namespace+"/"+workload.kind+"/"+workload.name+"/"+container.Name]
+			if !found || recommendation.LowConfidence {
+				continue
+			}
+
+			// A missing request with a limit set already defaults to the limit;
+			// lowering it would drop the pod from Guaranteed to Burstable QoS
+			requests := make(map[string]string)
+			if !hasRequestOrLimit(container.Resources, corev1.ResourceCPU) {
+				requests["cpu"] = formatCPUQuantity(recommendation.RecommendedCPURequest)
+			}
+			if !hasRequestOrLimit(container.Resources, corev1.ResourceMemory) {
+				requests["memory"] = formatMemoryQuantity(recommendation.RecommendedMemoryRequest)
+			}
+			if len(requests) == 0 {
+				continue
+			}
+
+			for _, name := range []string{"cpu", "memory"} {
+				if value, exists := requests[name]; exists {
+					diff = append(diff, fmt.Sprintf("+ containers[%s].resources.requests.%s: %s", container.Name, name, value))
+				}
+			}
+			containers = append(containers, map[string]interface{}{
+				"name":      container.Name,
+				"resources": map[string]interface{}{"requests": requests},
+			})
+		}
+		if len(containers) == 0 {
+			continue
+		}
+
+		patch, err := json.Marshal(map[string]interface{}{
+			"spec": map[string]interface{}{
+				"template": map[string]interface{}{
+					"spec": map[string]interface{}{"containers": containers},
+				},
+			},
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to build patch for %s/%s: %w", workload.namespace, workload.name, err)
+		}
+
+		actions = append(actions, FixAction{
+			Rule:      "missing-requests",
+			Operation: "patch",
+			Kind:      workload.kind,
+			Namespace: workload.namespace,
+			Name:      workload.name,
+			Reason:    "Containers without CPU/memory requests",
+			Diff:      diff,
+			patch:     patch,
+		})
+	}
+	sortFixActions(actions)
+	return actions, nil
+}
+
+func hasRequestOrLimit(resources corev1.ResourceRequirements, name corev1.ResourceName) bool {
+	if _, exists := resources.Requests[name]; exists {
+		return true
+	}
+	_, exists := resources.Limits[name]
+	return exists
+}
+
+func sortFixActions(actions []FixAction) {
+	sort.SliceStable(actions, func(i, j int) bool {
+		if actions[i].Namespace != actions[j].Namespace {
+			return actions[i].Namespace < actions[j].Namespace
+		}
+		return actions[i].Name < actions[j].Name
+	})
+}
+
+func valueOrNone(value string) string {
+	if value == "" {
+		return "<none>"
+	}
+	return value
+}
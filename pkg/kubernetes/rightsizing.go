@@ -0,0 +1,403 @@
+package kubernetes
+
+import (
+	"fmt"
+	"math"
+	"slices"
+	"sort"
+	"strings"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/yaml"
+)
+
+type RightsizingOptions struct {
+	Namespace  string
+	Percentile float64
+	Headroom   float64
+	Samples    int
+	Interval   time.Duration
+}
+
+type RightsizingReport struct {
+	Workloads      []WorkloadRightsizing
+	Samples        int
+	MonthlySavings float64
+}
+
+type WorkloadRightsizing struct {
+	Kind           string
+	Name           string
+	Namespace      string
+	Pods           int
+	Containers     []ContainerRightsizing
+	MonthlySavings float64
+}
+
+type ContainerRightsizing struct {
+	Container                string
+	Source                   string
+	CPUUsage                 int64
+	MemoryUsage              int64
+	CPURequest               int64
+	CPULimit                 int64
+	MemoryRequest            int64
+	MemoryLimit              int64
+	RecommendedCPURequest    int64
+	RecommendedCPULimit      int64
+	RecommendedMemoryRequest int64
+	RecommendedMemoryLimit   int64
+	Samples                  int
+	LowConfidence            bool
+	Changed                  bool
+}
+
+type RightsizingPatch struct {
+	Filename string
+	Content  []byte
+}
+
+// MinRightsizingSamples is the number of metrics samples below which a
+// usage percentile is too noisy to act on. Such recommendations are shown as
+// low confidence and left out of patches and fixes.
+const MinRightsizingSamples = 5
+
+const (
+	defaultRightsizingHeadroom = 0.2
+	minRecommendedCPU          = 10
+	minRecommendedMemory       = 16 * 1024 * 1024
+	// Changes smaller than this fraction of the current value are not worth a rollout
+	rightsizingChangeThreshold = 0.1
+)
+
+// GetRightsizingReport samples pod metrics and recommends per-container
+// requests and limits from the observed usage percentile plus headroom,
+// preferring VPA targets when a VPA covers the workload.
+func (c *Client) GetRightsizingReport(options RightsizingOptions) (*RightsizingReport, error) {
+	if options.Samples < 1 {
+		options.Samples = 1
+	}
+
+	pods, err := c.Clientset.CoreV1().Pods(options.Namespace).List(c.Context, metav1.ListOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get pods: %w", err)
+	}
+
+	podsByKey := make(map[string]*corev1.Pod)
+	for i := range pods.Items {
+		pod := &pods.Items[i]
+		if pod.Status.Phase == corev1.PodRunning {
+			podsByKey[pod.Namespace+"/"+pod.Name] = pod
+		}
+	}
+
+	cpuUsage := make(map[string][]int64)
+	memUsage := make(map[string][]int64)
+	for sample := 0; sample < options.Samples; sample++ {
+		if sample > 0 {
+			select {
+			case <-c.Context.Done():
+				return nil, c.Context.Err()
+			case <-time.After(options.Interval):
+			}
+		}
+
+		podMetrics, err := c.MetricsClient.MetricsV1beta1().PodMetricses(options.Namespace).List(c.Context, metav1.ListOptions{})
+		if err != nil {
+			return nil, fmt.Errorf("failed to get pod metrics: %w", err)
+		}
+
+		for _, metric := range podMetrics.Items {
+			pod, exists := podsByKey[metric.Namespace+"/"+metric.Name]
+			if !exists {
+				continue
+			}
+			workload := pod.Namespace + "/" + getPodWorkload(pod)
+			for _, container := range metric.Containers {
+				key := workload + "/" + container.Name
+				cpu := container.Usage[corev1.ResourceCPU]
+				memory := container.Usage[corev1.ResourceMemory]
+				cpuUsage[key] = append(cpuUsage[key], cpu.MilliValue())
+				memUsage[key] = append(memUsage[key], memory.Value())
+			}
+		}
+	}
+
+	// VPA targets are best effort, as in the utilization analysis
+	var vpas []VPAStatus
+	if items, err := c.listVPAs(options.Namespace); err == nil {
+		vpas = items
+	}
+	autoscalers := newWorkloadAutoscalers(nil, vpas)
+
+	workloads := make(map[string]*WorkloadRightsizing)
+	templates := make(map[string]*corev1.Pod)
+	for _, pod := range podsByKey {
+		key := pod.Namespace + "/" + getPodWorkload(pod)
+		if _, exists := workloads[key]; !exists {
+			kind, name, _ := strings.Cut(getPodWorkload(pod), "/")
+			workloads[key] = &WorkloadRightsizing{Kind: kind, Name: name, Namespace: pod.Namespace}
+			templates[key] = pod
+		}
+		workloads[key].Pods++
+	}
+
+	report := &RightsizingReport{Samples: options.Samples}
+	for key, workload := range workloads {
+		_, vpa := autoscalers.lookup(workload.Namespace, workload.Kind+"/"+workload.Name)
+
+		var cpuSaved, memSaved int64
+		for _, container := range templates[key].Spec.Containers {
+			usageKey := key + "/" + container.Name
+			if len(cpuUsage[usageKey]) == 0 {
+				continue
+			}
+
+			var target *ContainerRecommendation
+			if vpa != nil {
+				for i := range vpa.Recommendations {
+					if vpa.Recommendations[i].Container == container.Name {
+						target = &vpa.Recommendations[i]
+					}
+				}
+			}
+
+			recommendation := recommendContainerResources(container, cpuUsage[usageKey], memUsage[usageKey], target, options)
+			workload.Containers = append(workload.Containers, recommendation)
+			if recommendation.Changed {
+				cpuSaved += recommendation.CPURequest - recommendation.RecommendedCPURequest
+				memSaved += recommendation.MemoryRequest - recommendation.RecommendedMemoryRequest
+			}
+		}
+		if len(workload.Containers) == 0 {
+			continue
+		}
+
+		workload.MonthlySavings = c.estimateResourceSavings(cpuSaved, memSaved) * float64(workload.Pods)
+		report.MonthlySavings += workload.MonthlySavings
+		report.Workloads = append(report.Workloads, *workload)
+	}
+
+	sort.SliceStable(report.Workloads, func(i, j int) bool {
+		if report.Workloads[i].MonthlySavings != report.Workloads[j].MonthlySavings {
+			return report.Workloads[i].MonthlySavings > report.Workloads[j].MonthlySavings
+		}
+		return report.Workloads[i].Namespace+"/"+report.Workloads[i].Name < report.Workloads[j].Namespace+"/"+report.Workloads[j].Name
+	})
+
+	return report, nil
+}
+
+// recommendContainerResources sizes requests at the usage percentile (or
+// the VPA target) plus headroom. Limits are only recommended where the
+// container already sets them, keeping their ratio to the request; the
+// memory limit always covers the observed peak plus headroom.
+func recommendContainerResources(container corev1.Container, cpuUsage, memUsage []int64, vpa *ContainerRecommendation, options RightsizingOptions) ContainerRightsizing {
+	recommendation := ContainerRightsizing{
+		Container:   container.Name,
+		Source:      fmt.Sprintf("p%g usage", options.Percentile),
+		CPUUsage:    usagePercentile(cpuUsage, options.Percentile),
+		MemoryUsage: usagePercentile(memUsage, options.Percentile),
+		Samples:     len(cpuUsage),
+	}
+	if cpu, exists := container.Resources.Requests[corev1.ResourceCPU]; exists {
+		recommendation.CPURequest = cpu.MilliValue()
+	}
+	if cpu, exists := container.Resources.Limits[corev1.ResourceCPU]; exists {
+		recommendation.CPULimit = cpu.MilliValue()
+	}
+	if memory, exists := container.Resources.Requests[corev1.ResourceMemory]; exists {
+		recommendation.MemoryRequest = memory.Value()
+	}
+	if memory, exists := container.Resources.Limits[corev1.ResourceMemory]; exists {
+		recommendation.MemoryLimit = memory.Value()
+	}
+
+	cpuBase, memBase := recommendation.CPUUsage, recommendation.MemoryUsage
+	if vpa != nil && vpa.CPUTarget > 0 && vpa.MemoryTarget > 0 {
+		cpuBase, memBase = vpa.CPUTarget, vpa.MemoryTarget
+		recommendation.Source = "VPA target"
+	} else {
+		recommendation.LowConfidence = recommendation.Samples < MinRightsizingSamples
+	}
+
+	recommendation.RecommendedCPURequest = roundUpCPU(float64(cpuBase) * (1 + options.Headroom))
+	recommendation.RecommendedMemoryRequest = roundUpMemory(float64(memBase) * (1 + options.Headroom))
+
+	if recommendation.CPULimit > 0 {
+		recommendation.RecommendedCPULimit = recommendation.CPULimit
+		if recommendation.CPURequest > 0 {
+			ratio := float64(recommendation.CPULimit) / float64(recommendation.CPURequest)
+			recommendation.RecommendedCPULimit = roundUpCPU(float64(recommendation.RecommendedCPURequest) * ratio)
+		}
+	}
+	if recommendation.MemoryLimit > 0 {
+		limit := recommendation.MemoryLimit
+		if recommendation.MemoryRequest > 0 {
+			ratio := float64(recommendation.MemoryLimit) / float64(recommendation.MemoryRequest)
+			limit = roundUpMemory(float64(recommendation.RecommendedMemoryRequest) * ratio)
+		}
+		peak := roundUpMemory(float64(slices.Max(memUsage)) * (1 + options.Headroom))
+		recommendation.RecommendedMemoryLimit = max(limit, peak, recommendation.RecommendedMemoryRequest)
+	}
+
+	recommendation.Changed = significantChange(recommendation.CPURequest, recommendation.RecommendedCPURequest) ||
+		significantChange(recommendation.MemoryRequest, recommendation.RecommendedMemoryRequest) ||
+		significantChange(recommendation.CPULimit, recommendation.RecommendedCPULimit) ||
+		significantChange(recommendation.MemoryLimit, recommendation.RecommendedMemoryLimit)
+
+	return recommendation
+}
+
+// usagePercentile returns the nearest-rank percentile of the samples.
+func usagePercentile(samples []int64, percentile float64) int64 {
+	if len(samples) == 0 {
+		return 0
+	}
+	sorted := append([]int64(nil), samples...)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i] < sorted[j] })
+
+	rank := int(math.Ceil(percentile / 100 * float64(len(sorted))))
+	if rank < 1 {
+		rank = 1
+	}
+	if rank > len(sorted) {
+		rank = len(sorted)
+	}
+	return sorted[rank-1]
+}
+
+// roundUpCPU rounds millicores up to a multiple of 5m.
+func roundUpCPU(milliCores float64) int64 {
+	return max(int64(math.Ceil(milliCores/5))*5, minRecommendedCPU)
+}
+
+// roundUpMemory rounds bytes up to a whole Mi.
+func roundUpMemory(bytes float64) int64 {
+	const mebibyte = 1024 * 1024
+	return max(int64(math.Ceil(bytes/mebibyte))*mebibyte, minRecommendedMemory)
+}
+
+func significantChange(current, recommended int64) bool {
+	if current == 0 {
+		return recommended > 0
+	}
+	return math.Abs(float64(recommended-current)) > float64(current)*rightsizingChangeThreshold
+}
+
+func formatCPUQuantity(milliCores int64) string {
+	return resource.NewMilliQuantity(milliCores, resource.DecimalSI).String()
+}
+
+func formatMemoryQuantity(bytes int64) string {
+	return resource.NewQuantity(bytes, resource.BinarySI).String()
+}
+
+// FormatResourceChange renders a current and recommended value as
+// "500m → 120m"; an unset current value is shown as "-".
+func FormatResourceChange(current, recommended int64, memory bool) string {
+	format := formatCPUQuantity
+	if memory {
+		format = formatMemoryQuantity
+	}
+	if recommended == 0 {
+		return "-"
+	}
+	from := "-"
+	if current > 0 {
+		from = format(current)
+	}
+	return fmt.Sprintf("%s → %s", from, format(recommended))
+}
+
+// BuildRightsizingPatches renders one strategic-merge patch per changed
+// Deployment, StatefulSet or DaemonSet. Low confidence recommendations are
+// skipped. With kustomizeBase set the patches are accompanied by a
+// kustomization.yaml overlaying that base.
+func BuildRightsizingPatches(report *RightsizingReport, kustomizeBase string) ([]RightsizingPatch, error) {
+	var patches []RightsizingPatch
+
+	for _, workload := range report.Workloads {
+		if workload.Kind != "Deployment" && workload.Kind != "StatefulSet" && workload.Kind != "DaemonSet" {
+			continue
+		}
+
+		var containers []interface{}
+		for _, container := range workload.Containers {
+			if !container.Changed || container.LowConfidence {
+				continue
+			}
+
+			requests := map[string]string{
+				"cpu":    formatCPUQuantity(container.RecommendedCPURequest),
+				"memory": formatMemoryQuantity(container.RecommendedMemoryRequest),
+			}
+			limits := map[string]string{}
+			if container.RecommendedCPULimit > 0 {
+				limits["cpu"] = formatCPUQuantity(container.RecommendedCPULimit)
+			}
+			if container.RecommendedMemoryLimit > 0 {
+				limits["memory"] = formatMemoryQuantity(container.RecommendedMemoryLimit)
+			}
+
+			resources := map[string]interface{}{"requests": requests}
+			if len(limits) > 0 {
+				resources["limits"] = limits
+			}
+			containers = append(containers, map[string]interface{}{
+				"name":      container.Container,
+				"resources": resources,
+			})
+		}
+		if len(containers) == 0 {
+			continue
+		}
+
+		patch := map[string]interface{}{
+			"apiVersion": "apps/v1",
+			"kind":       workload.Kind,
+			"metadata": map[string]interface{}{
+				"name":      workload.Name,
+				"namespace": workload.Namespace,
+			},
+			"spec": map[string]interface{}{
+				"template": map[string]interface{}{
+					"spec": map[string]interface{}{
+						"containers": containers,
+					},
+				},
+			},
+		}
+
+		content, err := yaml.Marshal(patch)
+		if err != nil {
+			return nil, fmt.Errorf("failed to render patch for %s/%s: %w", workload.Namespace, workload.Name, err)
+		}
+		patches = append(patches, RightsizingPatch{
+			Filename: fmt.Sprintf("%s-%s-%s.yaml", workload.Namespace, strings.ToLower(workload.Kind), workload.Name),
+			Content:  content,
+		})
+	}
+
+	if kustomizeBase != "" && len(patches) > 0 {
+		var entries []interface{}
+		for _, patch := range patches {
+			entries = append(entries, map[string]string{"path": patch.Filename})
+		}
+		content, err := yaml.Marshal(map[string]interface{}{
+			"apiVersion": "kustomize.config.k8s.io/v1beta1",
+			"kind":       "Kustomization",
+			"resources":  []string{kustomizeBase},
+			"patches":    entries,
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to render kustomization: %w", err)
+		}
+		patches = append(patches, RightsizingPatch{Filename: "kustomization.yaml", Content: content})
+	}
+
+	return patches, nil
+}
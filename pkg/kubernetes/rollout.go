@@ -0,0 +1,300 @@
+package kubernetes
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+type RolloutStatus struct {
+	Kind              string
+	Name              string
+	Namespace         string
+	State             string
+	Message           string
+	Revision          int
+	DesiredReplicas   int32
+	UpdatedReplicas   int32
+	ReadyReplicas     int32
+	AvailableReplicas int32
+	RolloutTime       time.Time
+	Restarts          int32
+	Suspicious        bool
+	Findings          []string
+	Events            []ClusterEvent
+	History           []RolloutRevision
+}
+
+type RolloutRevision struct {
+	Revision      int
+	ReplicaSet    string
+	Images        []string
+	ImageChanges  []string
+	ChangeCause   string
+	CreatedAt     time.Time
+	Replicas      int32
+	ReadyReplicas int32
+	Current       bool
+}
+
+const (
+	changeCauseAnnotation     = "kubernetes.io/change-cause"
+	revisionHistoryAnnotation = "deployment.kubernetes.io/revision-history"
+)
+
+// GetRolloutStatus reconstructs the rollout history of a Deployment from its
+// ReplicaSets and correlates restarts and warning events with the latest
+// rollout.
+func (c *Client) GetRolloutStatus(target, namespace string) (*RolloutStatus, error) {
+	kind, name, found := strings.Cut(target, "/")
+	if !found {
+		kind, name = "deploy", target
+	}
+	switch strings.ToLower(kind) {
+	case "deploy", "deployment", "deployments":
+	default:
+		return nil, fmt.Errorf("unsupported target kind %q (use deploy/<name>)", kind)
+	}
+	if namespace == "" {
+		namespace = "default"
+	}
+
+	deployment, err := c.Clientset.AppsV1().Deployments(namespace).Get(c.Context, name, metav1.GetOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get deployment %s: %w", name, err)
+	}
+
+	selector, err := metav1.LabelSelectorAsSelector(deployment.Spec.Selector)
+	if err != nil {
+		return nil, fmt.Errorf("invalid selector for deployment %s: %w", name, err)
+	}
+	listOptions := metav1.ListOptions{LabelSelector: selector.String()}
+
+	replicaSets, err := c.Clientset.AppsV1().ReplicaSets(namespace).List(c.Context, listOptions)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get replicasets: %w", err)
+	}
+
+	pods, err := c.Clientset.CoreV1().Pods(namespace).List(c.Context, listOptions)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get pods: %w", err)
+	}
+
+	// Events are best effort; the rollout state does not depend on them
+	var events []ClusterEvent
+	if eventList, err := c.Clientset.CoreV1().Events(namespace).List(c.Context, metav1.ListOptions{}); err == nil {
+		for i := range eventList.Items {
+			events = append(events, clusterEventFromCoreV1(&eventList.Items[i]))
+		}
+	}
+
+	return buildRolloutStatus(deployment, replicaSets.Items, pods.Items, events), nil
+}
+
+func buildRolloutStatus(deployment *appsv1.Deployment, replicaSets []appsv1.ReplicaSet, pods []corev1.Pod, events []ClusterEvent) *RolloutStatus {
+	status := &RolloutStatus{
+		Kind:              "Deployment",
+		Name:              deployment.Name,
+		Namespace:         deployment.Namespace,
+		DesiredReplicas:   replicasOrDefault(deployment.Spec.Replicas),
+		UpdatedReplicas:   deployment.Status.UpdatedReplicas,
+		ReadyReplicas:     deployment.Status.ReadyReplicas,
+		AvailableReplicas: deployment.Status.AvailableReplicas,
+		Findings:          []string{},
+	}
+	status.State, status.Message = rolloutState(deployment)
+
+	var owned []appsv1.ReplicaSet
+	for _, rs := range replicaSets {
+		for _, owner := range rs.OwnerReferences {
+			if owner.Kind == "Deployment" && owner.UID == deployment.UID {
+				owned = append(owned, rs)
+				break
+			}
+		}
+	}
+	sort.Slice(owned, func(i, j int) bool {
+		return replicaSetRevision(&owned[i]) < replicaSetRevision(&owned[j])
+	})
+
+	var current *appsv1.ReplicaSet
+	var previousImages map[string]string
+	for i := range owned {
+		rs := &owned[i]
+		images := make(map[string]string)
+		revision := RolloutRevision{
+			Revision:      replicaSetRevision(rs),
+			ReplicaSet:    rs.Name,
+			ChangeCause:   valueOrNone(rs.Annotations[changeCauseAnnotation]),
+			CreatedAt:     rs.CreationTimestamp.Time,
+			Replicas:      rs.Status.Replicas,
+			ReadyReplicas: rs.Status.ReadyReplicas,
+		}
+		for _, container := range rs.Spec.Template.Spec.Containers {
+			images[container.Name] = container.Image
+			revision.Images = append(revision.Images, fmt.Sprintf("%s=%s", container.Name, container.Image))
+		}
+		if previousImages != nil {
+			revision.ImageChanges = diffImages(previousImages, images)
+		}
+		previousImages = images
+
+		status.History = append(status.History, revision)
+		current = rs
+	}
+
+	if current == nil {
+		status.Findings = append(status.Findings, "No ReplicaSets found; the deployment has never rolled out")
+		return status
+	}
+
+	latest := &status.History[len(status.History)-1]
+	latest.Current = true
+	status.Revision = latest.Revision
+	status.RolloutTime = rolloutTime(deployment, current)
+
+	// Only pods and events of the current revision count against the rollout
+	objects := map[string]bool{"Deployment/" + deployment.Name: true, "ReplicaSet/" + current.Name: true}
+	restartedPods := 0
+	for _, pod := range pods {
+		if getObjectOwner(pod.OwnerReferences) != "ReplicaSet/"+current.Name {
+			continue
+		}
+		objects["Pod/"+pod.Name] = true
+		if restarts := restartsSince(&pod, status.RolloutTime); restarts > 0 {
+			status.Restarts += restarts
+			restartedPods++
+		}
+	}
+
+	for _, event := range aggregateClusterEvents(events, status.RolloutTime) {
+		if event.Type != corev1.EventTypeWarning || !objects[event.Object] || event.LastTime.Before(status.RolloutTime) {
+			continue
+		}
+		status.Events = append(status.Events, event)
+	}
+	sort.SliceStable(status.Events, func(i, j int) bool {
+		return status.Events[i].Count > status.Events[j].Count
+	})
+
+	since := getSimpleAge(status.RolloutTime)
+	if status.State == "Stalled" {
+		status.Findings = append(status.Findings, fmt.Sprintf("Revision %d is stalled: %s", status.Revision, status.Message))
+		status.Suspicious = true
+	}
+	if status.Restarts > 0 {
+		status.Findings = append(status.Findings, fmt.Sprintf("%d restarts across %d pods of revision %d since it rolled out %s ago", status.Restarts, restartedPods, status.Revision, since))
+		status.Suspicious = true
+	}
+	if len(status.Events) > 0 {
+		var total int32
+		reasons := make(map[string]bool)
+		for _, event := range status.Events {
+			total += event.Count
+			reasons[event.Reason] = true
+		}
+		status.Findings = append(status.Findings, fmt.Sprintf("%d warning events since the rollout (%s)", total, strings.Join(sortedKeys(reasons), ", ")))
+		status.Suspicious = true
+	}
+	if status.Suspicious && len(status.History) > 1 {
+		previous := status.History[len(status.History)-2]
+		status.Findings = append(status.Findings, fmt.Sprintf("Problems started after revision %d; roll back with 'kubectl rollout undo deployment/%s --to-revision=%d'", status.Revision, deployment.Name, previous.Revision))
+	}
+
+	return status
+}
+
+// rolloutTime is when the current revision rolled out. A rollback re-adopts
+// an older ReplicaSet, recording its former revisions in revision-history, so
+// its creation time predates the rollout; the Progressing condition's last
+// update is used for those instead.
+func rolloutTime(deployment *appsv1.Deployment, rs *appsv1.ReplicaSet) time.Time {
+	created := rs.CreationTimestamp.Time
+	if _, reused := rs.Annotations[revisionHistoryAnnotation]; !reused {
+		return created
+	}
+	for _, condition := range deployment.Status.Conditions {
+		if condition.Type == appsv1.DeploymentProgressing && condition.LastUpdateTime.After(created) {
+			return condition.LastUpdateTime.Time
+		}
+	}
+	return created
+}
+
+// restartsSince counts container restarts after since. Pods created after it
+// count every restart; older pods kept across a rollback only count a restart
+// whose last termination finished after it, as a lower bound.
+func restartsSince(pod *corev1.Pod, since time.Time) int32 {
+	if !pod.CreationTimestamp.Time.Before(since) {
+		return getTotalRestarts(pod)
+	}
+
+	var restarts int32
+	for _, containerStatus := range pod.Status.ContainerStatuses {
+		if terminated := containerStatus.LastTerminationState.Terminated; terminated != nil && terminated.FinishedAt.After(since) {
+			restarts++
+		}
+	}
+	return restarts
+}
+
+// rolloutState mirrors the checks 'kubectl rollout status' performs.
+func rolloutState(deployment *appsv1.Deployment) (string, string) {
+	desired := replicasOrDefault(deployment.Spec.Replicas)
+
+	if deployment.Spec.Paused {
+		return "Paused", "Rollout is paused"
+	}
+	if deployment.Generation > deployment.Status.ObservedGeneration {
+		return "Progressing", "Waiting for the deployment spec update to be observed"
+	}
+	for _, condition := range deployment.Status.Conditions {
+		if condition.Type == appsv1.DeploymentProgressing && condition.Reason == "ProgressDeadlineExceeded" {
+			return "Stalled", condition.Message
+		}
+	}
+	if deployment.Status.UpdatedReplicas < desired {
+		return "Progressing", fmt.Sprintf("%d of %d updated replicas available", deployment.Status.UpdatedReplicas, desired)
+	}
+	if deployment.Status.Replicas > deployment.Status.UpdatedReplicas {
+		return "Progressing", fmt.Sprintf("%d old replicas pending termination", deployment.Status.Replicas-deployment.Status.UpdatedReplicas)
+	}
+	if deployment.Status.AvailableReplicas < deployment.Status.UpdatedReplicas {
+		return "Progressing", fmt.Sprintf("%d of %d updated replicas available", deployment.Status.AvailableReplicas, deployment.Status.UpdatedReplicas)
+	}
+	return "Complete", "Successfully rolled out"
+}
+
+// diffImages lists per-container image changes between two revisions.
+func diffImages(previous, current map[string]string) []string {
+	var changes []string
+	for _, name := range sortedStringKeys(current) {
+		before, existed := previous[name]
+		switch {
+		case !existed:
+			changes = append(changes, fmt.Sprintf("+%s: %s", name, current[name]))
+		case before != current[name]:
+			changes = append(changes, fmt.Sprintf("%s: %s → %s", name, before, current[name]))
+		}
+	}
+	for _, name := range sortedStringKeys(previous) {
+		if _, exists := current[name]; !exists {
+			changes = append(changes, fmt.Sprintf("-%s: %s", name, previous[name]))
+		}
+	}
+	return changes
+}
+
+func sortedStringKeys(values map[string]string) []string {
+	keys := make([]string, 0, len(values))
+	for key := range values {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,481 @@
+package kubernetes
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	storagev1 "k8s.io/api/storage/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/selection"
+)
+
+type PendingPodExplanation struct {
+	Name             string
+	Namespace        string
+	Age              string
+	CPURequest       int64
+	MemoryRequest    int64
+	SchedulerMessage string
+	PodIssues        []string
+	FittingNodes     int
+	Nodes            []NodeFitResult
+	ReasonSummary    []string
+}
+
+type NodeFitResult struct {
+	Node    string
+	Fits    bool
+	Reasons []string
+}
+
+// nodeAllocation is the free capacity of a node after the requests of the
+// pods already running on it.
+type nodeAllocation struct {
+	freeCPU    int64
+	freeMemory int64
+	freePods   int64
+}
+
+// volumeConstraint restricts the nodes a pod can run on because of a volume.
+type volumeConstraint struct {
+	claim        string
+	nodeAffinity *corev1.VolumeNodeAffinity
+	issue        string
+}
+
+// GetPendingPodExplanations explains for every Pending pod why it does not
+// fit on each node. If podName is set only that pod is explained.
+func (c *Client) GetPendingPodExplanations(namespace, podName string) ([]PendingPodExplanation, error) {
+	nodes, err := c.Clientset.CoreV1().Nodes().List(c.Context, metav1.ListOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get nodes: %w", err)
+	}
+
+	allPods, err := c.Clientset.CoreV1().Pods("").List(c.Context, metav1.ListOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get pods: %w", err)
+	}
+
+	var explanations []PendingPodExplanation
+	for i := range allPods.Items {
+		pod := &allPods.Items[i]
+		if pod.Status.Phase != corev1.PodPending || pod.Spec.NodeName != "" {
+			continue
+		}
+		if namespace != "" && pod.Namespace != namespace {
+			continue
+		}
+		if podName != "" && pod.Name != podName {
+			continue
+		}
+
+		constraints := c.getVolumeConstraints(pod)
+		explanations = append(explanations, explainPendingPod(pod, nodes.Items, allPods.Items, constraints))
+	}
+
+	return explanations, nil
+}
+
+func (c *Client) getVolumeConstraints(pod *corev1.Pod) []volumeConstraint {
+	var constraints []volumeConstraint
+
+	for _, volume := range pod.Spec.Volumes {
+		if volume.PersistentVolumeClaim == nil {
+			continue
+		}
+		claimName := volume.PersistentVolumeClaim.ClaimName
+
+		pvc, err := c.Clientset.CoreV1().PersistentVolumeClaims(pod.Namespace).Get(c.Context, claimName, metav1.GetOptions{})
+		if err != nil {
+			constraints = append(constraints, volumeConstraint{claim: claimName, issue: fmt.Sprintf("PVC %s not found", claimName)})
+			continue
+		}
+
+		if pvc.Spec.VolumeName == "" {
+			if pvc.Spec.StorageClassName != nil {
+				storageClass, err := c.Clientset.StorageV1().StorageClasses().Get(c.Context, *pvc.Spec.StorageClassName, metav1.GetOptions{})
+				if err == nil && storageClass.VolumeBindingMode != nil && *storageClass.VolumeBindingMode == storagev1.VolumeBindingWaitForFirstConsumer {
+					continue
+				}
+			}
+			constraints = append(constraints, volumeConstraint{claim: claimName, issue: fmt.Sprintf("PVC %s is not bound (%s)", claimName, pvc.Status.Phase)})
+			continue
+		}
+
+		pv, err := c.Clientset.CoreV1().PersistentVolumes().Get(c.Context, pvc.Spec.VolumeName, metav1.GetOptions{})
+		if err != nil {
+			continue
+		}
+		if pv.Spec.NodeAffinity != nil && pv.Spec.NodeAffinity.Required != nil {
+			constraints = append(constraints, volumeConstraint{claim: claimName, nodeAffinity: pv.Spec.NodeAffinity})
+		}
+	}
+
+	return constraints
+}
+
+func explainPendingPod(pod *corev1.Pod, nodes []corev1.Node, allPods []corev1.Pod, volumes []volumeConstraint) PendingPodExplanation {
+	cpuRequest, memoryRequest := getPodResourceRequests(pod)
+
+	explanation := PendingPodExplanation{
+		Name:          pod.Name,
+		Namespace:     pod.Namespace,
+		Age:           time.Since(pod.CreationTimestamp.Time).Truncate(time.Second).String(),
+		CPURequest:    cpuRequest,
+		MemoryRequest: memoryRequest,
+	}
+
+	for _, condition := range pod.Status.Conditions {
+		if condition.Type == corev1.PodScheduled && condition.Status == corev1.ConditionFalse {
+			explanation.SchedulerMessage = condition.Message
+		}
+	}
+
+	for _, volume := range volumes {
+		if volume.issue != "" {
+			explanation.PodIssues = append(explanation.PodIssues, volume.issue)
+		}
+	}
+	if pod.Spec.SchedulerName != "" && pod.Spec.SchedulerName != corev1.DefaultSchedulerName {
+		explanation.PodIssues = append(explanation.PodIssues, fmt.Sprintf("Uses scheduler %q; make sure it is running", pod.Spec.SchedulerName))
+	}
+
+	allocations := calculateNodeAllocations(nodes, allPods)
+	podsByNode := make(map[string][]corev1.Pod)
+	for _, other := range allPods {
+		if other.Spec.NodeName != "" && isPodActive(&other) {
+			podsByNode[other.Spec.NodeName] = append(podsByNode[other.Spec.NodeName], other)
+		}
+	}
+
+	reasonCounts := make(map[string]int)
+	for i := range nodes {
+		node := &nodes[i]
+		result := NodeFitResult{Node: node.Name}
+
+		result.Reasons = append(result.Reasons, checkNodeSelection(pod, node)...)
+		result.Reasons = append(result.Reasons, checkTaints(pod, node)...)
+		result.Reasons = append(result.Reasons, checkVolumeAffinity(volumes, node)...)
+		result.Reasons = append(result.Reasons, checkResourceFit(cpuRequest, memoryRequest, allocations[node.Name])...)
+		result.Reasons = append(result.Reasons, checkTopologySpread(pod, node, nodes, podsByNode)...)
+		result.Reasons = append(result.Reasons, checkPodAffinity(pod, node, nodes, podsByNode)...)
+
+		result.Fits = len(result.Reasons) == 0
+		if result.Fits {
+			explanation.FittingNodes++
+		}
+		for _, reason := range result.Reasons {
+			reasonCounts[reasonCategory(reason)]++
+		}
+
+		explanation.Nodes = append(explanation.Nodes, result)
+	}
+
+	sort.SliceStable(explanation.Nodes, func(i, j int) bool {
+		if explanation.Nodes[i].Fits != explanation.Nodes[j].Fits {
+			return explanation.Nodes[i].Fits
+		}
+		return len(explanation.Nodes[i].Reasons) < len(explanation.Nodes[j].Reasons)
+	})
+
+	for category, count := range reasonCounts {
+		explanation.ReasonSummary = append(explanation.ReasonSummary, fmt.Sprintf("%d/%d nodes: %s", count, len(nodes), category))
+	}
+	sort.Strings(explanation.ReasonSummary)
+
+	return explanation
+}
+
+func calculateNodeAllocations(nodes []corev1.Node, pods []corev1.Pod) map[string]nodeAllocation {
+	allocations := make(map[string]nodeAllocation)
+	for _, node := range nodes {
+		allocations[node.Name] = nodeAllocation{
+			freeCPU:    node.Status.Allocatable.Cpu().MilliValue(),
+			freeMemory: node.Status.Allocatable.Memory().Value(),
+			freePods:   node.Status.Allocatable.Pods().Value(),
+		}
+	}
+
+	for i := range pods {
+		pod := &pods[i]
+		allocation, exists := allocations[pod.Spec.NodeName]
+		if !exists || !isPodActive(pod) {
+			continue
+		}
+		cpu, memory := getPodResourceRequests(pod)
+		allocation.freeCPU -= cpu
+		allocation.freeMemory -= memory
+		allocation.freePods--
+		allocations[pod.Spec.NodeName] = allocation
+	}
+
+	return allocations
+}
+
+func isPodActive(pod *corev1.Pod) bool {
+	return pod.Status.Phase != corev1.PodSucceeded && pod.Status.Phase != corev1.PodFailed
+}
+
+func checkNodeSelection(pod *corev1.Pod, node *corev1.Node) []string {
+	var reasons []string
+
+	if node.Spec.Unschedulable && !toleratesUnschedulable(pod) {
+		reasons = append(reasons, "node is cordoned (unschedulable)")
+	}
+
+	for key, value := range pod.Spec.NodeSelector {
+		if actual, exists := node.Labels[key]; !exists || actual != value {
+			reasons = append(reasons, fmt.Sprintf("nodeSelector %s=%s does not match", key, value))
+		}
+	}
+
+	affinity := pod.Spec.Affinity
+	if affinity != nil && affinity.NodeAffinity != nil && affinity.NodeAffinity.RequiredDuringSchedulingIgnoredDuringExecution != nil {
+		if !matchesNodeSelectorTerms(node, affinity.NodeAffinity.RequiredDuringSchedulingIgnoredDuringExecution.NodeSelectorTerms) {
+			reasons = append(reasons, "required node affinity does not match")
+		}
+	}
+
+	return reasons
+}
+
+func toleratesUnschedulable(pod *corev1.Pod) bool {
+	taint := &corev1.Taint{Key: corev1.TaintNodeUnschedulable, Effect: corev1.TaintEffectNoSchedule}
+	for i := range pod.Spec.Tolerations {
+		if pod.Spec.Tolerations[i].ToleratesTaint(taint) {
+			return true
+		}
+	}
+	return false
+}
+
+// matchesNodeSelectorTerms reports whether any term (ORed) matches; the
+// requirements within a term are ANDed.
+func matchesNodeSelectorTerms(node *corev1.Node, terms []corev1.NodeSelectorTerm) bool {
+	for _, term := range terms {
+		if len(term.MatchExpressions) == 0 && len(term.MatchFields) == 0 {
+			continue
+		}
+		if matchesNodeSelectorRequirements(labels.Set(node.Labels), term.MatchExpressions) &&
+			matchesNodeSelectorRequirements(labels.Set{"metadata.name": node.Name}, term.MatchFields) {
+			return true
+		}
+	}
+	return false
+}
+
+func matchesNodeSelectorRequirements(set labels.Set, requirements []corev1.NodeSelectorRequirement) bool {
+	operators := map[corev1.NodeSelectorOperator]selection.Operator{
+		corev1.NodeSelectorOpIn:           selection.In,
+		corev1.NodeSelectorOpNotIn:        selection.NotIn,
+		corev1.NodeSelectorOpExists:       selection.Exists,
+		corev1.NodeSelectorOpDoesNotExist: selection.DoesNotExist,
+		corev1.NodeSelectorOpGt:           selection.GreaterThan,
+		corev1.NodeSelectorOpLt:           selection.LessThan,
+	}
+
+	for _, requirement := range requirements {
+		operator, exists := operators[requirement.Operator]
+		if !exists {
+			return false
+		}
+		parsed, err := labels.NewRequirement(requirement.Key, operator, requirement.Values)
+		if err != nil || !parsed.Matches(set) {
+			return false
+		}
+	}
+	return true
+}
+
+func checkTaints(pod *corev1.Pod, node *corev1.Node) []string {
+	var reasons []string
+	for i := range node.Spec.Taints {
+		taint := &node.Spec.Taints[i]
+		if taint.Effect == corev1.TaintEffectPreferNoSchedule {
+			continue
+		}
+		// The cordon taint is reported by checkNodeSelection
+		if taint.Key == corev1.TaintNodeUnschedulable {
+			continue
+		}
+
+		tolerated := false
+		for j := range pod.Spec.Tolerations {
+			if pod.Spec.Tolerations[j].ToleratesTaint(taint) {
+				tolerated = true
+				break
+			}
+		}
+		if !tolerated {
+			reasons = append(reasons, fmt.Sprintf("untolerated taint %s", taint.ToString()))
+		}
+	}
+	return reasons
+}
+
+func checkVolumeAffinity(volumes []volumeConstraint, node *corev1.Node) []string {
+	var reasons []string
+	for _, volume := range volumes {
+		if volume.nodeAffinity == nil {
+			continue
+		}
+		if !matchesNodeSelectorTerms(node, volume.nodeAffinity.Required.NodeSelectorTerms) {
+			zone := node.Labels["topology.kubernetes.io/zone"]
+			if zone == "" {
+				zone = "unknown zone"
+			}
+			reasons = append(reasons, fmt.Sprintf("volume of PVC %s cannot attach here (node in %s)", volume.claim, zone))
+		}
+	}
+	return reasons
+}
+
+func checkResourceFit(cpuRequest, memoryRequest int64, allocation nodeAllocation) []string {
+	var reasons []string
+	if cpuRequest > allocation.freeCPU {
+		reasons = append(reasons, fmt.Sprintf("insufficient cpu (requests %s, free %s)", formatCPU(cpuRequest), formatCPU(max(allocation.freeCPU, 0))))
+	}
+	if memoryRequest > allocation.freeMemory {
+		reasons = append(reasons, fmt.Sprintf("insufficient memory (requests %s, free %s)", formatBytes(memoryRequest), formatBytes(max(allocation.freeMemory, 0))))
+	}
+	if allocation.freePods < 1 {
+		reasons = append(reasons, "too many pods")
+	}
+	return reasons
+}
+
+// checkTopologySpread evaluates DoNotSchedule constraints: placing the pod on
+// node must not push the skew between topology domains above maxSkew.
+func checkTopologySpread(pod *corev1.Pod, node *corev1.Node, nodes []corev1.Node, podsByNode map[string][]corev1.Pod) []string {
+	var reasons []string
+
+	for _, constraint := range pod.Spec.TopologySpreadConstraints {
+		if constraint.WhenUnsatisfiable != corev1.DoNotSchedule {
+			continue
+		}
+
+		domain, exists := node.Labels[constraint.TopologyKey]
+		if !exists {
+			reasons = append(reasons, fmt.Sprintf("missing topology label %s", constraint.TopologyKey))
+			continue
+		}
+
+		selector, err := metav1.LabelSelectorAsSelector(constraint.LabelSelector)
+		if err != nil {
+			continue
+		}
+
+		counts := make(map[string]int)
+		for i := range nodes {
+			candidate := &nodes[i]
+			value, exists := candidate.Labels[constraint.TopologyKey]
+			if !exists || len(checkNodeSelection(pod, candidate)) > 0 {
+				continue
+			}
+			if _, seen := counts[value]; !seen {
+				counts[value] = 0
+			}
+			for _, other := range podsByNode[candidate.Name] {
+				if other.Namespace == pod.Namespace && selector.Matches(labels.Set(other.Labels)) {
+					counts[value]++
+				}
+			}
+		}
+
+		minCount := -1
+		for _, count := range counts {
+			if minCount < 0 || count < minCount {
+				minCount = count
+			}
+		}
+		if minCount < 0 {
+			minCount = 0
+		}
+
+		if skew := counts[domain] + 1 - minCount; skew > int(constraint.MaxSkew) {
+			reasons = append(reasons, fmt.Sprintf("topology spread on %s would exceed maxSkew %d (skew %d)", constraint.TopologyKey, constraint.MaxSkew, skew))
+		}
+	}
+
+	return reasons
+}
+
+// checkPodAffinity evaluates required inter-pod affinity and anti-affinity
+// terms against the pods in the node's topology domain.
+func checkPodAffinity(pod *corev1.Pod, node *corev1.Node, nodes []corev1.Node, podsByNode map[string][]corev1.Pod) []string {
+	affinity := pod.Spec.Affinity
+	if affinity == nil {
+		return nil
+	}
+
+	var reasons []string
+
+	domainPods := func(term corev1.PodAffinityTerm) (bool, bool) {
+		domain, exists := node.Labels[term.TopologyKey]
+		if !exists {
+			return false, false
+		}
+		selector, err := metav1.LabelSelectorAsSelector(term.LabelSelector)
+		if err != nil {
+			return true, false
+		}
+
+		namespaces := term.Namespaces
+		if len(namespaces) == 0 {
+			namespaces = []string{pod.Namespace}
+		}
+
+		for i := range nodes {
+			if nodes[i].Labels[term.TopologyKey] != domain {
+				continue
+			}
+			for _, other := range podsByNode[nodes[i].Name] {
+				if containsString(namespaces, other.Namespace) && selector.Matches(labels.Set(other.Labels)) {
+					return true, true
+				}
+			}
+		}
+		return true, false
+	}
+
+	if affinity.PodAffinity != nil {
+		for _, term := range affinity.PodAffinity.RequiredDuringSchedulingIgnoredDuringExecution {
+			hasDomain, matched := domainPods(term)
+			if !hasDomain || !matched {
+				reasons = append(reasons, fmt.Sprintf("pod affinity: no matching pod in the same %s", term.TopologyKey))
+			}
+		}
+	}
+
+	if affinity.PodAntiAffinity != nil {
+		for _, term := range affinity.PodAntiAffinity.RequiredDuringSchedulingIgnoredDuringExecution {
+			if _, matched := domainPods(term); matched {
+				reasons = append(reasons, fmt.Sprintf("pod anti-affinity: a matching pod already runs in the same %s", term.TopologyKey))
+			}
+		}
+	}
+
+	return reasons
+}
+
+func containsString(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
+
+// reasonCategory strips node specific details so reasons can be counted
+// across nodes.
+func reasonCategory(reason string) string {
+	if i := strings.Index(reason, " ("); i > 0 {
+		return reason[:i]
+	}
+	return reason
+}
@@ -18,6 +18,9 @@ type SimpleNodeInfo struct {
 	InternalIP     string
 	CPUCapacity    string
 	MemoryCapacity string
+
+	CPUAllocatable    string
+	MemoryAllocatable string
 }
 
 type SimplePodInfo struct {
@@ -45,13 +48,7 @@ func (c *Client) GetSimpleNodesInfo() ([]SimpleNodeInfo, error) {
 	var nodeInfos []SimpleNodeInfo
 
 	for _, node := range nodes.Items {
-		status := "Ready"
-		for _, condition := range node.Status.Conditions {
-			if condition.Type == corev1.NodeReady && condition.Status != corev1.ConditionTrue {
-				status = "NotReady"
-				break
-			}
-		}
+		status := getNodeStatus(&node)
 
 		roles := getSimpleRoles(&node)
 		age := getSimpleAge(node.CreationTimestamp.Time)
@@ -59,6 +56,8 @@ func (c *Client) GetSimpleNodesInfo() ([]SimpleNodeInfo, error) {
 
 		cpuCapacity := node.Status.Capacity[corev1.ResourceCPU]
 		memoryCapacity := node.Status.Capacity[corev1.ResourceMemory]
+		cpuAllocatable := node.Status.Allocatable[corev1.ResourceCPU]
+		memoryAllocatable := node.Status.Allocatable[corev1.ResourceMemory]
 
 		nodeInfos = append(nodeInfos, SimpleNodeInfo{
 			Name:           node.Name,
@@ -69,6 +68,9 @@ func (c *Client) GetSimpleNodesInfo() ([]SimpleNodeInfo, error) {
 			InternalIP:     internalIP,
 			CPUCapacity:    cpuCapacity.String(),
 			MemoryCapacity: formatSimpleBytes(memoryCapacity.Value()),
+
+			CPUAllocatable:    cpuAllocatable.String(),
+			MemoryAllocatable: formatSimpleBytes(memoryAllocatable.Value()),
 		})
 	}
 
@@ -6,7 +6,6 @@ import (
 	appsv1 "k8s.io/api/apps/v1"
 )
 
-
 func getMainContainerImage(deployment *appsv1.Deployment) string {
 	if len(deployment.Spec.Template.Spec.Containers) > 0 {
 		return deployment.Spec.Template.Spec.Containers[0].Image
@@ -22,12 +21,12 @@ func getMainContainerImageDS(daemonset *appsv1.DaemonSet) string {
 }
 
 func extractVersionFromImage(image string) string {
-	parts := strings.Split(image, ":")
-	if len(parts) > 1 {
-		version := parts[len(parts)-1]
-		if version != "latest" {
-			return version
-		}
+	ref := parseImageReference(image)
+	if ref.Tag != "" && ref.Tag != "latest" {
+		return ref.Tag
+	}
+	if ref.Tag == "" && ref.Digest != "" {
+		return "@" + shortDigest(ref.Digest)
 	}
 	return "latest"
 }
@@ -2,10 +2,8 @@ package kubernetes
 
 import (
 	"fmt"
-	"strings"
 
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
-	"k8s.io/apimachinery/pkg/runtime/schema"
 )
 
 type ClusterInfo struct {
@@ -46,6 +44,14 @@ type ComponentInfo struct {
 	Version   string
 	Ready     string
 	Source    string // "Kubernetes", "Helm", "StatefulSet", etc.
+	Image     string // Main container image, for workload-detected components
+
+	// Helm chart metadata, when installed via Helm. Version falls back to
+	// the chart version when the chart declares no app version.
+	Chart        string
+	ChartVersion string
+	AppVersion   string
+	Revision     int
 }
 
 func (c *Client) GetInstalledComponents() ([]ComponentInfo, error) {
@@ -72,57 +78,30 @@ func (c *Client) GetInstalledComponents() ([]ComponentInfo, error) {
 func (c *Client) getHelmComponents() ([]ComponentInfo, error) {
 	var components []ComponentInfo
 
-	// Define the Helm secret resource
-	gvr := schema.GroupVersionResource{
-		Group:    "",
-		Version:  "v1",
-		Resource: "secrets",
-	}
-
-	// List all secrets in all namespaces that are Helm releases
-	secrets, err := c.DynamicClient.Resource(gvr).List(c.Context, metav1.ListOptions{
-		LabelSelector: "owner=helm",
-	})
+	releases, err := c.GetHelmReleases("")
 	if err != nil {
-		return components, fmt.Errorf("failed to list helm secrets: %w", err)
+		return components, err
 	}
 
-	for _, secret := range secrets.Items {
-		labels := secret.GetLabels()
-		if labels == nil {
-			continue
-		}
-
-		name, hasName := labels["name"]
-		status, hasStatus := labels["status"]
-		if !hasName {
-			continue
-		}
-
+	for _, release := range releases {
 		version := "Unknown"
-		if appVersion, ok := labels["app.kubernetes.io/version"]; ok {
-			version = appVersion
-		} else if chartVersion, ok := labels["version"]; ok {
-			version = chartVersion
-		}
-
-		statusStr := "Unknown"
-		if hasStatus {
-			// Capitalize first letter (replacement for deprecated strings.Title)
-			if len(status) > 0 {
-				statusStr = strings.ToUpper(string(status[0])) + strings.ToLower(status[1:])
-			} else {
-				statusStr = status
-			}
+		if release.AppVersion != "" {
+			version = release.AppVersion
+		} else if release.ChartVersion != "" {
+			version = release.ChartVersion
 		}
 
 		components = append(components, ComponentInfo{
-			Name:      name,
-			Namespace: secret.GetNamespace(),
-			Status:    statusStr,
-			Version:   version,
-			Ready:     "Helm",
-			Source:    "Helm",
+			Name:         release.Name,
+			Namespace:    release.Namespace,
+			Status:       formatHelmStatus(release.Status),
+			Version:      version,
+			Ready:        "Helm",
+			Source:       "Helm",
+			Chart:        release.Chart,
+			ChartVersion: release.ChartVersion,
+			AppVersion:   release.AppVersion,
+			Revision:     release.Revision,
 		})
 	}
 
@@ -167,7 +146,8 @@ func (c *Client) getKubernetesComponents() ([]ComponentInfo, error) {
 					}
 
 					version := "Unknown"
-					if image := getMainContainerImage(&dep); image != "" {
+					image := getMainContainerImage(&dep)
+					if image != "" {
 						version = extractVersionFromImage(image)
 					}
 
@@ -178,6 +158,7 @@ func (c *Client) getKubernetesComponents() ([]ComponentInfo, error) {
 						Version:   version,
 						Ready:     fmt.Sprintf("%d/%d", dep.Status.ReadyReplicas, dep.Status.Replicas),
 						Source:    "Deployment",
+						Image:     image,
 					})
 				}
 			}
@@ -194,8 +175,9 @@ func (c *Client) getKubernetesComponents() ([]ComponentInfo, error) {
 					}
 
 					version := "Unknown"
+					image := ""
 					if len(sts.Spec.Template.Spec.Containers) > 0 {
-						image := sts.Spec.Template.Spec.Containers[0].Image
+						image = sts.Spec.Template.Spec.Containers[0].Image
 						version = extractVersionFromImage(image)
 					}
 
@@ -206,6 +188,7 @@ func (c *Client) getKubernetesComponents() ([]ComponentInfo, error) {
 						Version:   version,
 						Ready:     fmt.Sprintf("%d/%d", sts.Status.ReadyReplicas, sts.Status.Replicas),
 						Source:    "StatefulSet",
+						Image:     image,
 					})
 				}
 			}
@@ -222,7 +205,8 @@ func (c *Client) getKubernetesComponents() ([]ComponentInfo, error) {
 					}
 
 					version := "Unknown"
-					if image := getMainContainerImageDS(&ds); image != "" {
+					image := getMainContainerImageDS(&ds)
+					if image != "" {
 						version = extractVersionFromImage(image)
 					}
 
@@ -233,6 +217,7 @@ func (c *Client) getKubernetesComponents() ([]ComponentInfo, error) {
 						Version:   version,
 						Ready:     fmt.Sprintf("%d/%d", ds.Status.NumberReady, ds.Status.DesiredNumberScheduled),
 						Source:    "DaemonSet",
+						Image:     image,
 					})
 				}
 			}
@@ -7,6 +7,7 @@ import (
 
 	appsv1 "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
+	policyv1 "k8s.io/api/policy/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
@@ -14,7 +15,12 @@ type WorkloadAnalysis struct {
 	DeploymentAnalysis  []DeploymentHealth
 	StatefulSetAnalysis []StatefulSetHealth
 	DaemonSetAnalysis   []DaemonSetHealth
+	JobAnalysis         []JobHealth
+	CronJobAnalysis     []CronJobHealth
 	PodAnalysis         []PodHealth
+	PDBAnalysis         []PDBAudit
+	HPAAnalysis         []HPAStatus
+	VPAAnalysis         []VPAStatus
 	WorkloadSummary     WorkloadSummary
 }
 
@@ -29,22 +35,26 @@ type DeploymentHealth struct {
 	Age                 string
 	RestartRate         float64
 	ResourceEfficiency  string
+	PodDisruptionBudget string
+	Autoscaler          string
 	HealthScore         int
 	Issues              []string
 	Recommendations     []string
 }
 
 type StatefulSetHealth struct {
-	Name            string
-	Namespace       string
-	Replicas        int32
-	ReadyReplicas   int32
-	CurrentReplicas int32
-	Status          string
-	Age             string
-	HealthScore     int
-	Issues          []string
-	Recommendations []string
+	Name                string
+	Namespace           string
+	Replicas            int32
+	ReadyReplicas       int32
+	CurrentReplicas     int32
+	Status              string
+	Age                 string
+	PodDisruptionBudget string
+	Autoscaler          string
+	HealthScore         int
+	Issues              []string
+	Recommendations     []string
 }
 
 type DaemonSetHealth struct {
@@ -82,6 +92,10 @@ type WorkloadSummary struct {
 	HealthyStatefulSets int
 	TotalDaemonSets     int
 	HealthyDaemonSets   int
+	TotalJobs           int
+	HealthyJobs         int
+	TotalCronJobs       int
+	HealthyCronJobs     int
 	TotalPods           int
 	HealthyPods         int
 	CriticalIssues      int
@@ -91,13 +105,31 @@ type WorkloadSummary struct {
 func (c *Client) GetWorkloadAnalysis(namespace string) (*WorkloadAnalysis, error) {
 	analysis := &WorkloadAnalysis{}
 
-	deployments, err := c.analyzeDeployments(namespace)
+	// PDB checks are skipped (nil) when budgets cannot be listed
+	var pdbs []policyv1.PodDisruptionBudget
+	if items, err := c.listPodDisruptionBudgets(namespace); err == nil {
+		pdbs = append([]policyv1.PodDisruptionBudget{}, items...)
+		if pods, err := c.Clientset.CoreV1().Pods(namespace).List(c.Context, metav1.ListOptions{}); err == nil {
+			analysis.PDBAnalysis = auditPDBs(pdbs, pods.Items)
+		}
+	}
+
+	// Autoscalers are best effort too; the VPA CRDs are often not installed
+	if hpas, err := c.listHPAs(namespace); err == nil {
+		analysis.HPAAnalysis = hpas
+	}
+	if vpas, err := c.listVPAs(namespace); err == nil {
+		analysis.VPAAnalysis = vpas
+	}
+	autoscalers := newWorkloadAutoscalers(analysis.HPAAnalysis, analysis.VPAAnalysis)
+
+	deployments, err := c.analyzeDeployments(namespace, pdbs, autoscalers)
 	if err != nil {
 		return nil, fmt.Errorf("failed to analyze deployments: %w", err)
 	}
 	analysis.DeploymentAnalysis = deployments
 
-	statefulSets, err := c.analyzeStatefulSets(namespace)
+	statefulSets, err := c.analyzeStatefulSets(namespace, pdbs, autoscalers)
 	if err != nil {
 		return nil, fmt.Errorf("failed to analyze statefulsets: %w", err)
 	}
@@ -109,18 +141,25 @@ func (c *Client) GetWorkloadAnalysis(namespace string) (*WorkloadAnalysis, error
 	}
 	analysis.DaemonSetAnalysis = daemonSets
 
+	jobs, cronJobs, err := c.analyzeBatchWorkloads(namespace)
+	if err != nil {
+		return nil, fmt.Errorf("failed to analyze batch workloads: %w", err)
+	}
+	analysis.JobAnalysis = jobs
+	analysis.CronJobAnalysis = cronJobs
+
 	pods, err := c.analyzePods(namespace)
 	if err != nil {
 		return nil, fmt.Errorf("failed to analyze pods: %w", err)
 	}
 	analysis.PodAnalysis = pods
 
-	analysis.WorkloadSummary = c.calculateWorkloadSummary(deployments, statefulSets, daemonSets, pods)
+	analysis.WorkloadSummary = c.calculateWorkloadSummary(deployments, statefulSets, daemonSets, jobs, cronJobs, pods)
 
 	return analysis, nil
 }
 
-func (c *Client) analyzeDeployments(namespace string) ([]DeploymentHealth, error) {
+func (c *Client) analyzeDeployments(namespace string, pdbs []policyv1.PodDisruptionBudget, autoscalers workloadAutoscalers) ([]DeploymentHealth, error) {
 	deployments, err := c.Clientset.AppsV1().Deployments(namespace).List(c.Context, metav1.ListOptions{})
 	if err != nil {
 		return nil, err
@@ -128,7 +167,8 @@ func (c *Client) analyzeDeployments(namespace string) ([]DeploymentHealth, error
 
 	var analysis []DeploymentHealth
 	for _, deploy := range deployments.Items {
-		health := c.analyzeDeploymentHealth(&deploy)
+		hpa, vpa := autoscalers.lookup(deploy.Namespace, "Deployment/"+deploy.Name)
+		health := c.analyzeDeploymentHealth(&deploy, pdbs, hpa, vpa)
 		analysis = append(analysis, health)
 	}
 
@@ -139,7 +179,7 @@ func (c *Client) analyzeDeployments(namespace string) ([]DeploymentHealth, error
 	return analysis, nil
 }
 
-func (c *Client) analyzeStatefulSets(namespace string) ([]StatefulSetHealth, error) {
+func (c *Client) analyzeStatefulSets(namespace string, pdbs []policyv1.PodDisruptionBudget, autoscalers workloadAutoscalers) ([]StatefulSetHealth, error) {
 	statefulSets, err := c.Clientset.AppsV1().StatefulSets(namespace).List(c.Context, metav1.ListOptions{})
 	if err != nil {
 		return nil, err
@@ -147,7 +187,8 @@ func (c *Client) analyzeStatefulSets(namespace string) ([]StatefulSetHealth, err
 
 	var analysis []StatefulSetHealth
 	for _, ss := range statefulSets.Items {
-		health := c.analyzeStatefulSetHealth(&ss)
+		hpa, vpa := autoscalers.lookup(ss.Namespace, "StatefulSet/"+ss.Name)
+		health := c.analyzeStatefulSetHealth(&ss, pdbs, hpa, vpa)
 		analysis = append(analysis, health)
 	}
 
@@ -199,11 +240,11 @@ func (c *Client) analyzePods(namespace string) ([]PodHealth, error) {
 	return analysis, nil
 }
 
-func (c *Client) analyzeDeploymentHealth(deploy *appsv1.Deployment) DeploymentHealth {
+func (c *Client) analyzeDeploymentHealth(deploy *appsv1.Deployment, pdbs []policyv1.PodDisruptionBudget, hpa *HPAStatus, vpa *VPAStatus) DeploymentHealth {
 	health := DeploymentHealth{
 		Name:                deploy.Name,
 		Namespace:           deploy.Namespace,
-		Replicas:            *deploy.Spec.Replicas,
+		Replicas:            replicasOrDefault(deploy.Spec.Replicas),
 		ReadyReplicas:       deploy.Status.ReadyReplicas,
 		AvailableReplicas:   deploy.Status.AvailableReplicas,
 		UnavailableReplicas: deploy.Status.UnavailableReplicas,
@@ -224,35 +265,29 @@ func (c *Client) analyzeDeploymentHealth(deploy *appsv1.Deployment) DeploymentHe
 		score -= 20
 	}
 
-	if health.Replicas == 1 {
-		health.Issues = append(health.Issues, "Single replica - no high availability")
-		health.Recommendations = append(health.Recommendations, "Consider increasing replicas for HA")
-		score -= 10
+	checks := replicaChecks(health.Replicas, hpa != nil)
+	if containers := deploy.Spec.Template.Spec.Containers; len(containers) > 0 {
+		checks = append(checks, containerChecks(containers[0], true)...)
 	}
-
-	if deploy.Spec.Template.Spec.Containers[0].Resources.Requests == nil {
-		health.Issues = append(health.Issues, "No resource requests defined")
-		health.Recommendations = append(health.Recommendations, "Define CPU and memory requests")
-		score -= 15
+	for _, check := range checks {
+		health.Issues = append(health.Issues, check.Issue)
+		health.Recommendations = append(health.Recommendations, check.Recommendation)
+		score -= check.Penalty
 	}
 
-	if deploy.Spec.Template.Spec.Containers[0].Resources.Limits == nil {
-		health.Issues = append(health.Issues, "No resource limits defined")
-		health.Recommendations = append(health.Recommendations, "Define CPU and memory limits")
-		score -= 10
-	}
-
-	if deploy.Spec.Template.Spec.Containers[0].LivenessProbe == nil {
-		health.Issues = append(health.Issues, "No liveness probe configured")
-		health.Recommendations = append(health.Recommendations, "Add liveness probe for better health monitoring")
-		score -= 10
+	if pdbs != nil {
+		pdbName, issues, recommendations, penalty := checkDisruptionReadiness(deploy.Namespace, health.Replicas, deploy.Spec.Template, pdbs)
+		health.PodDisruptionBudget = pdbName
+		health.Issues = append(health.Issues, issues...)
+		health.Recommendations = append(health.Recommendations, recommendations...)
+		score -= penalty
 	}
 
-	if deploy.Spec.Template.Spec.Containers[0].ReadinessProbe == nil {
-		health.Issues = append(health.Issues, "No readiness probe configured")
-		health.Recommendations = append(health.Recommendations, "Add readiness probe for better traffic management")
-		score -= 10
-	}
+	autoscaler, issues, recommendations, penalty := checkAutoscaling(hpa, vpa)
+	health.Autoscaler = autoscaler
+	health.Issues = append(health.Issues, issues...)
+	health.Recommendations = append(health.Recommendations, recommendations...)
+	score -= penalty
 
 	if score < 0 {
 		score = 0
@@ -270,11 +305,11 @@ func (c *Client) analyzeDeploymentHealth(deploy *appsv1.Deployment) DeploymentHe
 	return health
 }
 
-func (c *Client) analyzeStatefulSetHealth(ss *appsv1.StatefulSet) StatefulSetHealth {
+func (c *Client) analyzeStatefulSetHealth(ss *appsv1.StatefulSet, pdbs []policyv1.PodDisruptionBudget, hpa *HPAStatus, vpa *VPAStatus) StatefulSetHealth {
 	health := StatefulSetHealth{
 		Name:            ss.Name,
 		Namespace:       ss.Namespace,
-		Replicas:        *ss.Spec.Replicas,
+		Replicas:        replicasOrDefault(ss.Spec.Replicas),
 		ReadyReplicas:   ss.Status.ReadyReplicas,
 		CurrentReplicas: ss.Status.CurrentReplicas,
 		Age:             time.Since(ss.CreationTimestamp.Time).Truncate(time.Second).String(),
@@ -300,6 +335,20 @@ func (c *Client) analyzeStatefulSetHealth(ss *appsv1.StatefulSet) StatefulSetHea
 		score -= 15
 	}
 
+	if pdbs != nil {
+		pdbName, issues, recommendations, penalty := checkDisruptionReadiness(ss.Namespace, health.Replicas, ss.Spec.Template, pdbs)
+		health.PodDisruptionBudget = pdbName
+		health.Issues = append(health.Issues, issues...)
+		health.Recommendations = append(health.Recommendations, recommendations...)
+		score -= penalty
+	}
+
+	autoscaler, issues, recommendations, penalty := checkAutoscaling(hpa, vpa)
+	health.Autoscaler = autoscaler
+	health.Issues = append(health.Issues, issues...)
+	health.Recommendations = append(health.Recommendations, recommendations...)
+	score -= penalty
+
 	if score < 0 {
 		score = 0
 	}
@@ -414,11 +463,13 @@ func (c *Client) analyzePodHealth(pod *corev1.Pod) PodHealth {
 	return health
 }
 
-func (c *Client) calculateWorkloadSummary(deployments []DeploymentHealth, statefulSets []StatefulSetHealth, daemonSets []DaemonSetHealth, pods []PodHealth) WorkloadSummary {
+func (c *Client) calculateWorkloadSummary(deployments []DeploymentHealth, statefulSets []StatefulSetHealth, daemonSets []DaemonSetHealth, jobs []JobHealth, cronJobs []CronJobHealth, pods []PodHealth) WorkloadSummary {
 	summary := WorkloadSummary{
 		TotalDeployments:  len(deployments),
 		TotalStatefulSets: len(statefulSets),
 		TotalDaemonSets:   len(daemonSets),
+		TotalJobs:         len(jobs),
+		TotalCronJobs:     len(cronJobs),
 		TotalPods:         len(pods),
 	}
 
@@ -458,6 +509,28 @@ func (c *Client) calculateWorkloadSummary(deployments []DeploymentHealth, statef
 		totalWorkloads++
 	}
 
+	for _, job := range jobs {
+		if job.HealthScore >= 80 {
+			summary.HealthyJobs++
+		}
+		if job.Status == "Critical" {
+			summary.CriticalIssues++
+		}
+		totalScore += job.HealthScore
+		totalWorkloads++
+	}
+
+	for _, cronJob := range cronJobs {
+		if cronJob.HealthScore >= 80 {
+			summary.HealthyCronJobs++
+		}
+		if cronJob.Status == "Critical" {
+			summary.CriticalIssues++
+		}
+		totalScore += cronJob.HealthScore
+		totalWorkloads++
+	}
+
 	for _, pod := range pods {
 		if pod.HealthScore >= 80 {
 			summary.HealthyPods++
@@ -486,11 +559,67 @@ func (c *Client) shouldSkipPod(pod *corev1.Pod) bool {
 		return true
 	}
 
+	// Failed Job pods are reported through the Job and CronJob analysis
 	for _, ownerRef := range pod.OwnerReferences {
-		if ownerRef.Kind == "Job" && pod.Status.Phase == corev1.PodSucceeded {
+		if ownerRef.Kind == "Job" && pod.Status.Phase == corev1.PodFailed {
 			return true
 		}
 	}
 
 	return false
 }
+
+// workloadCheck is a spec-level finding shared by the live workload analysis
+// and the manifest linter.
+type workloadCheck struct {
+	Rule           string
+	Severity       string
+	Issue          string
+	Recommendation string
+	Penalty        int
+}
+
+// containerChecks covers resources and, for long-running workloads, probes.
+func containerChecks(container corev1.Container, longRunning bool) []workloadCheck {
+	var checks []workloadCheck
+
+	if container.Resources.Requests == nil {
+		checks = append(checks, workloadCheck{"resource-requests", "Medium", "No resource requests defined", "Define CPU and memory requests", 15})
+	}
+
+	if container.Resources.Limits == nil {
+		checks = append(checks, workloadCheck{"resource-limits", "Low", "No resource limits defined", "Define CPU and memory limits", 10})
+	}
+
+	if !longRunning {
+		return checks
+	}
+
+	if container.LivenessProbe == nil {
+		checks = append(checks, workloadCheck{"liveness-probe", "Low", "No liveness probe configured", "Add liveness probe for better health monitoring", 10})
+	}
+
+	if container.ReadinessProbe == nil {
+		checks = append(checks, workloadCheck{"readiness-probe", "Medium", "No readiness probe configured", "Add readiness probe for better traffic management", 10})
+	}
+
+	return checks
+}
+
+// replicaChecks flags single replica workloads; the HPA owns the replica
+// count of autoscaled ones.
+func replicaChecks(replicas int32, autoscaled bool) []workloadCheck {
+	if replicas != 1 || autoscaled {
+		return nil
+	}
+	return []workloadCheck{{"single-replica", "Low", "Single replica - no high availability", "Consider increasing replicas for HA", 10}}
+}
+
+// replicasOrDefault returns the replica count the API server defaults a
+// nil spec.replicas to.
+func replicasOrDefault(replicas *int32) int32 {
+	if replicas == nil {
+		return 1
+	}
+	return *replicas
+}
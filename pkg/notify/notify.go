@@ -0,0 +1,307 @@
+package notify
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+	"sync/atomic"
+	"text/template"
+	"time"
+
+	"k8s.io/apimachinery/pkg/util/yaml"
+)
+
+const defaultTemplate = `[{{.Severity}}] {{.Title}}{{if .Object}} ({{if .Namespace}}{{.Namespace}}/{{end}}{{.Object}}){{end}}: {{.Message}}`
+
+type Alert struct {
+	Title     string            `json:"title"`
+	Message   string            `json:"message"`
+	Severity  string            `json:"severity"`
+	Source    string            `json:"source"`
+	Object    string            `json:"object,omitempty"`
+	Namespace string            `json:"namespace,omitempty"`
+	Time      time.Time         `json:"time"`
+	Fields    map[string]string `json:"fields,omitempty"`
+}
+
+// Sink delivers a rendered alert to an external system.
+type Sink interface {
+	Name() string
+	Send(ctx context.Context, alert Alert, text string) error
+}
+
+type Config struct {
+	Retries int          `json:"retries"`
+	Backoff string       `json:"backoff"`
+	Sinks   []SinkConfig `json:"sinks"`
+}
+
+type SinkConfig struct {
+	Name        string            `json:"name"`
+	Type        string            `json:"type"`
+	URL         string            `json:"url"`
+	Headers     map[string]string `json:"headers"`
+	MinSeverity string            `json:"minSeverity"`
+	Template    string            `json:"template"`
+	SMTP        SMTPConfig        `json:"smtp"`
+}
+
+type SMTPConfig struct {
+	Host        string   `json:"host"`
+	Port        int      `json:"port"`
+	From        string   `json:"from"`
+	To          []string `json:"to"`
+	Username    string   `json:"username"`
+	PasswordEnv string   `json:"passwordEnv"`
+}
+
+type Notifier struct {
+	sinks   []sinkEntry
+	retries int
+	backoff time.Duration
+}
+
+type sinkEntry struct {
+	sink        Sink
+	minSeverity int
+	template    *template.Template
+}
+
+// permanentError marks delivery failures that will not succeed on retry,
+// such as a 4xx response from a webhook.
+type permanentError struct {
+	err error
+}
+
+func (e *permanentError) Error() string {
+	return e.err.Error()
+}
+
+func (e *permanentError) Unwrap() error {
+	return e.err
+}
+
+// LoadConfig reads a notifier configuration file in YAML or JSON format.
+func LoadConfig(path string) (*Config, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read notifier config %s: %w", path, err)
+	}
+
+	var config Config
+	if err := yaml.Unmarshal(data, &config); err != nil {
+		return nil, fmt.Errorf("failed to parse notifier config %s: %w", path, err)
+	}
+
+	return &config, nil
+}
+
+func NewNotifier(config *Config) (*Notifier, error) {
+	notifier := &Notifier{
+		retries: 3,
+		backoff: time.Second,
+	}
+
+	if config.Retries > 0 {
+		notifier.retries = config.Retries
+	}
+	if config.Backoff != "" {
+		backoff, err := time.ParseDuration(config.Backoff)
+		if err != nil {
+			return nil, fmt.Errorf("invalid backoff %q: %w", config.Backoff, err)
+		}
+		notifier.backoff = backoff
+	}
+
+	for i, sinkConfig := range config.Sinks {
+		if sinkConfig.Name == "" {
+			sinkConfig.Name = fmt.Sprintf("%s-%d", sinkConfig.Type, i+1)
+		}
+
+		sink, err := newSink(sinkConfig)
+		if err != nil {
+			return nil, fmt.Errorf("sink %s: %w", sinkConfig.Name, err)
+		}
+
+		text := sinkConfig.Template
+		if text == "" {
+			text = defaultTemplate
+		}
+		tmpl, err := template.New(sinkConfig.Name).Parse(text)
+		if err != nil {
+			return nil, fmt.Errorf("sink %s: invalid template: %w", sinkConfig.Name, err)
+		}
+
+		notifier.AddSink(sink, sinkConfig.MinSeverity, tmpl)
+	}
+
+	return notifier, nil
+}
+
+func newSink(config SinkConfig) (Sink, error) {
+	switch strings.ToLower(config.Type) {
+	case "webhook":
+		if config.URL == "" {
+			return nil, fmt.Errorf("url is required")
+		}
+		return NewWebhookSink(config.Name, config.URL, config.Headers), nil
+	case "slack", "teams":
+		if config.URL == "" {
+			return nil, fmt.Errorf("url is required")
+		}
+		return NewChatSink(config.Name, strings.ToLower(config.Type), config.URL), nil
+	case "smtp", "email":
+		smtp := config.SMTP
+		if smtp.Host == "" || smtp.From == "" || len(smtp.To) == 0 {
+			return nil, fmt.Errorf("smtp host, from and to are required")
+		}
+		password := ""
+		if smtp.PasswordEnv != "" {
+			password = os.Getenv(smtp.PasswordEnv)
+		}
+		return NewSMTPSink(config.Name, smtp.Host, smtp.Port, smtp.From, smtp.To, smtp.Username, password), nil
+	default:
+		return nil, fmt.Errorf("unsupported sink type: %q", config.Type)
+	}
+}
+
+// AddSink registers a sink that receives alerts at or above minSeverity. A nil
+// template uses the default message format.
+func (n *Notifier) AddSink(sink Sink, minSeverity string, tmpl *template.Template) {
+	if tmpl == nil {
+		tmpl = template.Must(template.New(sink.Name()).Parse(defaultTemplate))
+	}
+	n.sinks = append(n.sinks, sinkEntry{
+		sink:        sink,
+		minSeverity: SeverityRank(minSeverity),
+		template:    tmpl,
+	})
+}
+
+func (n *Notifier) SetRetry(retries int, backoff time.Duration) {
+	n.retries = retries
+	n.backoff = backoff
+}
+
+// Notify delivers the alert to every sink whose severity filter matches,
+// retrying failed deliveries with exponential backoff.
+func (n *Notifier) Notify(ctx context.Context, alert Alert) error {
+	if alert.Time.IsZero() {
+		alert.Time = time.Now()
+	}
+
+	var errs []error
+	for _, entry := range n.sinks {
+		if SeverityRank(alert.Severity) < entry.minSeverity {
+			continue
+		}
+
+		var text bytes.Buffer
+		if err := entry.template.Execute(&text, alert); err != nil {
+			errs = append(errs, fmt.Errorf("%s: failed to render template: %w", entry.sink.Name(), err))
+			continue
+		}
+
+		if err := n.sendWithRetry(ctx, entry.sink, alert, text.String()); err != nil {
+			errs = append(errs, fmt.Errorf("%s: %w", entry.sink.Name(), err))
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+func (n *Notifier) sendWithRetry(ctx context.Context, sink Sink, alert Alert, text string) error {
+	backoff := n.backoff
+	var err error
+
+	for attempt := 0; attempt <= n.retries; attempt++ {
+		if attempt > 0 {
+			select {
+			case <-ctx.Done():
+				return ctx.Err()
+			case <-time.After(backoff):
+			}
+			backoff *= 2
+		}
+
+		err = sink.Send(ctx, alert, text)
+		if err == nil {
+			return nil
+		}
+
+		var permanent *permanentError
+		if errors.As(err, &permanent) {
+			return err
+		}
+	}
+
+	return fmt.Errorf("giving up after %d attempts: %w", n.retries+1, err)
+}
+
+// SeverityRank orders both event (Critical/Warning/Info) and recommendation
+// (High/Medium/Low) severities on a single scale.
+func SeverityRank(severity string) int {
+	switch strings.ToLower(severity) {
+	case "critical", "high":
+		return 3
+	case "warning", "medium":
+		return 2
+	case "low", "info":
+		return 1
+	default:
+		return 0
+	}
+}
+
+// Queue delivers alerts on a background worker so slow or failing sinks
+// never block the caller. When the buffer is full new alerts are dropped.
+type Queue struct {
+	notifier *Notifier
+	alerts   chan Alert
+	done     chan struct{}
+	dropped  atomic.Int64
+}
+
+// NewQueue starts the worker; onError is called from it for failed
+// deliveries. Close the queue to flush pending alerts and stop the worker.
+func NewQueue(ctx context.Context, notifier *Notifier, size int, onError func(Alert, error)) *Queue {
+	queue := &Queue{
+		notifier: notifier,
+		alerts:   make(chan Alert, size),
+		done:     make(chan struct{}),
+	}
+
+	go func() {
+		defer close(queue.done)
+		for alert := range queue.alerts {
+			if err := notifier.Notify(ctx, alert); err != nil && onError != nil {
+				onError(alert, err)
+			}
+		}
+	}()
+
+	return queue
+}
+
+// Enqueue reports whether the alert was queued.
+func (q *Queue) Enqueue(alert Alert) bool {
+	select {
+	case q.alerts <- alert:
+		return true
+	default:
+		q.dropped.Add(1)
+		return false
+	}
+}
+
+func (q *Queue) Dropped() int64 {
+	return q.dropped.Load()
+}
+
+func (q *Queue) Close() {
+	close(q.alerts)
+	<-q.done
+}
@@ -0,0 +1,267 @@
+package notify
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"text/template"
+	"time"
+)
+
+func TestWebhookSinkRetriesAndFilters(t *testing.T) {
+	var attempts int32
+	var received map[string]interface{}
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if atomic.AddInt32(&attempts, 1) < 3 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		if r.Header.Get("X-Token") != "secret" {
+			t.Errorf("expected custom header to be set")
+		}
+		_ = json.NewDecoder(r.Body).Decode(&received)
+	}))
+	defer server.Close()
+
+	notifier, err := NewNotifier(&Config{
+		Retries: 3,
+		Backoff: "1ms",
+		Sinks: []SinkConfig{{
+			Name:        "hook",
+			Type:        "webhook",
+			URL:         server.URL,
+			Headers:     map[string]string{"X-Token": "secret"},
+			MinSeverity: "Critical",
+			Template:    "{{.Severity}}: {{.Title}}",
+		}},
+	})
+	if err != nil {
+		t.Fatalf("failed to create notifier: %v", err)
+	}
+
+	if err := notifier.Notify(context.Background(), Alert{Title: "Disk pressure", Severity: "Warning"}); err != nil {
+		t.Fatalf("unexpected error for filtered alert: %v", err)
+	}
+	if attempts != 0 {
+		t.Fatalf("expected Warning alert to be filtered out, got %d attempts", attempts)
+	}
+
+	if err := notifier.Notify(context.Background(), Alert{Title: "Node down", Severity: "High"}); err != nil {
+		t.Fatalf("expected delivery after retries, got %v", err)
+	}
+	if attempts != 3 {
+		t.Errorf("expected 3 attempts, got %d", attempts)
+	}
+	if received["text"] != "High: Node down" || received["title"] != "Node down" {
+		t.Errorf("unexpected webhook payload: %v", received)
+	}
+}
+
+func TestWebhookSinkDoesNotRetryClientErrors(t *testing.T) {
+	var attempts int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&attempts, 1)
+		w.WriteHeader(http.StatusBadRequest)
+	}))
+	defer server.Close()
+
+	notifier := &Notifier{}
+	notifier.SetRetry(3, time.Millisecond)
+	notifier.AddSink(NewWebhookSink("hook", server.URL, nil), "", nil)
+
+	if err := notifier.Notify(context.Background(), Alert{Title: "x", Severity: "Critical"}); err == nil {
+		t.Fatal("expected error for 400 response")
+	}
+	if attempts != 1 {
+		t.Errorf("expected a single attempt for a client error, got %d", attempts)
+	}
+}
+
+func TestChatSinkPayloads(t *testing.T) {
+	var payloads []map[string]interface{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var payload map[string]interface{}
+		_ = json.NewDecoder(r.Body).Decode(&payload)
+		payloads = append(payloads, payload)
+	}))
+	defer server.Close()
+
+	notifier := &Notifier{}
+	notifier.AddSink(NewChatSink("slack", "slack", server.URL), "", nil)
+	notifier.AddSink(NewChatSink("teams", "teams", server.URL), "", template.Must(template.New("t").Parse("{{.Message}}")))
+
+	alert := Alert{Title: "CrashLoopBackOff", Message: "api keeps restarting", Severity: "Critical", Object: "Pod/api", Namespace: "prod"}
+	if err := notifier.Notify(context.Background(), alert); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if len(payloads) != 2 {
+		t.Fatalf("expected 2 payloads, got %d", len(payloads))
+	}
+	if payloads[0]["text"] != "[Critical] CrashLoopBackOff (prod/Pod/api): api keeps restarting" {
+		t.Errorf("unexpected slack text: %v", payloads[0]["text"])
+	}
+	if payloads[1]["@type"] != "MessageCard" || payloads[1]["text"] != "api keeps restarting" {
+		t.Errorf("unexpected teams payload: %v", payloads[1])
+	}
+}
+
+func TestSMTPSink(t *testing.T) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("failed to listen: %v", err)
+	}
+	defer listener.Close()
+
+	messages := make(chan string, 1)
+	go serveFakeSMTP(listener, messages)
+
+	addr := listener.Addr().(*net.TCPAddr)
+	sink := NewSMTPSink("mail", "127.0.0.1", addr.Port, "k8s-cli@example.com", []string{"oncall@example.com"}, "", "")
+
+	if err := sink.Send(context.Background(), Alert{Title: "Node down", Severity: "Critical", Time: time.Now()}, "node-1 is NotReady"); err != nil {
+		t.Fatalf("failed to send mail: %v", err)
+	}
+
+	select {
+	case message := <-messages:
+		if !strings.Contains(message, "Subject: [k8s-cli] [Critical] Node down") || !strings.Contains(message, "node-1 is NotReady") {
+			t.Errorf("unexpected message: %q", message)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("timed out waiting for message")
+	}
+}
+
+// serveFakeSMTP implements just enough of SMTP for net/smtp.SendMail.
+func serveFakeSMTP(listener net.Listener, messages chan<- string) {
+	conn, err := listener.Accept()
+	if err != nil {
+		return
+	}
+	defer conn.Close()
+
+	reader := bufio.NewReader(conn)
+	reply := func(line string) {
+		_, _ = conn.Write([]byte(line + "\r\n"))
+	}
+
+	reply("220 localhost ESMTP")
+	for {
+		line, err := reader.ReadString('\n')
+		if err != nil {
+			return
+		}
+
+		command := strings.ToUpper(strings.TrimSpace(line))
+		switch {
+		case strings.HasPrefix(command, "EHLO"), strings.HasPrefix(command, "HELO"):
+			reply("250 localhost")
+		case strings.HasPrefix(command, "DATA"):
+			reply("354 End data with <CR><LF>.<CR><LF>")
+			var data strings.Builder
+			for {
+				dataLine, err := reader.ReadString('\n')
+				if err != nil {
+					return
+				}
+				if dataLine == ".\r\n" {
+					break
+				}
+				data.WriteString(dataLine)
+			}
+			messages <- data.String()
+			reply("250 OK")
+		case strings.HasPrefix(command, "QUIT"):
+			reply("221 Bye")
+			return
+		default:
+			reply("250 OK")
+		}
+	}
+}
+
+type blockingSink struct {
+	release chan struct{}
+	sent    int32
+}
+
+func (s *blockingSink) Name() string {
+	return "blocking"
+}
+
+func (s *blockingSink) Send(ctx context.Context, alert Alert, text string) error {
+	<-s.release
+	atomic.AddInt32(&s.sent, 1)
+	return nil
+}
+
+func TestQueueDropsWhenFull(t *testing.T) {
+	sink := &blockingSink{release: make(chan struct{})}
+	notifier := &Notifier{}
+	notifier.AddSink(sink, "", nil)
+
+	queue := NewQueue(context.Background(), notifier, 2, nil)
+
+	// The worker holds one alert while the sink blocks, two more fit in the buffer
+	start := time.Now()
+	queued := 0
+	for i := 0; i < 10; i++ {
+		if queue.Enqueue(Alert{Title: "event", Severity: "Critical"}) {
+			queued++
+		}
+		if i == 0 {
+			time.Sleep(50 * time.Millisecond)
+		}
+	}
+	if time.Since(start) > time.Second {
+		t.Errorf("expected Enqueue not to block on a slow sink")
+	}
+	if queued != 3 || queue.Dropped() != 7 {
+		t.Errorf("expected 3 queued and 7 dropped, got %d and %d", queued, queue.Dropped())
+	}
+
+	close(sink.release)
+	queue.Close()
+	if sent := atomic.LoadInt32(&sink.sent); sent != 3 {
+		t.Errorf("expected queued alerts to be flushed on Close, got %d", sent)
+	}
+}
+
+func TestSMTPSinkHonorsContext(t *testing.T) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("failed to listen: %v", err)
+	}
+	defer listener.Close()
+
+	// Accept but never send the greeting
+	go func() {
+		conn, err := listener.Accept()
+		if err == nil {
+			defer conn.Close()
+			time.Sleep(5 * time.Second)
+		}
+	}()
+
+	addr := listener.Addr().(*net.TCPAddr)
+	sink := NewSMTPSink("mail", "127.0.0.1", addr.Port, "k8s-cli@example.com", []string{"oncall@example.com"}, "", "")
+
+	ctx, cancel := context.WithTimeout(context.Background(), 200*time.Millisecond)
+	defer cancel()
+
+	start := time.Now()
+	if err := sink.Send(ctx, Alert{Title: "Node down", Severity: "Critical", Time: time.Now()}, "node-1 is NotReady"); err == nil {
+		t.Fatal("expected a hung server to fail the send")
+	}
+	if elapsed := time.Since(start); elapsed > 2*time.Second {
+		t.Errorf("expected the send to stop with the context, took %s", elapsed)
+	}
+}
@@ -0,0 +1,256 @@
+package notify
+
+import (
+	"bytes"
+	"context"
+	"crypto/tls"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/smtp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+type WebhookSink struct {
+	name    string
+	url     string
+	headers map[string]string
+	client  *http.Client
+}
+
+func NewWebhookSink(name, url string, headers map[string]string) *WebhookSink {
+	return &WebhookSink{
+		name:    name,
+		url:     url,
+		headers: headers,
+		client:  &http.Client{Timeout: 10 * time.Second},
+	}
+}
+
+func (s *WebhookSink) Name() string {
+	return s.name
+}
+
+// Send posts the alert as JSON, with the rendered template in "text".
+func (s *WebhookSink) Send(ctx context.Context, alert Alert, text string) error {
+	payload := struct {
+		Alert
+		Text string `json:"text"`
+	}{alert, text}
+
+	return postJSON(ctx, s.client, s.url, s.headers, payload)
+}
+
+// ChatSink posts to Slack or Microsoft Teams incoming webhooks.
+type ChatSink struct {
+	name   string
+	format string
+	url    string
+	client *http.Client
+}
+
+func NewChatSink(name, format, url string) *ChatSink {
+	return &ChatSink{
+		name:   name,
+		format: format,
+		url:    url,
+		client: &http.Client{Timeout: 10 * time.Second},
+	}
+}
+
+func (s *ChatSink) Name() string {
+	return s.name
+}
+
+func (s *ChatSink) Send(ctx context.Context, alert Alert, text string) error {
+	var payload interface{}
+
+	if s.format == "teams" {
+		payload = map[string]interface{}{
+			"@type":      "MessageCard",
+			"@context":   "https://schema.org/extensions",
+			"summary":    alert.Title,
+			"title":      alert.Title,
+			"text":       text,
+			"themeColor": severityColor(alert.Severity),
+		}
+	} else {
+		payload = map[string]interface{}{
+			"text": text,
+			"attachments": []map[string]interface{}{{
+				"color":  "#" + severityColor(alert.Severity),
+				"title":  alert.Title,
+				"text":   alert.Message,
+				"footer": "k8s-cli " + alert.Source,
+				"ts":     alert.Time.Unix(),
+			}},
+		}
+	}
+
+	return postJSON(ctx, s.client, s.url, nil, payload)
+}
+
+// smtpTimeout bounds a whole SMTP session when the context has no earlier
+// deadline.
+const smtpTimeout = 30 * time.Second
+
+type SMTPSink struct {
+	name     string
+	addr     string
+	host     string
+	from     string
+	to       []string
+	username string
+	password string
+}
+
+func NewSMTPSink(name, host string, port int, from string, to []string, username, password string) *SMTPSink {
+	if port == 0 {
+		port = 587
+	}
+	return &SMTPSink{
+		name:     name,
+		addr:     net.JoinHostPort(host, strconv.Itoa(port)),
+		host:     host,
+		from:     from,
+		to:       to,
+		username: username,
+		password: password,
+	}
+}
+
+func (s *SMTPSink) Name() string {
+	return s.name
+}
+
+func (s *SMTPSink) Send(ctx context.Context, alert Alert, text string) error {
+	var auth smtp.Auth
+	if s.username != "" {
+		auth = smtp.PlainAuth("", s.username, s.password, s.host)
+	}
+
+	var message bytes.Buffer
+	fmt.Fprintf(&message, "From: %s\r\n", s.from)
+	fmt.Fprintf(&message, "To: %s\r\n", strings.Join(s.to, ", "))
+	fmt.Fprintf(&message, "Subject: [k8s-cli] [%s] %s\r\n", alert.Severity, alert.Title)
+	fmt.Fprintf(&message, "Date: %s\r\n", alert.Time.Format(time.RFC1123Z))
+	message.WriteString("MIME-Version: 1.0\r\n")
+	message.WriteString("Content-Type: text/plain; charset=utf-8\r\n\r\n")
+	message.WriteString(strings.ReplaceAll(text, "\n", "\r\n"))
+	message.WriteString("\r\n")
+
+	if err := s.sendMail(ctx, auth, message.Bytes()); err != nil {
+		return fmt.Errorf("failed to send mail: %w", err)
+	}
+	return nil
+}
+
+// sendMail is smtp.SendMail with a context: the dial respects ctx, the
+// session has a deadline and cancelling ctx closes the connection.
+func (s *SMTPSink) sendMail(ctx context.Context, auth smtp.Auth, message []byte) error {
+	dialer := net.Dialer{Timeout: smtpTimeout}
+	conn, err := dialer.DialContext(ctx, "tcp", s.addr)
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+
+	deadline := time.Now().Add(smtpTimeout)
+	if ctxDeadline, ok := ctx.Deadline(); ok && ctxDeadline.Before(deadline) {
+		deadline = ctxDeadline
+	}
+	if err := conn.SetDeadline(deadline); err != nil {
+		return err
+	}
+	stop := context.AfterFunc(ctx, func() { conn.Close() })
+	defer stop()
+
+	client, err := smtp.NewClient(conn, s.host)
+	if err != nil {
+		return err
+	}
+	defer client.Close()
+
+	if ok, _ := client.Extension("STARTTLS"); ok {
+		if err := client.StartTLS(&tls.Config{ServerName: s.host}); err != nil {
+			return err
+		}
+	}
+	if auth != nil {
+		if ok, _ := client.Extension("AUTH"); ok {
+			if err := client.Auth(auth); err != nil {
+				return err
+			}
+		}
+	}
+
+	if err := client.Mail(s.from); err != nil {
+		return err
+	}
+	for _, recipient := range s.to {
+		if err := client.Rcpt(recipient); err != nil {
+			return err
+		}
+	}
+
+	writer, err := client.Data()
+	if err != nil {
+		return err
+	}
+	if _, err := writer.Write(message); err != nil {
+		return err
+	}
+	if err := writer.Close(); err != nil {
+		return err
+	}
+	return client.Quit()
+}
+
+func postJSON(ctx context.Context, client *http.Client, url string, headers map[string]string, payload interface{}) error {
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return &permanentError{fmt.Errorf("failed to encode payload: %w", err)}
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(body))
+	if err != nil {
+		return &permanentError{fmt.Errorf("failed to create request: %w", err)}
+	}
+	req.Header.Set("Content-Type", "application/json")
+	for key, value := range headers {
+		req.Header.Set(key, value)
+	}
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to post alert: %w", err)
+	}
+	defer resp.Body.Close()
+	_, _ = io.Copy(io.Discard, resp.Body)
+
+	if resp.StatusCode >= 300 {
+		err := fmt.Errorf("unexpected response status: %s", resp.Status)
+		// Client errors other than rate limiting will not succeed on retry
+		if resp.StatusCode >= 400 && resp.StatusCode < 500 && resp.StatusCode != http.StatusTooManyRequests {
+			return &permanentError{err}
+		}
+		return err
+	}
+
+	return nil
+}
+
+func severityColor(severity string) string {
+	switch SeverityRank(severity) {
+	case 3:
+		return "D93F0B"
+	case 2:
+		return "FBCA04"
+	default:
+		return "0E8A16"
+	}
+}
@@ -18,7 +18,8 @@ type Recommendation struct {
 }
 
 type RecommendationAnalyzer struct {
-	client *kubernetes.Client
+	client      *kubernetes.Client
+	catalogPath string
 }
 
 func NewRecommendationAnalyzer(client *kubernetes.Client) *RecommendationAnalyzer {
@@ -27,6 +28,12 @@ func NewRecommendationAnalyzer(client *kubernetes.Client) *RecommendationAnalyze
 	}
 }
 
+// SetComponentCatalogPath overrides the local component catalog file used to
+// detect outdated components.
+func (r *RecommendationAnalyzer) SetComponentCatalogPath(path string) {
+	r.catalogPath = path
+}
+
 func (r *RecommendationAnalyzer) AnalyzeCluster() ([]Recommendation, error) {
 	var recommendations []Recommendation
 
@@ -50,11 +57,36 @@ func (r *RecommendationAnalyzer) AnalyzeCluster() ([]Recommendation, error) {
 		recommendations = append(recommendations, componentRecs...)
 	}
 
+	componentVersionRecs, err := r.analyzeComponentVersions()
+	if err == nil {
+		recommendations = append(recommendations, componentVersionRecs...)
+	}
+
 	versionRecs, err := r.analyzeVersions()
 	if err == nil {
 		recommendations = append(recommendations, versionRecs...)
 	}
 
+	deprecatedRecs, err := r.analyzeDeprecatedAPIs()
+	if err == nil {
+		recommendations = append(recommendations, deprecatedRecs...)
+	}
+
+	networkRecs, err := r.analyzeNetwork()
+	if err == nil {
+		recommendations = append(recommendations, networkRecs...)
+	}
+
+	imageRecs, err := r.analyzeImages()
+	if err == nil {
+		recommendations = append(recommendations, imageRecs...)
+	}
+
+	nodeConditionRecs, err := r.analyzeNodeConditions()
+	if err == nil {
+		recommendations = append(recommendations, nodeConditionRecs...)
+	}
+
 	return recommendations, nil
 }
 
@@ -98,12 +130,16 @@ func (r *RecommendationAnalyzer) analyzeSimpleNodes() ([]Recommendation, error)
 	}
 
 	notReadyNodes := 0
+	cordonedNodes := 0
 	oldNodes := 0
 
 	for _, node := range nodes {
-		if strings.ToLower(node.Status) != "ready" {
+		if !strings.HasPrefix(node.Status, "Ready") {
 			notReadyNodes++
 		}
+		if strings.HasSuffix(node.Status, ",SchedulingDisabled") {
+			cordonedNodes++
+		}
 
 		if strings.Contains(node.Age, "d") {
 			daysStr := strings.TrimSuffix(node.Age, "d")
@@ -123,6 +159,16 @@ func (r *RecommendationAnalyzer) analyzeSimpleNodes() ([]Recommendation, error)
 		})
 	}
 
+	if cordonedNodes > 0 {
+		recommendations = append(recommendations, Recommendation{
+			Type:        "Availability",
+			Severity:    "Medium",
+			Title:       "Cordoned Nodes",
+			Description: fmt.Sprintf("%d nodes are cordoned and do not accept new pods.", cordonedNodes),
+			Action:      "Uncordon the nodes once maintenance is finished, or drain and remove them. Run 'k8s-cli nodes' for details.",
+		})
+	}
+
 	if oldNodes > 0 {
 		recommendations = append(recommendations, Recommendation{
 			Type:        "Maintenance",
@@ -237,6 +283,75 @@ func (r *RecommendationAnalyzer) analyzeComponents() ([]Recommendation, error) {
 		})
 	}
 
+	if releases, err := r.client.GetHelmReleases(""); err == nil {
+		var flagged []string
+		for _, release := range releases {
+			if release.Flagged {
+				flagged = append(flagged, fmt.Sprintf("%s/%s (%s)", release.Namespace, release.Name, release.Status))
+			}
+		}
+
+		if len(flagged) > 0 {
+			recommendations = append(recommendations, Recommendation{
+				Type:        "Component",
+				Severity:    "High",
+				Title:       "Failed or Pending Helm Releases",
+				Description: fmt.Sprintf("%d Helm releases are failed or stuck: %s.", len(flagged), strings.Join(flagged, ", ")),
+				Action:      "Inspect with 'k8s-cli helm --history <release>' and roll back or re-run the upgrade.",
+			})
+		}
+	}
+
+	return recommendations, nil
+}
+
+func (r *RecommendationAnalyzer) analyzeComponentVersions() ([]Recommendation, error) {
+	var recommendations []Recommendation
+
+	catalog, err := kubernetes.LoadComponentCatalog(r.catalogPath)
+	if err != nil {
+		return recommendations, err
+	}
+
+	statuses, err := r.client.GetComponentVersionStatus(catalog)
+	if err != nil {
+		return recommendations, err
+	}
+
+	var outdated []string
+	for _, status := range statuses {
+		switch status.Status {
+		case "Up to date":
+			continue
+		case "Outdated":
+			outdated = append(outdated, fmt.Sprintf("%s %s", status.Name, status.InstalledVersion))
+			continue
+		}
+
+		recType := "Component"
+		if status.Status == "Vulnerable" {
+			recType = "Security"
+		}
+
+		recommendations = append(recommendations, Recommendation{
+			Type:        recType,
+			Severity:    status.Severity,
+			Title:       fmt.Sprintf("%s Component: %s", status.Status, status.Name),
+			Description: fmt.Sprintf("%s %s in %s: %s.", status.CatalogName, status.InstalledVersion, status.Namespace, strings.Join(status.Issues, "; ")),
+			Action:      fmt.Sprintf("Upgrade %s to %s.", status.CatalogName, status.LatestVersion),
+		})
+	}
+
+	if len(outdated) > 0 {
+		recommendations = append(recommendations, Recommendation{
+			Type:        "Maintenance",
+			Severity:    "Low",
+			Title:       "Outdated Components",
+			Description: fmt.Sprintf("%d components have newer releases available: %s.", len(outdated), strings.Join(outdated, ", ")),
+			Action:      "Plan upgrades to the latest supported releases.",
+		})
+	}
+
 	return recommendations, nil
 }
 
@@ -272,3 +387,183 @@ func (r *RecommendationAnalyzer) analyzeVersions() ([]Recommendation, error) {
 	return recommendations, nil
 }
 
+
+func (r *RecommendationAnalyzer) analyzeDeprecatedAPIs() ([]Recommendation, error) {
+	var recommendations []Recommendation
+
+	result, err := r.client.GetUpgradeCheck("")
+	if err != nil {
+		return recommendations, err
+	}
+
+	if result.RemovedCount > 0 {
+		recommendations = append(recommendations, Recommendation{
+			Type:        "Maintenance",
+			Severity:    "High",
+			Title:       "Removed APIs In Use",
+			Description: fmt.Sprintf("%d objects use APIs that are removed in Kubernetes %s.", result.RemovedCount, result.TargetVersion),
+			Action:      fmt.Sprintf("Migrate manifests to the replacement APIs before upgrading (see 'k8s-cli upgrade-check --target %s').", result.TargetVersion),
+			Link:        "https://kubernetes.io/docs/reference/using-api/deprecation-guide/",
+		})
+	}
+
+	if result.DeprecatedCount > 0 {
+		recommendations = append(recommendations, Recommendation{
+			Type:        "Maintenance",
+			Severity:    "Low",
+			Title:       "Deprecated APIs In Use",
+			Description: fmt.Sprintf("%d objects use deprecated APIs.", result.DeprecatedCount),
+			Action:      "Plan migration to the replacement APIs.",
+			Link:        "https://kubernetes.io/docs/reference/using-api/deprecation-guide/",
+		})
+	}
+
+	return recommendations, nil
+}
+
+func (r *RecommendationAnalyzer) analyzeNetwork() ([]Recommendation, error) {
+	var recommendations []Recommendation
+
+	analysis, err := r.client.GetNetworkAnalysis("")
+	if err != nil {
+		return recommendations, err
+	}
+
+	summary := analysis.NetworkSummary
+
+	if summary.UncoveredNamespaces > 0 {
+		recommendations = append(recommendations, Recommendation{
+			Type:        "Security",
+			Severity:    "Medium",
+			Title:       "Namespaces Without NetworkPolicies",
+			Description: fmt.Sprintf("%d namespaces with running pods have no NetworkPolicy selecting them.", summary.UncoveredNamespaces),
+			Action:      "Add a default-deny NetworkPolicy and explicitly allow required traffic.",
+		})
+	}
+
+	highRiskServices := 0
+	for _, svc := range analysis.ExposedServices {
+		if svc.RiskLevel == "High" {
+			highRiskServices++
+		}
+	}
+
+	if highRiskServices > 0 {
+		recommendations = append(recommendations, Recommendation{
+			Type:        "Security",
+			Severity:    "High",
+			Title:       "Unrestricted External Services",
+			Description: fmt.Sprintf("%d services are exposed externally without source range restrictions.", highRiskServices),
+			Action:      "Set loadBalancerSourceRanges or expose the services through an Ingress.",
+		})
+	}
+
+	if summary.InsecureIngresses > 0 {
+		recommendations = append(recommendations, Recommendation{
+			Type:        "Security",
+			Severity:    "Medium",
+			Title:       "Ingresses Without TLS",
+			Description: fmt.Sprintf("%d ingresses serve traffic without TLS.", summary.InsecureIngresses),
+			Action:      "Configure TLS certificates for all ingress hosts.",
+		})
+	}
+
+	if summary.ServicesWithoutEndpoints > 0 {
+		recommendations = append(recommendations, Recommendation{
+			Type:        "Workload",
+			Severity:    "Low",
+			Title:       "Services Without Endpoints",
+			Description: fmt.Sprintf("%d services have no backing endpoints.", summary.ServicesWithoutEndpoints),
+			Action:      "Fix service selectors or remove unused services.",
+		})
+	}
+
+	return recommendations, nil
+}
+
+func (r *RecommendationAnalyzer) analyzeImages() ([]Recommendation, error) {
+	var recommendations []Recommendation
+
+	inventory, err := r.client.GetImageInventory("", nil)
+	if err != nil {
+		return recommendations, err
+	}
+
+	summary := inventory.Summary
+
+	if summary.PullFailures > 0 {
+		recommendations = append(recommendations, Recommendation{
+			Type:        "Workload",
+			Severity:    "High",
+			Title:       "Image Pull Failures",
+			Description: fmt.Sprintf("%d pods recently failed to pull their images.", summary.PullFailures),
+			Action:      "Check image names, tags and registry credentials (imagePullSecrets).",
+		})
+	}
+
+	if summary.LatestOrUntagged > 0 {
+		recommendations = append(recommendations, Recommendation{
+			Type:        "Workload",
+			Severity:    "Medium",
+			Title:       "Images Using Latest Tag",
+			Description: fmt.Sprintf("%d images use the latest tag or no tag at all.", summary.LatestOrUntagged),
+			Action:      "Pin images to a specific version tag or digest for reproducible deployments.",
+		})
+	}
+
+	if summary.TagConflicts > 0 {
+		recommendations = append(recommendations, Recommendation{
+			Type:        "Workload",
+			Severity:    "Low",
+			Title:       "Mixed Image Tags Across Namespaces",
+			Description: fmt.Sprintf("%d repositories run different tags in different namespaces.", summary.TagConflicts),
+			Action:      "Align image versions across environments or document the intended drift.",
+		})
+	}
+
+	return recommendations, nil
+}
+
+func (r *RecommendationAnalyzer) analyzeNodeConditions() ([]Recommendation, error) {
+	var recommendations []Recommendation
+
+	analysis, err := r.client.GetNodeAnalysis()
+	if err != nil {
+		return recommendations, err
+	}
+
+	var pressureNodes, skewedNodes []string
+	for _, node := range analysis.Nodes {
+		for _, condition := range node.Conditions {
+			if strings.Contains(condition, "Pressure") {
+				pressureNodes = append(pressureNodes, node.Name)
+				break
+			}
+		}
+		if node.VersionSkew < 0 || node.VersionSkew > 3 {
+			skewedNodes = append(skewedNodes, node.Name)
+		}
+	}
+
+	if len(pressureNodes) > 0 {
+		recommendations = append(recommendations, Recommendation{
+			Type:        "Performance",
+			Severity:    "High",
+			Title:       "Nodes Under Resource Pressure",
+			Description: fmt.Sprintf("%d nodes report memory, disk or PID pressure: %s.", len(pressureNodes), strings.Join(pressureNodes, ", ")),
+			Action:      "Free resources or add capacity; the kubelet evicts pods from nodes under pressure.",
+		})
+	}
+
+	if len(skewedNodes) > 0 {
+		recommendations = append(recommendations, Recommendation{
+			Type:        "Maintenance",
+			Severity:    "Medium",
+			Title:       "Unsupported Kubelet Version Skew",
+			Description: fmt.Sprintf("%d nodes run a kubelet outside the supported skew of control plane %s.", len(skewedNodes), analysis.ControlPlaneVersion),
+			Action:      "Upgrade the node pools so kubelets are at most three minor versions behind the API server.",
+		})
+	}
+
+	return recommendations, nil
+}
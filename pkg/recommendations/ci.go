@@ -0,0 +1,323 @@
+package recommendations
+
+import (
+	"encoding/json"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+)
+
+const sarifSchema = "https://json.schemastore.org/sarif-2.1.0.json"
+
+var severityLevels = map[string]int{"low": 1, "medium": 2, "high": 3}
+
+// ParseSeverity validates a --fail-on style threshold.
+func ParseSeverity(severity string) (string, error) {
+	normalized := strings.ToLower(strings.TrimSpace(severity))
+	if _, ok := severityLevels[normalized]; !ok {
+		return "", fmt.Errorf("unsupported severity %q (use high, medium or low)", severity)
+	}
+	return normalized, nil
+}
+
+// AtOrAbove reports whether a severity meets the threshold. An empty
+// threshold matches every severity.
+func AtOrAbove(severity, threshold string) bool {
+	if threshold == "" {
+		return true
+	}
+	return severityLevels[strings.ToLower(severity)] >= severityLevels[strings.ToLower(threshold)]
+}
+
+// CountAtOrAbove returns how many recommendations meet the threshold.
+func CountAtOrAbove(recs []Recommendation, threshold string) int {
+	count := 0
+	for _, rec := range recs {
+		if AtOrAbove(rec.Severity, threshold) {
+			count++
+		}
+	}
+	return count
+}
+
+// RuleID is a stable identifier for a recommendation, e.g.
+// "security/ingresses-without-tls".
+func RuleID(rec Recommendation) string {
+	return slugify(rec.Type) + "/" + slugify(rec.Title)
+}
+
+func slugify(value string) string {
+	var slug strings.Builder
+	dash := false
+	for _, r := range strings.ToLower(value) {
+		if (r >= 'a' && r <= 'z') || (r >= '0' && r <= '9') {
+			slug.WriteRune(r)
+			dash = false
+		} else if !dash && slug.Len() > 0 {
+			slug.WriteByte('-')
+			dash = true
+		}
+	}
+	return strings.TrimSuffix(slug.String(), "-")
+}
+
+type sarifLog struct {
+	Schema  string     `json:"$schema"`
+	Version string     `json:"version"`
+	Runs    []sarifRun `json:"runs"`
+}
+
+type sarifRun struct {
+	Tool    sarifTool     `json:"tool"`
+	Results []sarifResult `json:"results"`
+}
+
+type sarifTool struct {
+	Driver sarifDriver `json:"driver"`
+}
+
+type sarifDriver struct {
+	Name    string      `json:"name"`
+	Version string      `json:"version,omitempty"`
+	Rules   []sarifRule `json:"rules"`
+}
+
+type sarifRule struct {
+	ID                   string              `json:"id"`
+	Name                 string              `json:"name"`
+	ShortDescription     sarifMessage        `json:"shortDescription"`
+	FullDescription      sarifMessage        `json:"fullDescription"`
+	Help                 sarifMessage        `json:"help"`
+	HelpURI              string              `json:"helpUri,omitempty"`
+	DefaultConfiguration sarifConfiguration  `json:"defaultConfiguration"`
+	Properties           sarifRuleProperties `json:"properties"`
+}
+
+type sarifConfiguration struct {
+	Level string `json:"level"`
+}
+
+type sarifRuleProperties struct {
+	Tags             []string `json:"tags"`
+	SecuritySeverity string   `json:"security-severity"`
+}
+
+type sarifMessage struct {
+	Text string `json:"text"`
+}
+
+type sarifResult struct {
+	RuleID    string          `json:"ruleId"`
+	RuleIndex int             `json:"ruleIndex"`
+	Level     string          `json:"level"`
+	Message   sarifMessage    `json:"message"`
+	Locations []sarifLocation `json:"locations"`
+}
+
+type sarifLocation struct {
+	PhysicalLocation sarifPhysicalLocation  `json:"physicalLocation"`
+	LogicalLocations []sarifLogicalLocation `json:"logicalLocations"`
+}
+
+type sarifPhysicalLocation struct {
+	ArtifactLocation sarifArtifactLocation `json:"artifactLocation"`
+}
+
+type sarifArtifactLocation struct {
+	URI string `json:"uri"`
+}
+
+type sarifLogicalLocation struct {
+	Name               string `json:"name"`
+	FullyQualifiedName string `json:"fullyQualifiedName"`
+	Kind               string `json:"kind"`
+}
+
+// sarifLevel maps recommendation severities to SARIF levels; the numeric
+// security-severity drives the severity shown by code-scanning UIs.
+func sarifLevel(severity string) (string, string) {
+	switch strings.ToLower(severity) {
+	case "high":
+		return "error", "8.0"
+	case "medium":
+		return "warning", "5.0"
+	}
+	return "note", "2.0"
+}
+
+// WriteSARIF writes recommendations as a SARIF 2.1.0 log with one rule per
+// distinct recommendation. Cluster findings have no source file, so every
+// result points at the cluster identified by source.
+func WriteSARIF(w io.Writer, recs []Recommendation, toolVersion, source string) error {
+	if source == "" {
+		source = "cluster"
+	}
+
+	driver := sarifDriver{
+		Name:    "k8s-cli",
+		Version: toolVersion,
+		Rules:   []sarifRule{},
+	}
+	results := []sarifResult{}
+	ruleIndex := make(map[string]int)
+
+	for _, rec := range recs {
+		id := RuleID(rec)
+		level, securitySeverity := sarifLevel(rec.Severity)
+
+		index, exists := ruleIndex[id]
+		if !exists {
+			index = len(driver.Rules)
+			ruleIndex[id] = index
+			driver.Rules = append(driver.Rules, sarifRule{
+				ID:                   id,
+				Name:                 rec.Title,
+				ShortDescription:     sarifMessage{Text: rec.Title},
+				FullDescription:      sarifMessage{Text: rec.Description},
+				Help:                 sarifMessage{Text: rec.Action},
+				HelpURI:              rec.Link,
+				DefaultConfiguration: sarifConfiguration{Level: level},
+				Properties: sarifRuleProperties{
+					Tags:             []string{"kubernetes", strings.ToLower(rec.Type)},
+					SecuritySeverity: securitySeverity,
+				},
+			})
+		}
+
+		message := rec.Description
+		if rec.Action != "" {
+			message += " " + rec.Action
+		}
+
+		results = append(results, sarifResult{
+			RuleID:    id,
+			RuleIndex: index,
+			Level:     level,
+			Message:   sarifMessage{Text: message},
+			Locations: []sarifLocation{{
+				PhysicalLocation: sarifPhysicalLocation{ArtifactLocation: sarifArtifactLocation{URI: source}},
+				LogicalLocations: []sarifLogicalLocation{{
+					Name:               rec.Title,
+					FullyQualifiedName: rec.Type + "/" + rec.Title,
+					Kind:               "resource",
+				}},
+			}},
+		})
+	}
+
+	log := sarifLog{
+		Schema:  sarifSchema,
+		Version: "2.1.0",
+		Runs:    []sarifRun{{Tool: sarifTool{Driver: driver}, Results: results}},
+	}
+
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	if err := encoder.Encode(log); err != nil {
+		return fmt.Errorf("failed to encode SARIF: %w", err)
+	}
+	return nil
+}
+
+type junitTestSuites struct {
+	XMLName  xml.Name         `xml:"testsuites"`
+	Name     string           `xml:"name,attr"`
+	Tests    int              `xml:"tests,attr"`
+	Failures int              `xml:"failures,attr"`
+	Skipped  int              `xml:"skipped,attr"`
+	Suites   []junitTestSuite `xml:"testsuite"`
+}
+
+type junitTestSuite struct {
+	Name     string          `xml:"name,attr"`
+	Tests    int             `xml:"tests,attr"`
+	Failures int             `xml:"failures,attr"`
+	Skipped  int             `xml:"skipped,attr"`
+	Cases    []junitTestCase `xml:"testcase"`
+}
+
+type junitTestCase struct {
+	Name      string        `xml:"name,attr"`
+	ClassName string        `xml:"classname,attr"`
+	Failure   *junitFailure `xml:"failure,omitempty"`
+	Skipped   *junitSkipped `xml:"skipped,omitempty"`
+}
+
+type junitFailure struct {
+	Message string `xml:"message,attr"`
+	Type    string `xml:"type,attr"`
+	Text    string `xml:",chardata"`
+}
+
+type junitSkipped struct {
+	Message string `xml:"message,attr"`
+}
+
+// WriteJUnit writes one testsuite per recommendation type and one testcase
+// per recommendation. Findings at or above the threshold are failures; the
+// rest are reported as skipped so they stay visible without failing the run.
+func WriteJUnit(w io.Writer, recs []Recommendation, threshold string) error {
+	suites := make(map[string]*junitTestSuite)
+	report := junitTestSuites{Name: "k8s-cli recommendations"}
+
+	for _, rec := range recs {
+		suite, exists := suites[rec.Type]
+		if !exists {
+			suite = &junitTestSuite{Name: rec.Type}
+			suites[rec.Type] = suite
+		}
+
+		testCase := junitTestCase{
+			Name:      rec.Title,
+			ClassName: "k8s-cli." + RuleID(rec),
+		}
+		if AtOrAbove(rec.Severity, threshold) {
+			details := rec.Description
+			if rec.Action != "" {
+				details += "\nAction: " + rec.Action
+			}
+			if rec.Link != "" {
+				details += "\nSee: " + rec.Link
+			}
+			testCase.Failure = &junitFailure{
+				Message: fmt.Sprintf("[%s] %s", rec.Severity, rec.Title),
+				Type:    rec.Severity,
+				Text:    details,
+			}
+			suite.Failures++
+		} else {
+			testCase.Skipped = &junitSkipped{Message: fmt.Sprintf("%s severity is below the failure threshold", rec.Severity)}
+			suite.Skipped++
+		}
+
+		suite.Tests++
+		suite.Cases = append(suite.Cases, testCase)
+	}
+
+	var names []string
+	for name := range suites {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		suite := suites[name]
+		report.Tests += suite.Tests
+		report.Failures += suite.Failures
+		report.Skipped += suite.Skipped
+		report.Suites = append(report.Suites, *suite)
+	}
+
+	if _, err := io.WriteString(w, xml.Header); err != nil {
+		return err
+	}
+	encoder := xml.NewEncoder(w)
+	encoder.Indent("", "  ")
+	if err := encoder.Encode(report); err != nil {
+		return fmt.Errorf("failed to encode JUnit report: %w", err)
+	}
+	_, err := io.WriteString(w, "\n")
+	return err
+}
@@ -1,7 +1,97 @@
 package recommendations
 
-import "testing"
+import (
+	"bytes"
+	"encoding/json"
+	"encoding/xml"
+	"strings"
+	"testing"
+)
 
 func TestRecommendationsDummy(t *testing.T) {
 	t.Log("recommendations test running")
 }
+
+func TestCIReports(t *testing.T) {
+	recs := []Recommendation{
+		{Type: "Security", Severity: "High", Title: "Ingresses Without TLS", Description: "2 ingresses serve plain HTTP.", Action: "Add a tls section.", Link: "https://kubernetes.io/docs/concepts/services-networking/ingress/#tls"},
+		{Type: "Workload", Severity: "Medium", Title: "High Restart Count Pods", Description: "3 pods restart often."},
+		{Type: "Workload", Severity: "Low", Title: "Images Using Latest Tag", Description: "4 containers use :latest."},
+		{Type: "Component", Severity: "High", Title: "Degraded Component: cert-manager", Description: "cert-manager is not ready."},
+	}
+
+	if _, err := ParseSeverity("critical"); err == nil {
+		t.Errorf("expected unknown severity to be rejected")
+	}
+	if severity, err := ParseSeverity(" High "); err != nil || severity != "high" {
+		t.Errorf("expected High to parse as high, got %q (%v)", severity, err)
+	}
+
+	for threshold, expected := range map[string]int{"": 4, "low": 4, "medium": 3, "high": 2} {
+		if count := CountAtOrAbove(recs, threshold); count != expected {
+			t.Errorf("expected %d recommendations at or above %q, got %d", expected, threshold, count)
+		}
+	}
+
+	if id := RuleID(recs[3]); id != "component/degraded-component-cert-manager" {
+		t.Errorf("unexpected rule id %q", id)
+	}
+
+	var sarif bytes.Buffer
+	if err := WriteSARIF(&sarif, append(recs, recs[0]), "1.2.3", "https://10.0.0.1:6443"); err != nil {
+		t.Fatalf("failed to write SARIF: %v", err)
+	}
+
+	var log sarifLog
+	if err := json.Unmarshal(sarif.Bytes(), &log); err != nil {
+		t.Fatalf("failed to parse SARIF: %v", err)
+	}
+	run := log.Runs[0]
+	if log.Version != "2.1.0" || run.Tool.Driver.Version != "1.2.3" {
+		t.Errorf("unexpected SARIF header: version %q, tool %q", log.Version, run.Tool.Driver.Version)
+	}
+	if len(run.Tool.Driver.Rules) != 4 || len(run.Results) != 5 {
+		t.Fatalf("expected 4 rules and 5 results, got %d and %d", len(run.Tool.Driver.Rules), len(run.Results))
+	}
+	if result := run.Results[4]; result.RuleIndex != 0 || result.Level != "error" {
+		t.Errorf("expected repeated finding to reuse rule 0 as an error, got %+v", result)
+	}
+	if rule := run.Tool.Driver.Rules[0]; rule.HelpURI != recs[0].Link || rule.Properties.SecuritySeverity != "8.0" {
+		t.Errorf("unexpected rule %+v", rule)
+	}
+	if level := run.Results[2].Level; level != "note" {
+		t.Errorf("expected Low severity to map to note, got %q", level)
+	}
+	if uri := run.Results[1].Locations[0].PhysicalLocation.ArtifactLocation.URI; uri != "https://10.0.0.1:6443" {
+		t.Errorf("expected results to point at the cluster, got %q", uri)
+	}
+
+	var junit bytes.Buffer
+	if err := WriteJUnit(&junit, recs, "medium"); err != nil {
+		t.Fatalf("failed to write JUnit: %v", err)
+	}
+	if !strings.HasPrefix(junit.String(), "<?xml") {
+		t.Errorf("expected an XML header")
+	}
+
+	var suites junitTestSuites
+	if err := xml.Unmarshal(junit.Bytes(), &suites); err != nil {
+		t.Fatalf("failed to parse JUnit: %v", err)
+	}
+	if suites.Tests != 4 || suites.Failures != 3 || suites.Skipped != 1 {
+		t.Errorf("expected 4 tests, 3 failures and 1 skipped, got %d, %d and %d", suites.Tests, suites.Failures, suites.Skipped)
+	}
+	if len(suites.Suites) != 3 || suites.Suites[0].Name != "Component" || suites.Suites[2].Name != "Workload" {
+		t.Fatalf("expected suites sorted by type, got %+v", suites.Suites)
+	}
+	workload := suites.Suites[2]
+	if workload.Cases[0].Failure == nil || workload.Cases[0].Failure.Type != "Medium" {
+		t.Errorf("expected Medium finding to fail, got %+v", workload.Cases[0])
+	}
+	if workload.Cases[1].Skipped == nil || workload.Cases[1].Failure != nil {
+		t.Errorf("expected Low finding to be skipped, got %+v", workload.Cases[1])
+	}
+	if !strings.Contains(suites.Suites[1].Cases[0].Failure.Text, "Action: Add a tls section.") {
+		t.Errorf("expected failure details to include the action")
+	}
+}
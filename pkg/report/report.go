@@ -0,0 +1,301 @@
+package report
+
+import (
+	_ "embed"
+	"fmt"
+	htmltemplate "html/template"
+	"io"
+	"os"
+	"sort"
+	"strings"
+	texttemplate "text/template"
+	"time"
+
+	"k8s-cli/pkg/kubernetes"
+	"k8s-cli/pkg/recommendations"
+)
+
+//go:embed report.html.tmpl
+var defaultHTMLTemplate string
+
+//go:embed report.md.tmpl
+var defaultMarkdownTemplate string
+
+// Data is everything the `all` command shows. Sections that could not be
+// collected are nil or empty and listed in Warnings.
+type Data struct {
+	Title           string
+	GeneratedAt     time.Time
+	Server          string
+	ClusterVersion  *kubernetes.ClusterInfo
+	Components      []kubernetes.ComponentInfo
+	Summary         *kubernetes.SimpleClusterSummary
+	Nodes           []kubernetes.SimpleNodeInfo
+	ClusterMetrics  *kubernetes.ClusterMetrics
+	NodeMetrics     []kubernetes.NodeMetrics
+	CostAnalysis    *kubernetes.CostAnalysis
+	Workload        *kubernetes.WorkloadAnalysis
+	CriticalEvents  []kubernetes.ClusterEvent
+	Recommendations []recommendations.Recommendation
+	Warnings        []string
+}
+
+type WorkloadRow struct {
+	Kind        string
+	Name        string
+	Namespace   string
+	Status      string
+	HealthScore int
+	Issues      []string
+}
+
+type ChartPoint struct {
+	Label string
+	Value float64
+}
+
+var Formats = []string{"html", "markdown"}
+
+// DefaultTemplate returns the built-in template for a format, as a starting
+// point for an override.
+func DefaultTemplate(format string) (string, error) {
+	switch format {
+	case "html":
+		return defaultHTMLTemplate, nil
+	case "markdown", "md":
+		return defaultMarkdownTemplate, nil
+	}
+	return "", fmt.Errorf("unsupported report format %q (use %s)", format, strings.Join(Formats, " or "))
+}
+
+// Render writes the report in the given format. templatePath overrides the
+// built-in template; it is executed with the same Data and functions.
+func Render(w io.Writer, format string, data *Data, templatePath string) error {
+	source, err := DefaultTemplate(format)
+	if err != nil {
+		return err
+	}
+	if templatePath != "" {
+		content, err := os.ReadFile(templatePath)
+		if err != nil {
+			return fmt.Errorf("failed to read template %s: %w", templatePath, err)
+		}
+		source = string(content)
+	}
+
+	// html/template escapes everything it renders; markdown is plain text
+	if format == "html" {
+		tmpl, err := htmltemplate.New("report").Funcs(htmltemplate.FuncMap(templateFuncs())).Funcs(htmltemplate.FuncMap{"barChart": barChartSVG}).Parse(source)
+		if err != nil {
+			return fmt.Errorf("failed to parse template: %w", err)
+		}
+		return tmpl.Execute(w, data)
+	}
+
+	tmpl, err := texttemplate.New("report").Funcs(texttemplate.FuncMap(templateFuncs())).Funcs(texttemplate.FuncMap{"barChart": barChartText}).Parse(source)
+	if err != nil {
+		return fmt.Errorf("failed to parse template: %w", err)
+	}
+	return tmpl.Execute(w, data)
+}
+
+func templateFuncs() map[string]interface{} {
+	return map[string]interface{}{
+		"money":              func(value float64) string { return fmt.Sprintf("$%.2f", value) },
+		"percent":            func(value float64) string { return fmt.Sprintf("%.1f%%", value) },
+		"join":               strings.Join,
+		"date":               func(t time.Time) string { return t.Format("2006-01-02 15:04:05") },
+		"cell":               markdownCell,
+		"lower":              strings.ToLower,
+		"severityCounts":     severityCounts,
+		"workloadRows":       workloadRows,
+		"totalSavings":       totalSavings,
+		"namespaceCostChart": namespaceCostChart,
+		"nodeCPUChart": func(nodes []kubernetes.NodeMetrics) []ChartPoint {
+			return nodeUtilizationChart(nodes, false)
+		},
+		"nodeMemoryChart": func(nodes []kubernetes.NodeMetrics) []ChartPoint {
+			return nodeUtilizationChart(nodes, true)
+		},
+	}
+}
+
+// markdownCell keeps a value on one table row.
+func markdownCell(value string) string {
+	value = strings.ReplaceAll(value, "|", "\\|")
+	return strings.Join(strings.Fields(value), " ")
+}
+
+// workloadRows flattens the workload analysis into one table, least healthy
+// first.
+func workloadRows(analysis *kubernetes.WorkloadAnalysis) []WorkloadRow {
+	if analysis == nil {
+		return nil
+	}
+
+	var rows []WorkloadRow
+	for _, deploy := range analysis.DeploymentAnalysis {
+		rows = append(rows, WorkloadRow{"Deployment", deploy.Name, deploy.Namespace, deploy.Status, deploy.HealthScore, deploy.Issues})
+	}
+	for _, ss := range analysis.StatefulSetAnalysis {
+		rows = append(rows, WorkloadRow{"StatefulSet", ss.Name, ss.Namespace, ss.Status, ss.HealthScore, ss.Issues})
+	}
+	for _, ds := range analysis.DaemonSetAnalysis {
+		rows = append(rows, WorkloadRow{"DaemonSet", ds.Name, ds.Namespace, ds.Status, ds.HealthScore, ds.Issues})
+	}
+	for _, job := range analysis.JobAnalysis {
+		rows = append(rows, WorkloadRow{"Job", job.Name, job.Namespace, job.Status, job.HealthScore, job.Issues})
+	}
+	for _, cronJob := range analysis.CronJobAnalysis {
+		rows = append(rows, WorkloadRow{"CronJob", cronJob.Name, cronJob.Namespace, cronJob.Status, cronJob.HealthScore, cronJob.Issues})
+	}
+
+	sort.SliceStable(rows, func(i, j int) bool {
+		return rows[i].HealthScore < rows[j].HealthScore
+	})
+	return rows
+}
+
+func totalSavings(analysis *kubernetes.CostAnalysis) float64 {
+	if analysis == nil {
+		return 0
+	}
+
+	total := 0.0
+	for _, resource := range analysis.UnderutilizedResources {
+		total += resource.EstimatedSavings
+	}
+	return total
+}
+
+func severityCounts(recs []recommendations.Recommendation) []ChartPoint {
+	counts := make(map[string]float64)
+	for _, rec := range recs {
+		counts[rec.Severity]++
+	}
+
+	var points []ChartPoint
+	for _, severity := range []string{"High", "Medium", "Low"} {
+		if counts[severity] > 0 {
+			points = append(points, ChartPoint{Label: severity, Value: counts[severity]})
+		}
+	}
+	return points
+}
+
+// namespaceCostChart returns the ten most expensive namespaces.
+func namespaceCostChart(analysis *kubernetes.CostAnalysis) []ChartPoint {
+	if analysis == nil {
+		return nil
+	}
+
+	var points []ChartPoint
+	for _, namespace := range analysis.NamespaceCosts {
+		points = append(points, ChartPoint{Label: namespace.Name, Value: namespace.MonthlyCost})
+	}
+	sort.SliceStable(points, func(i, j int) bool {
+		return points[i].Value > points[j].Value
+	})
+	if len(points) > 10 {
+		points = points[:10]
+	}
+	return points
+}
+
+func nodeUtilizationChart(nodes []kubernetes.NodeMetrics, memory bool) []ChartPoint {
+	var points []ChartPoint
+	for _, node := range nodes {
+		value := node.CPUUsagePercent
+		if memory {
+			value = node.MemoryUsagePercent
+		}
+		points = append(points, ChartPoint{Label: node.Name, Value: value})
+	}
+	return points
+}
+
+const (
+	chartLabelWidth = 160
+	chartBarWidth   = 360
+	chartRowHeight  = 22
+)
+
+// barChartSVG renders a horizontal bar chart as inline SVG so the report
+// needs no external assets.
+func barChartSVG(title, unit string, points []ChartPoint) htmltemplate.HTML {
+	if len(points) == 0 {
+		return ""
+	}
+
+	maxValue := 0.0
+	for _, point := range points {
+		if point.Value > maxValue {
+			maxValue = point.Value
+		}
+	}
+	if maxValue <= 0 {
+		maxValue = 1
+	}
+
+	height := len(points)*chartRowHeight + 30
+	width := chartLabelWidth + chartBarWidth + 90
+
+	var svg strings.Builder
+	fmt.Fprintf(&svg, `<svg class="chart" xmlns="http://www.w3.org/2000/svg" width="%d" height="%d" role="img" aria-label="%s">`, width, height, htmltemplate.HTMLEscapeString(title))
+	fmt.Fprintf(&svg, `<text x="0" y="16" class="chart-title">%s</text>`, htmltemplate.HTMLEscapeString(title))
+	for i, point := range points {
+		y := 26 + i*chartRowHeight
+		barWidth := int(point.Value / maxValue * chartBarWidth)
+		fmt.Fprintf(&svg, `<text x="0" y="%d" class="chart-label">%s</text>`, y+14, htmltemplate.HTMLEscapeString(truncateLabel(point.Label)))
+		fmt.Fprintf(&svg, `<rect x="%d" y="%d" width="%d" height="%d" rx="3" class="chart-bar"><title>%s: %s</title></rect>`,
+			chartLabelWidth, y, barWidth, chartRowHeight-6, htmltemplate.HTMLEscapeString(point.Label), formatChartValue(point.Value, unit))
+		fmt.Fprintf(&svg, `<text x="%d" y="%d" class="chart-value">%s</text>`, chartLabelWidth+barWidth+6, y+14, formatChartValue(point.Value, unit))
+	}
+	svg.WriteString(`</svg>`)
+
+	return htmltemplate.HTML(svg.String())
+}
+
+// barChartText is the Markdown counterpart of barChartSVG, drawn with block
+// characters inside a code block.
+func barChartText(title, unit string, points []ChartPoint) string {
+	if len(points) == 0 {
+		return ""
+	}
+
+	maxValue := 0.0
+	for _, point := range points {
+		if point.Value > maxValue {
+			maxValue = point.Value
+		}
+	}
+	if maxValue <= 0 {
+		maxValue = 1
+	}
+
+	var chart strings.Builder
+	fmt.Fprintf(&chart, "```text\n%s\n", title)
+	for _, point := range points {
+		fmt.Fprintf(&chart, "%-24s %-30s %s\n", truncateLabel(point.Label), strings.Repeat("█", int(point.Value/maxValue*30)), formatChartValue(point.Value, unit))
+	}
+	chart.WriteString("```")
+
+	return chart.String()
+}
+
+func formatChartValue(value float64, unit string) string {
+	switch unit {
+	case "$":
+		return fmt.Sprintf("$%.2f", value)
+	case "%":
+		return fmt.Sprintf("%.1f%%", value)
+	}
+	return fmt.Sprintf("%g", value)
+}
+
+func truncateLabel(label string) string {
+	if runes := []rune(label); len(runes) > 24 {
+		return string(runes[:21]) + "..."
+	}
+	return label
+}
@@ -0,0 +1,113 @@
+package report
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+	"unicode/utf8"
+
+	"k8s-cli/pkg/kubernetes"
+	"k8s-cli/pkg/recommendations"
+)
+
+func sampleData() *Data {
+	return &Data{
+		Title:          "Prod Report",
+		GeneratedAt:    time.Date(2025, 6, 1, 8, 0, 0, 0, time.UTC),
+		ClusterVersion: &kubernetes.ClusterInfo{GitVersion: "v1.30.1", Platform: "linux/amd64"},
+		Components:     []kubernetes.ComponentInfo{{Name: "ingress-nginx", Namespace: "ingress", Status: "Running", Version: "1.10.1"}},
+		Summary:        &kubernetes.SimpleClusterSummary{TotalNodes: 2, TotalPods: 30, TotalCPUCapacity: "8", TotalMemCapacity: "32Gi"},
+		ClusterMetrics: &kubernetes.ClusterMetrics{CPUUsagePercent: 42.5, MemoryUsagePercent: 61},
+		NodeMetrics:    []kubernetes.NodeMetrics{{Name: "node-a", CPUUsagePercent: 80, MemoryUsagePercent: 40}, {Name: "node-b", CPUUsagePercent: 20, MemoryUsagePercent: 70}},
+		CostAnalysis: &kubernetes.CostAnalysis{
+			TotalMonthlyCost:       420,
+			NamespaceCosts:         []kubernetes.NamespaceCost{{Name: "prod", MonthlyCost: 300}, {Name: "staging", MonthlyCost: 120}},
+			UnderutilizedResources: []kubernetes.UnderutilizedResource{{Name: "api", EstimatedSavings: 25}, {Name: "web", EstimatedSavings: 15}},
+		},
+		Workload: &kubernetes.WorkloadAnalysis{
+			DeploymentAnalysis: []kubernetes.DeploymentHealth{{Name: "api", Namespace: "prod", Status: "Healthy", HealthScore: 100}},
+			CronJobAnalysis:    []kubernetes.CronJobHealth{{Name: "backup", Namespace: "prod", Status: "Critical", HealthScore: 40, Issues: []string{"3 consecutive failed runs", "Missed 2 | runs"}}},
+			WorkloadSummary:    kubernetes.WorkloadSummary{OverallHealthScore: 70, CriticalIssues: 1},
+		},
+		CriticalEvents:  []kubernetes.ClusterEvent{{Object: "Pod/api-1", Namespace: "prod", Reason: "FailedMount", Message: "<script>alert(1)</script>", Count: 3}},
+		Recommendations: []recommendations.Recommendation{{Type: "Security", Severity: "High", Title: "Privileged pods"}, {Type: "Resource", Severity: "Low", Title: "Missing requests"}},
+		Warnings:        []string{"Metrics: metrics server unavailable"},
+	}
+}
+
+func TestRenderReport(t *testing.T) {
+	var html bytes.Buffer
+	if err := Render(&html, "html", sampleData(), ""); err != nil {
+		t.Fatalf("failed to render HTML: %v", err)
+	}
+	output := html.String()
+
+	for _, expected := range []string{
+		"<title>Prod Report</title>",
+		"v1.30.1",
+		"ingress-nginx",
+		`<table class="sortable">`,
+		"<svg class=\"chart\"",
+		"Monthly cost by namespace (top 10)",
+		"$40.00", // potential savings
+		`<td class="status-critical">Critical</td>`,
+		"3 consecutive failed runs",
+		"&lt;script&gt;alert(1)&lt;/script&gt;",
+		"metrics server unavailable",
+	} {
+		if !strings.Contains(output, expected) {
+			t.Errorf("expected HTML report to contain %q", expected)
+		}
+	}
+	if strings.Contains(output, "<script>alert(1)") {
+		t.Errorf("expected event messages to be escaped")
+	}
+	// The least healthy workload is listed first
+	if strings.Index(output, "backup") > strings.Index(output, ">api<") {
+		t.Errorf("expected workloads sorted by health score")
+	}
+
+	var markdown bytes.Buffer
+	if err := Render(&markdown, "markdown", sampleData(), ""); err != nil {
+		t.Fatalf("failed to render Markdown: %v", err)
+	}
+	output = markdown.String()
+
+	for _, expected := range []string{
+		"# Prod Report",
+		"| v1.30.1 | 2 | 30 | $420.00 | 70/100 | 2 |",
+		"| CronJob | backup | prod | Critical | 40/100 | 3 consecutive failed runs; Missed 2 \\| runs |",
+		"prod                     ██████████████████████████████ $300.00",
+		"| High | Security | Privileged pods |",
+	} {
+		if !strings.Contains(output, expected) {
+			t.Errorf("expected Markdown report to contain %q", expected)
+		}
+	}
+	if strings.Contains(output, "<svg") {
+		t.Errorf("expected no SVG in the Markdown report")
+	}
+
+	override := filepath.Join(t.TempDir(), "custom.tmpl")
+	if err := os.WriteFile(override, []byte(`{{.Title}}: {{money .CostAnalysis.TotalMonthlyCost}} across {{len .Components}} components`), 0644); err != nil {
+		t.Fatal(err)
+	}
+	var custom bytes.Buffer
+	if err := Render(&custom, "markdown", sampleData(), override); err != nil {
+		t.Fatalf("failed to render override template: %v", err)
+	}
+	if custom.String() != "Prod Report: $420.00 across 1 components" {
+		t.Errorf("unexpected override output %q", custom.String())
+	}
+
+	if err := Render(&custom, "pdf", sampleData(), ""); err == nil {
+		t.Errorf("expected an unsupported format to fail")
+	}
+
+	if label := truncateLabel(strings.Repeat("ü", 30)); !utf8.ValidString(label) || utf8.RuneCountInString(label) != 24 {
+		t.Errorf("expected labels truncated to 24 runes, got %q", label)
+	}
+}